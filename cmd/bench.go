@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"dependency-hell-cli/internal/output"
+	"dependency-hell-cli/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchRuns    int
+	benchWorkers int
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "_bench <dir>",
+	Short: "Time sequential vs parallel directory size calculation",
+	Long: `Measure a directory with every size calculation method and print how long
+each took and its throughput. Use it to see what --parallel-walk-depth,
+--workers and --use-du do for your own caches.
+
+Examples:
+  dhell _bench ~/go/pkg/mod
+  dhell _bench ~/.m2/repository --runs 5 --parallel-walk-depth 3
+  dhell _bench ~/.cargo/registry --workers 4`,
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	Run:    runBench,
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+	benchCmd.Flags().IntVar(&benchRuns, "runs", 3, "Runs per method; the fastest is reported")
+	benchCmd.Flags().IntVar(&benchWorkers, "workers", scanner.DefaultWalkWorkers(), "Workers used by the parallel walk (like scan --concurrency)")
+}
+
+func runBench(cmd *cobra.Command, args []string) {
+	path := scanner.ExpandHome(args[0])
+
+	if cmd.Flags().Changed("workers") {
+		if benchWorkers < 1 {
+			fmt.Fprintln(os.Stderr, "--workers must be at least 1")
+			os.Exit(1)
+		}
+		scanner.SetWalkWorkers(benchWorkers)
+	}
+
+	// No size cache: every run has to walk the tree
+	ctx, cancel := commandContext(context.Background(), nil)
+	defer cancel()
+
+	timings, err := scanner.BenchmarkDirSize(ctx, path, benchRuns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Print(output.RenderBenchmark(path, timings))
+}
@@ -1,22 +1,42 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"dependency-hell-cli/internal/cleaner"
 	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/lock"
 	"dependency-hell-cli/internal/output"
-	"dependency-hell-cli/internal/providers"
+	"dependency-hell-cli/internal/scanner"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 )
 
 var (
-	dryRun bool
-	force  bool
+	dryRun      bool
+	force       bool
+	forceUnlock bool
+	cleanLocal  string
+	cleanBudget string
+	cleanScript bool
+	cleanTemp   bool
+	cleanAge    time.Duration
+	cleanSkip   []string
 )
 
+// defaultTempAge is how long a temp leftover must be untouched before it may be removed
+const defaultTempAge = 24 * time.Hour
+
 var cleanCmd = &cobra.Command{
 	Use:   "clean <language>",
 	Short: "Clean caches for a specific language",
@@ -31,7 +51,14 @@ Examples:
   dhell clean go                   # Clean Go caches
   dhell clean node --dry-run       # Preview Node.js cleaning
   dhell clean java --force         # Clean Java without confirmation
-  dhell clean all                  # Clean all languages`,
+  dhell clean all                  # Clean all languages
+  dhell clean go --local .         # Remove Go profiles and test binaries from a project
+  dhell clean java --local .       # Remove Gradle .gradle caches and build outputs from a project
+  dhell clean python --local .     # Remove build/, dist/ and *.egg-info from a Python package
+  dhell clean all --temp           # Remove temp leftovers and crash logs untouched for a day
+  dhell clean java --skip "Maven Repository"  # Clean everything but the Maven repository
+  dhell clean all --budget 10GB    # Clean the largest safe caches until ~10GB is freed
+  dhell clean all --script > clean.sh  # Write the clean as a shell script to review and run later`,
 	Args: cobra.ExactArgs(1),
 	Run:  runClean,
 }
@@ -40,59 +67,371 @@ func init() {
 	rootCmd.AddCommand(cleanCmd)
 	cleanCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview what would be deleted without actually deleting")
 	cleanCmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompts (use with caution)")
+	cleanCmd.Flags().StringVar(&cleanLocal, "local", "", "Clean build artifacts in a project directory instead of global caches")
+	cleanCmd.Flags().BoolVar(&cleanTemp, "temp", false, "Clean leftovers of compilers and package managers in the temp directory, and crash logs, instead of global caches")
+	cleanCmd.Flags().DurationVar(&cleanAge, "older-than", defaultTempAge, "With --temp, only remove leftovers modified longer ago than this")
+	cleanCmd.Flags().StringVar(&cleanBudget, "budget", "", "Only clean the largest safe items until this much is reclaimed (e.g. 10GB)")
+	cleanCmd.Flags().BoolVar(&cleanScript, "script", false, "Print a shell script of the removals instead of cleaning")
+	cleanCmd.Flags().BoolVar(&forceUnlock, "force-unlock", false, "Remove a leftover clean lock before starting")
+	cleanCmd.Flags().StringArrayVar(&cleanSkip, "skip", nil, "Leave out items with this description, case-insensitively (repeatable, e.g. --skip \"Maven Repository\")")
 }
 
 func runClean(cmd *cobra.Command, args []string) {
 	language := strings.ToLower(args[0])
 
 	// Initialize all providers
-	allProviders := []core.LanguageProvider{
-		providers.NewGoProvider(),
-		providers.NewNodeProvider(),
-		providers.NewJavaProvider(),
-		providers.NewPythonProvider(),
-		providers.NewPHPProvider(),
-		providers.NewRustProvider(),
-	}
+	allProviders := newProviders()
 
 	// Select providers based on language argument
 	var selectedProviders []core.LanguageProvider
 	if language == "all" {
 		selectedProviders = allProviders
-	} else {
-		for _, provider := range allProviders {
-			providerName := strings.ToLower(provider.Name())
-			if strings.Contains(providerName, language) {
-				selectedProviders = append(selectedProviders, provider)
-				break
-			}
-		}
+	} else if provider := findProvider(allProviders, language); provider != nil {
+		selectedProviders = append(selectedProviders, provider)
 	}
 
 	if len(selectedProviders) == 0 {
 		fmt.Printf("Unknown language: %s\n", language)
-		fmt.Println("Supported languages: go, node, java, python, php, rust, all")
+		fmt.Printf("Supported languages: %s, all\n", supportedLanguages)
+		return
+	}
+
+	var budget uint64
+	if cleanBudget != "" {
+		parsed, err := humanize.ParseBytes(cleanBudget)
+		if err != nil || parsed == 0 {
+			fmt.Printf("Invalid --budget %q: use a size such as 500MB or 10GB\n", cleanBudget)
+			return
+		}
+		budget = parsed
+	}
+
+	if cleanLocal != "" && cleanTemp {
+		fmt.Println("--local and --temp can't be used together")
+		return
+	}
+
+	if cleanLocal != "" {
+		root, err := projectRoot(cleanLocal)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		cleanLocal = root
+	}
+
+	// Only one clean may modify caches at a time; previews and scripts don't need the lock
+	if !dryRun && !cleanScript {
+		cleanLock, err := acquireCleanLock()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer cleanLock.Release()
+	}
+
+	// Avoid walking the same directory twice within this command
+	sizeCache := scanner.NewSizeCache()
+	ctx, cancel := commandContext(context.Background(), sizeCache)
+	defer cancel()
+
+	// Gather cleanable items for all selected providers concurrently
+	var leftovers []scanner.TempLeftover
+	if cleanTemp {
+		leftovers = scanner.ScanTempLeftoversAndCrashLogs()
+	}
+	targets := gatherCleanTargets(ctx, selectedProviders, leftovers)
+
+	// Keep stdout for the script itself when printing one
+	var notices io.Writer = os.Stdout
+	if cleanScript {
+		notices = os.Stderr
+	}
+
+	// Apply the user's safe/unsafe overrides from the config file
+	for _, target := range targets {
+		for _, warning := range cfg.ApplySafeOverrides(target.provider.Name(), target.items) {
+			fmt.Fprintf(notices, "⚠️  %s\n", warning)
+		}
+	}
+
+	// Leave out the items the user skipped before anything is previewed, budgeted or cleaned
+	if len(cleanSkip) > 0 {
+		targets = skipCleanItems(notices, targets, cleanSkip)
+	}
+
+	// Providers may share cache directories, and one provider's items may nest; clean each path
+	// only once. Skipped items are already gone, so nothing they contain is dropped with them.
+	dedupeCleanTargets(targets)
+
+	// Keep only the largest safe items needed to reach the budget
+	if budget > 0 {
+		targets = applyBudget(notices, targets, int64(budget))
+	}
+
+	if cleanScript {
+		writeCleanScript(os.Stdout, targets)
+		return
+	}
+
+	// Confirm each provider's items in turn, then clean the confirmed ones concurrently
+	var (
+		jobs      []cleaner.Job
+		confirmed []cleanTarget
+	)
+	for _, target := range targets {
+		if confirmCleanTarget(target) {
+			if verbose && !dryRun {
+				fmt.Printf("Cleaning %s...\n", target.provider.Name())
+			}
+			jobs = append(jobs, cleaner.Job{Provider: target.provider, Items: target.items})
+			confirmed = append(confirmed, target)
+		}
+	}
+	outcomes, total := cleaner.CleanConcurrently(ctx, jobs, dryRun, runtime.NumCPU())
+
+	for i, outcome := range outcomes {
+		printCleanOutcome(confirmed[i].provider, outcome)
+		invalidateCleanedSizes(sizeCache, confirmed[i].items)
+	}
+
+	if len(targets) > 1 {
+		printCleanTotal(total)
+	}
+}
+
+// printCleanTotal summarizes a clean of several providers
+func printCleanTotal(total *core.CleanResult) {
+	if total.ItemsCleaned == 0 {
 		return
 	}
 
-	// Clean each selected provider
-	for _, provider := range selectedProviders {
-		if err := cleanProvider(provider); err != nil {
-			fmt.Printf("Error cleaning %s: %v\n", provider.Name(), err)
+	verb := "Cleaned"
+	if total.DryRun {
+		verb = "Would clean"
+	}
+	fmt.Printf("%s %d item(s) across all languages, %s in total", verb, total.ItemsCleaned, output.FormatBytes(total.SpaceReclaimed))
+	if len(total.Errors) > 0 {
+		fmt.Printf(" (%d error(s))", len(total.Errors))
+	}
+	fmt.Println()
+}
+
+// skipCleanItems removes the items the user skipped from targets.
+// Skips that match nothing are reported to w, since they usually mean a typo.
+func skipCleanItems(w io.Writer, targets []cleanTarget, skip []string) []cleanTarget {
+	groups := make([][]core.CleanableItem, len(targets))
+	for i, target := range targets {
+		groups[i] = target.items
+	}
+
+	kept, unmatched := cleaner.SkipItems(groups, skip)
+	for i, items := range kept {
+		targets[i].items = items
+	}
+	for _, description := range unmatched {
+		fmt.Fprintf(w, "⚠️  --skip %q matched no cleanable item\n", description)
+	}
+	return targets
+}
+
+// applyBudget greedily picks safe items until their total reaches budget: the smallest item
+// that covers what is left when there is one, the largest remaining item otherwise.
+// Providers left without items are dropped, and the selection is summarized to w.
+func applyBudget(w io.Writer, targets []cleanTarget, budget int64) []cleanTarget {
+	type candidate struct {
+		target int
+		item   core.CleanableItem
+	}
+
+	var candidates []candidate
+	for i, target := range targets {
+		for _, item := range target.items {
+			if item.Safe && item.Size > 0 {
+				candidates = append(candidates, candidate{i, item})
+			}
 		}
 	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].item.Size > candidates[j].item.Size
+	})
+
+	chosen := make([][]core.CleanableItem, len(targets))
+	var selected int64
+	count := 0
+	for selected < budget && len(candidates) > 0 {
+		// Candidates are sorted largest first, so the last one covering the rest is the smallest
+		pick := 0
+		for i, c := range candidates {
+			if c.item.Size >= budget-selected {
+				pick = i
+			}
+		}
+
+		c := candidates[pick]
+		candidates = append(candidates[:pick], candidates[pick+1:]...)
+		chosen[c.target] = append(chosen[c.target], c.item)
+		selected += c.item.Size
+		count++
+	}
+
+	var budgeted []cleanTarget
+	for i, target := range targets {
+		if len(chosen[i]) == 0 && target.err == nil {
+			continue
+		}
+		target.items = chosen[i]
+		budgeted = append(budgeted, target)
+	}
+
+	fmt.Fprintf(w, "Budget %s: selected %d safe item(s) totalling %s\n", output.FormatBytes(budget), count, output.FormatBytes(selected))
+	if selected < budget {
+		fmt.Fprintln(w, "⚠️  Safe items can't reach the budget; unsafe items are never selected by --budget")
+	}
+	fmt.Fprintln(w)
+
+	return budgeted
 }
 
-func cleanProvider(provider core.LanguageProvider) error {
-	// Get cleanable items
-	items, err := provider.GetCleanableItems()
+// acquireCleanLock takes the lock guarding clean operations, honoring --force-unlock
+func acquireCleanLock() (*lock.Lock, error) {
+	path := lock.DefaultPath()
+	if forceUnlock {
+		if err := lock.ForceUnlock(path); err != nil && !errors.Is(err, lock.ErrLocked) {
+			return nil, fmt.Errorf("failed to remove lock %s: %w", path, err)
+		}
+	}
+
+	cleanLock, err := lock.Acquire(path)
+	if errors.Is(err, lock.ErrLocked) {
+		message := "Another clean is already running"
+		if holder, err := lock.ReadHolder(path); err == nil {
+			message += fmt.Sprintf(" (pid %d, started %s)", holder.PID, holder.Acquired.Local().Format("15:04:05"))
+		}
+		// A crashed clean's flock is released by the kernel, so on Unix the holder is still running
+		if lock.ReleasedOnExit {
+			return nil, fmt.Errorf("%s; wait for it to finish", message)
+		}
+		return nil, fmt.Errorf("%s; if it is no longer running, retry with --force-unlock", message)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get cleanable items: %w", err)
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", path, err)
+	}
+
+	return cleanLock, nil
+}
+
+// cleanTarget holds the cleanable items gathered for a provider
+type cleanTarget struct {
+	provider core.LanguageProvider
+	items    []core.CleanableItem
+	err      error
+}
+
+// gatherCleanTargets gets cleanable items for all providers concurrently, taking --temp items
+// from the given leftovers. Results keep the order of the given providers so output stays deterministic.
+func gatherCleanTargets(ctx context.Context, providers []core.LanguageProvider, leftovers []scanner.TempLeftover) []cleanTarget {
+	var wg sync.WaitGroup
+	targets := make([]cleanTarget, len(providers))
+	sem := make(chan struct{}, runtime.NumCPU())
+
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(index int, p core.LanguageProvider) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			items, err := cleanableItems(ctx, p, leftovers)
+			targets[index] = cleanTarget{provider: p, items: items, err: err}
+		}(i, provider)
+	}
+
+	wg.Wait()
+	return targets
+}
+
+// cleanableItems returns the project artifacts of a provider with --local, the stale temp
+// leftovers of its tools with --temp, its global caches otherwise
+func cleanableItems(ctx context.Context, provider core.LanguageProvider, leftovers []scanner.TempLeftover) ([]core.CleanableItem, error) {
+	if cleanTemp {
+		return staleTempItems(leftovers, provider.Name()), nil
+	}
+	if cleanLocal == "" {
+		return provider.GetCleanableItems(ctx)
+	}
+	if projectScanner, ok := provider.(core.ProjectScanner); ok {
+		return projectScanner.ScanProject(ctx, cleanLocal), nil
+	}
+	return nil, nil
+}
+
+// staleTempItems returns the leftovers and crash logs of a language's tools untouched for longer than --older-than.
+// Recent leftovers may belong to a build that is still running, so they are never returned.
+func staleTempItems(leftovers []scanner.TempLeftover, language string) []core.CleanableItem {
+	var items []core.CleanableItem
+	for _, leftover := range leftovers {
+		if leftover.Language != language || leftover.Age() <= cleanAge {
+			continue
+		}
+		description := leftover.Tool
+		if leftover.Kind == scanner.KindTempLeftover {
+			description += " Temp Leftover"
+		}
+		items = append(items, core.CleanableItem{
+			Path:        leftover.Path,
+			Description: description,
+			Size:        leftover.Size,
+			Safe:        !leftover.Unsafe,
+			Reclaim:     core.ReclaimExact,
+			RebuildCost: "none; left behind by runs that have finished",
+		})
+	}
+	return items
+}
+
+// dedupeCleanTargets removes cleanable items that overlap with another item, of the same provider or another
+func dedupeCleanTargets(targets []cleanTarget) {
+	groups := make([][]core.CleanableItem, len(targets))
+	for i, target := range targets {
+		groups[i] = target.items
+	}
+
+	for i, items := range cleaner.DedupeOverlapping(groups) {
+		targets[i].items = items
+	}
+}
+
+// invalidateCleanedSizes drops cached sizes that may have changed by cleaning items.
+// Command-based items can touch any path, so they reset the whole cache.
+func invalidateCleanedSizes(cache *scanner.SizeCache, items []core.CleanableItem) {
+	for _, item := range items {
+		if item.Path == "" {
+			cache.Reset()
+			return
+		}
+		cache.Invalidate(item.Path)
+	}
+}
+
+// confirmCleanTarget reports whether a provider's items should be cleaned, asking the user
+// unless this is a dry run or --force is set
+func confirmCleanTarget(target cleanTarget) bool {
+	provider := target.provider
+	items := target.items
+	if target.err != nil {
+		fmt.Printf("Error cleaning %s: failed to get cleanable items: %v\n", provider.Name(), target.err)
+		return false
 	}
 
 	if len(items) == 0 {
 		fmt.Printf("No cleanable items found for %s\n", provider.Name())
-		return nil
+		return false
+	}
+
+	// Dry-run mode: the provider checks the items without deleting, then clean previews them
+	if dryRun || force {
+		return true
 	}
 
 	// Calculate total size
@@ -101,13 +440,6 @@ func cleanProvider(provider core.LanguageProvider) error {
 		totalSize += item.Size
 	}
 
-	// Dry-run mode: just show preview
-	if dryRun {
-		preview := output.RenderCleanPreview(provider.Name(), items)
-		fmt.Println(preview)
-		return nil
-	}
-
 	// Check for unsafe items
 	hasUnsafeItems := false
 	for _, item := range items {
@@ -117,33 +449,105 @@ func cleanProvider(provider core.LanguageProvider) error {
 		}
 	}
 
-	// Show confirmation unless --force is used
-	if !force {
-		if hasUnsafeItems {
-			fmt.Println()
-			fmt.Println("⚠️  WARNING: Some items require careful consideration!")
-			fmt.Println()
-		}
+	if hasUnsafeItems {
+		fmt.Println()
+		fmt.Println("⚠️  WARNING: Some items require careful consideration!")
+		fmt.Println()
+	}
+
+	if !cleaner.ConfirmClean(items, totalSize) {
+		fmt.Printf("Cleaning %s cancelled.\n", provider.Name())
+		return false
+	}
+	return true
+}
 
-		if !cleaner.ConfirmClean(items, totalSize) {
-			fmt.Println("Cleaning cancelled.")
-			return nil
+// printCleanOutcome shows what cleaning a provider did, or the preview of a dry run
+func printCleanOutcome(provider core.LanguageProvider, outcome cleaner.Outcome) {
+	if outcome.Err != nil {
+		verb := "cleaning failed"
+		if dryRun {
+			verb = "dry-run failed"
 		}
+		fmt.Printf("Error cleaning %s: %s: %v\n", provider.Name(), verb, outcome.Err)
+		return
 	}
 
-	// Execute cleaning
-	if verbose {
-		fmt.Printf("Cleaning %s...\n", provider.Name())
+	if dryRun {
+		fmt.Println(output.RenderCleanPreview(provider.Name(), outcome.Result.Items))
+		return
 	}
+	fmt.Println(output.RenderCleanResult(outcome.Result))
+}
 
-	result, err := provider.Clean(items)
-	if err != nil {
-		return fmt.Errorf("cleaning failed: %w", err)
+// writeCleanScript writes a shell script doing what clean would do for the targets.
+// Unsafe items are commented out unless --force is set, since clean would ask before removing them.
+func writeCleanScript(w io.Writer, targets []cleanTarget) {
+	fmt.Fprintln(w, "#!/bin/sh")
+	fmt.Fprintln(w, "# Generated by dhell clean; review before running")
+
+	for _, target := range targets {
+		fmt.Fprintf(w, "\n# === %s ===\n", target.provider.Name())
+		if target.err != nil {
+			fmt.Fprintf(w, "# Failed to get cleanable items: %v\n", target.err)
+			continue
+		}
+		if len(target.items) == 0 {
+			fmt.Fprintln(w, "# No cleanable items")
+			continue
+		}
+
+		for _, item := range target.items {
+			line := cleanScriptLine(item)
+			if line == "" {
+				continue
+			}
+
+			fmt.Fprintf(w, "\n# %s (%s)\n", item.Description, output.FormatBytes(item.Size))
+			if !item.Safe && !force {
+				fmt.Fprintln(w, "# Unsafe: uncomment to remove")
+				line = "# " + line
+			}
+			fmt.Fprintln(w, line)
+		}
 	}
+}
 
-	// Show results
-	resultOutput := output.RenderCleanResult(result, items)
-	fmt.Println(resultOutput)
+// cleanScriptLine returns the shell command cleaning an item: its command when it has
+// one, otherwise deleting the files matching its patterns or removing its path.
+// Every word is quoted for the shell.
+func cleanScriptLine(item core.CleanableItem) string {
+	if item.Command != "" {
+		var words []string
+		for _, word := range item.CommandArgs() {
+			words = append(words, shellQuote(word))
+		}
+		return strings.Join(words, " ")
+	}
+	if item.Path != "" && len(item.Patterns) > 0 {
+		var names []string
+		for _, pattern := range item.Patterns {
+			names = append(names, "-name "+shellQuote(pattern))
+		}
+		return fmt.Sprintf("find %s -type f \\( %s \\) -delete", shellQuote(scanner.ExpandHome(item.Path)), strings.Join(names, " -o "))
+	}
+	if item.Path != "" {
+		return "rm -rf -- " + shellQuote(scanner.ExpandHome(item.Path))
+	}
+	return ""
+}
 
-	return nil
+// shellQuote single-quotes s unless it only contains characters the shell never interprets
+func shellQuote(s string) string {
+	plain := s != ""
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@%+,", r)) {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
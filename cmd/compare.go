@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dependency-hell-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <machineA.json> <machineB.json>",
+	Short: "Compare the scan exports of two machines",
+	Long: `Show two machines' languages side by side and point out version drift
+and install source differences, for "it works on my machine" debugging.
+
+Create an export on each machine with scan --output json.
+
+Examples:
+  dhell scan --output json > alice.json
+  dhell compare alice.json bob.json`,
+	Args: cobra.ExactArgs(2),
+	Run:  runCompare,
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+}
+
+func runCompare(cmd *cobra.Command, args []string) {
+	a, err := output.ReadScanExport(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	b, err := output.ReadScanExport(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	labelA, labelB := a.Host, b.Host
+	if labelA == "" || labelB == "" || labelA == labelB {
+		labelA, labelB = exportLabel(args[0]), exportLabel(args[1])
+	}
+
+	fmt.Print(output.RenderComparison(labelA, labelB, a, b))
+}
+
+// exportLabel names a machine after its export file when host names don't tell them apart
+func exportLabel(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"dependency-hell-cli/internal/doctor"
+	"dependency-hell-cli/internal/output"
+	"dependency-hell-cli/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorLang    string
+	doctorExplain bool
+	doctorOutput  string
+	doctorFailOn  string
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Detect conflicts and misconfigurations in your environment",
+	Long: `Run a set of checks across installed languages to find dependency hell:
+duplicate installations, conflicting sources and broken configuration.
+
+Examples:
+  dhell doctor                  # Check all languages
+  dhell doctor --lang go,node   # Check Go and Node.js
+  dhell doctor --output json --fail-on high   # Gate CI on high-severity conflicts`,
+	Args: cobra.NoArgs,
+	Run:  runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().StringVarP(&doctorLang, "lang", "l", "", "Filter languages to check (comma-separated: go,node,java)")
+	doctorCmd.Flags().BoolVar(&doctorExplain, "explain", false, "Explain why each install source was classified as it was")
+	doctorCmd.Flags().StringVarP(&doctorOutput, "output", "o", string(output.FormatTable), "Output format: table or json")
+	doctorCmd.Flags().StringVar(&doctorFailOn, "fail-on", "", "Exit with status 1 if a conflict of this severity or higher is found (low, medium, high)")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) {
+	format, err := output.ParseFormat(doctorOutput)
+	if err == nil && (format == output.FormatTSV || format == output.FormatCSV) {
+		err = fmt.Errorf("doctor doesn't support --output %s (want table or json)", format)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var failOn doctor.Severity
+	if doctorFailOn != "" {
+		if failOn, err = doctor.ParseSeverity(doctorFailOn); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	selectedProviders := filterProviders(newProviders(), doctorLang)
+	if len(selectedProviders) == 0 {
+		fmt.Println("No languages selected to check.")
+		return
+	}
+
+	ctx, cancel := commandContext(context.Background(), scanner.NewSizeCache())
+	defer cancel()
+
+	report := doctor.Run(ctx, selectedProviders)
+	if format == output.FormatJSON {
+		rendered, err := output.RenderDoctorJSON(report)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Print(rendered)
+	} else {
+		fmt.Println(output.RenderDoctorReport(report, doctorExplain))
+	}
+
+	if failOn != "" && report.HasConflictAtLeast(failOn) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"dependency-hell-cli/internal/output"
+	"dependency-hell-cli/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	freezeFormat string
+	freezeFile   string
+)
+
+var freezeCmd = &cobra.Command{
+	Use:   "freeze",
+	Short: "Snapshot active language versions to a lockfile",
+	Long: `Write the detected languages and their active versions to a file so the
+toolchain can be reproduced on another machine.
+
+Formats:
+  tool-versions   asdf/mise compatible .tool-versions (default)
+  lock            dhell.lock, which also records the install source
+
+Examples:
+  dhell freeze                     # Write .tool-versions
+  dhell freeze --format lock       # Write dhell.lock
+  dhell freeze --file -            # Print to stdout`,
+	Args: cobra.NoArgs,
+	Run:  runFreeze,
+}
+
+func init() {
+	rootCmd.AddCommand(freezeCmd)
+	freezeCmd.Flags().StringVar(&freezeFormat, "format", "tool-versions", "Output format (tool-versions, lock)")
+	freezeCmd.Flags().StringVar(&freezeFile, "file", "", "File to write (default .tool-versions or dhell.lock, - for stdout)")
+}
+
+func runFreeze(cmd *cobra.Command, args []string) {
+	ctx, cancel := commandContext(context.Background(), scanner.NewSizeCache())
+	defer cancel()
+
+	var entries []output.FreezeEntry
+	for _, provider := range newProviders() {
+		installations, err := provider.DetectInstalled(ctx)
+		if err != nil || len(installations) == 0 {
+			continue
+		}
+		entries = append(entries, output.NewFreezeEntry(provider.Name(), installations[0]))
+	}
+
+	var content, defaultFile string
+	switch freezeFormat {
+	case "tool-versions":
+		content = output.RenderToolVersions(entries)
+		defaultFile = ".tool-versions"
+	case "lock":
+		content = output.RenderLockfile(entries)
+		defaultFile = "dhell.lock"
+	default:
+		fmt.Printf("Unknown format: %s\n", freezeFormat)
+		fmt.Println("Supported formats: tool-versions, lock")
+		return
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No languages detected in your environment.")
+		return
+	}
+
+	file := freezeFile
+	if file == "" {
+		file = defaultFile
+	}
+
+	if file == "-" {
+		fmt.Print(content)
+		return
+	}
+
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		fmt.Printf("Error writing %s: %v\n", file, err)
+		return
+	}
+
+	fmt.Printf("Wrote %d languages to %s\n", len(entries), file)
+}
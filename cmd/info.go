@@ -1,16 +1,41 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/doctor"
 	"dependency-hell-cli/internal/output"
-	"dependency-hell-cli/internal/providers"
+	"dependency-hell-cli/internal/scanner"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	infoExplain   bool
+	infoMaxItems  int
+	infoWatch     bool
+	infoInterval  time.Duration
+	infoBreakdown bool
+)
+
+// Exit statuses of info when the language can't be inspected
+const (
+	exitInfoNotInstalled     = 1 // The language isn't on PATH
+	exitInfoBroken           = 2 // The binary is on PATH but fails to run
+	exitInfoPermissionDenied = 3 // The binary is on PATH but isn't executable by the user
+	exitInfoTimedOut         = 4 // The binary didn't answer in time
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
 var infoCmd = &cobra.Command{
 	Use:   "info <language>",
 	Short: "Show detailed information about a language installation",
@@ -23,49 +48,54 @@ var infoCmd = &cobra.Command{
 Examples:
   dhell info go       # Show Go information
   dhell info node     # Show Node.js information
-  dhell info python   # Show Python information`,
+  dhell info python   # Show Python information
+  dhell info go --explain  # Show why Go's source was classified
+  dhell info go --watch    # Refresh Go's disk usage while a build runs`,
 	Args: cobra.ExactArgs(1),
 	Run:  runInfo,
 }
 
 func init() {
 	rootCmd.AddCommand(infoCmd)
+	infoCmd.Flags().BoolVar(&infoExplain, "explain", false, "Explain why the install source was classified as it was")
+	infoCmd.Flags().IntVar(&infoMaxItems, "max-items", 0, "Show only the N largest cache locations (0 shows all)")
+	infoCmd.Flags().BoolVar(&infoWatch, "watch", false, "Refresh the disk usage in place until Ctrl-C, showing changes per cache")
+	infoCmd.Flags().DurationVar(&infoInterval, "interval", 3*time.Second, "Refresh interval for --watch")
+	infoCmd.Flags().BoolVar(&infoBreakdown, "breakdown", false, "Split caches by what they contain, e.g. Go's build cache into packages, binaries and vet results (reads every file)")
 }
 
 func runInfo(cmd *cobra.Command, args []string) {
 	language := strings.ToLower(args[0])
 
 	// Initialize all providers
-	allProviders := []core.LanguageProvider{
-		providers.NewGoProvider(),
-		providers.NewNodeProvider(),
-		providers.NewJavaProvider(),
-		providers.NewPythonProvider(),
-		providers.NewPHPProvider(),
-		providers.NewRustProvider(),
-	}
+	allProviders := newProviders()
 
 	// Find matching provider
-	var selectedProvider core.LanguageProvider
-	for _, provider := range allProviders {
-		providerName := strings.ToLower(provider.Name())
-		if strings.Contains(providerName, language) {
-			selectedProvider = provider
-			break
-		}
-	}
-
+	selectedProvider := findProvider(allProviders, language)
 	if selectedProvider == nil {
 		fmt.Printf("Unknown language: %s\n", language)
-		fmt.Println("Supported languages: go, node, java, python, php, rust")
+		fmt.Printf("Supported languages: %s\n", supportedLanguages)
 		return
 	}
 
+	ctx, cancel := commandContext(context.Background(), scanner.NewSizeCache())
+	defer cancel()
+
 	// Get installation info
-	installations, err := selectedProvider.DetectInstalled()
+	installations, err := selectedProvider.DetectInstalled(ctx)
 	if err != nil {
-		fmt.Printf("Error: %s is not installed or not found in PATH\n", selectedProvider.Name())
-		return
+		kind, ok := core.ProviderErrorKindOf(err)
+		switch {
+		case ok && kind == core.PermissionDenied:
+			fmt.Printf("Error: %s is on PATH but you don't have permission to run it (%v)\n", selectedProvider.Name(), err)
+		case ok && kind == core.Timeout:
+			fmt.Printf("Error: %s did not answer in time (%v)\n", selectedProvider.Name(), err)
+		case errors.Is(err, core.ErrBrokenInstall):
+			fmt.Printf("Error: %s is on PATH but broken (%v)\n", selectedProvider.Name(), err)
+		default:
+			fmt.Printf("Error: %s is not installed or not found in PATH\n", selectedProvider.Name())
+		}
+		os.Exit(infoExitCode(err))
 	}
 
 	if len(installations) == 0 {
@@ -73,10 +103,44 @@ func runInfo(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	installation := &installations[0]
+	if infoWatch {
+		if infoBreakdown {
+			fmt.Println("Error: --breakdown can't be used with --watch")
+			os.Exit(1)
+		}
+		cancel()
+		watchInfo(selectedProvider, installations)
+		return
+	}
+
+	// Many tiny files can matter as much as bytes (backups, inodes)
+	diskUsage := measureInfoUsage(ctx, selectedProvider, true)
+	fmt.Println(renderInfoPanel(ctx, selectedProvider, installations, diskUsage, nil))
+}
 
+// infoExitCode maps a detection failure to the exit status of info, so scripts can tell
+// a missing language from one that is there but unusable
+func infoExitCode(err error) int {
+	kind, ok := core.ProviderErrorKindOf(err)
+	if !ok {
+		return exitInfoNotInstalled
+	}
+	switch kind {
+	case core.Unusable:
+		return exitInfoBroken
+	case core.PermissionDenied:
+		return exitInfoPermissionDenied
+	case core.Timeout:
+		return exitInfoTimedOut
+	default:
+		return exitInfoNotInstalled
+	}
+}
+
+// measureInfoUsage measures a provider's caches
+func measureInfoUsage(ctx context.Context, provider core.LanguageProvider, counts bool) *core.DiskUsage {
 	// Get disk usage
-	diskUsage, err := selectedProvider.GetGlobalCacheUsage()
+	diskUsage, err := provider.GetGlobalCacheUsage(ctx)
 	if err != nil {
 		if verbose {
 			fmt.Printf("Warning: failed to get disk usage: %v\n", err)
@@ -87,7 +151,89 @@ func runInfo(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	// Render info
-	info := output.RenderInfo(selectedProvider, installation, diskUsage)
-	fmt.Println(info)
+	if counts {
+		fillFileCounts(ctx, diskUsage)
+	}
+	return diskUsage
+}
+
+// renderInfoPanel renders the info output, annotating caches with their change since the last refresh
+func renderInfoPanel(ctx context.Context, provider core.LanguageProvider, installations []core.Installation, diskUsage *core.DiskUsage, deltas map[string]int64) string {
+	var newer string
+	if installation, ok := core.NewerInactive(installations); ok {
+		newer = installation.Version
+	}
+
+	var breakdown []core.InfoSection
+	if breakdownProvider, ok := provider.(core.BreakdownProvider); ok && infoBreakdown {
+		breakdown = breakdownProvider.GetBreakdown(ctx)
+	}
+
+	var sections []core.InfoSection
+	if sectionProvider, ok := provider.(core.InfoSectionProvider); ok {
+		sections = sectionProvider.GetInfoSections(ctx)
+	}
+
+	return output.RenderInfo(provider, &installations[0], diskUsage, output.InfoOptions{
+		Explain:   infoExplain,
+		Conflicts: doctor.CheckProvider(ctx, provider, installations),
+		MaxItems:  infoMaxItems,
+		Deltas:    deltas,
+		Newer:     newer,
+		Breakdown: breakdown,
+		Others:    installations[1:],
+		EnvVars:   provider.GetEnvVars(ctx),
+		Sections:  sections,
+	})
+}
+
+// watchInfo re-renders the info panel every --interval until Ctrl-C, then prints a final snapshot.
+// File counts are skipped to keep each refresh cheap, and --timeout applies to each refresh.
+func watchInfo(provider core.LanguageProvider, installations []core.Installation) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+
+	ticker := time.NewTicker(infoInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := commandContext(context.Background(), scanner.NewSizeCache())
+	previous := measureInfoUsage(ctx, provider, false)
+	deltas := make(map[string]int64)
+	for {
+		panel := renderInfoPanel(ctx, provider, installations, previous, deltas)
+		cancel()
+		fmt.Print(clearScreen)
+		fmt.Println(panel)
+		fmt.Printf("Refreshing every %s, last at %s. Press Ctrl-C to stop.\n", infoInterval, time.Now().Format("15:04:05"))
+
+		select {
+		case <-signals:
+			fmt.Print(clearScreen)
+			fmt.Println(panel)
+			return
+		case <-ticker.C:
+		}
+
+		// Measure each refresh with a fresh size cache, or nothing would ever change
+		ctx, cancel = commandContext(context.Background(), scanner.NewSizeCache())
+		current := measureInfoUsage(ctx, provider, false)
+		deltas = usageDeltas(previous, current)
+		previous = current
+	}
+}
+
+// usageDeltas returns how much each cache path grew (or shrank) between two measurements.
+// The empty path holds the change in total.
+func usageDeltas(before, after *core.DiskUsage) map[string]int64 {
+	sizes := make(map[string]int64)
+	for _, item := range before.Items {
+		sizes[item.Path] = item.Size
+	}
+
+	deltas := map[string]int64{"": after.Total - before.Total}
+	for _, item := range after.Items {
+		deltas[item.Path] = item.Size - sizes[item.Path]
+	}
+	return deltas
 }
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"dependency-hell-cli/internal/advisor"
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/output"
+	"dependency-hell-cli/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateScript bool
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [language]",
+	Short: "Recommend migrating unmanaged languages to version managers",
+	Long: `Recommend how to move languages installed from System, Homebrew or
+unknown sources to a version manager (goenv, nvm, pyenv, sdkman, ...).
+
+The commands are advisory only and are never executed by dhell.
+
+Examples:
+  dhell migrate                    # Advise on all languages
+  dhell migrate python             # Advise on Python only
+  dhell migrate --script > fix.sh  # Write the commands as a shell script`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runMigrate,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().BoolVar(&migrateScript, "script", false, "Print the recommended commands as a shell script")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) {
+	selectedProviders := newProviders()
+
+	if len(args) == 1 {
+		provider := findProvider(selectedProviders, args[0])
+		if provider == nil {
+			fmt.Printf("Unknown language: %s\n", args[0])
+			fmt.Printf("Supported languages: %s\n", supportedLanguages)
+			return
+		}
+		selectedProviders = []core.LanguageProvider{provider}
+	}
+
+	ctx, cancel := commandContext(context.Background(), scanner.NewSizeCache())
+	defer cancel()
+
+	var migrations []advisor.Migration
+	for _, provider := range selectedProviders {
+		installations, err := provider.DetectInstalled(ctx)
+		if err != nil || len(installations) == 0 {
+			if verbose {
+				fmt.Printf("Skipping %s: not installed\n", provider.Name())
+			}
+			continue
+		}
+
+		if migration := advisor.PlanMigration(provider.Name(), installations[0]); migration != nil {
+			migrations = append(migrations, *migration)
+		}
+	}
+
+	if migrateScript {
+		fmt.Print(output.RenderMigrationScript(migrations))
+		return
+	}
+
+	fmt.Println(output.RenderMigrationPlan(migrations))
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/providers"
+)
+
+// supportedLanguages lists the language arguments accepted by the commands
+const supportedLanguages = "go, node, java, python, php, rust, deno, homebrew"
+
+// newProviders returns all registered language providers, followed by
+// any external dhell-provider-* executables found on PATH
+func newProviders() []core.LanguageProvider {
+	all := []core.LanguageProvider{
+		providers.NewGoProvider(),
+		providers.NewNodeProvider(),
+		providers.NewJavaProvider(),
+		providers.NewPythonProvider(),
+		providers.NewPHPProvider(),
+		providers.NewRustProvider(),
+		providers.NewDenoProvider(),
+		providers.NewHomebrewProvider(),
+	}
+
+	for _, external := range providers.DiscoverExternalProviders() {
+		all = append(all, external)
+	}
+
+	return all
+}
+
+// findProvider returns the first provider whose name contains the language
+func findProvider(allProviders []core.LanguageProvider, language string) core.LanguageProvider {
+	language = strings.ToLower(language)
+	for _, provider := range allProviders {
+		providerName := strings.ToLower(provider.Name())
+		if strings.Contains(providerName, language) {
+			return provider
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dependency-hell-cli/internal/cleaner"
+	"dependency-hell-cli/internal/output"
+	"dependency-hell-cli/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+var resetDryRun bool
+
+var resetCmd = &cobra.Command{
+	Use:   "reset <manager>",
+	Short: "Remove a version manager's entire data directory",
+	Long: `Remove everything a version manager has installed, for a fresh start.
+
+This is a bigger hammer than clean: every version, cache and plugin the
+manager owns is deleted. You must type the manager's name to confirm.
+
+Supported managers: ` + strings.Join(cleaner.ManagerNames(), ", ") + `
+
+Examples:
+  dhell reset pyenv              # Remove ~/.pyenv (or $PYENV_ROOT)
+  dhell reset nvm --dry-run      # Show what would be removed`,
+	Args: cobra.ExactArgs(1),
+	Run:  runReset,
+}
+
+func init() {
+	rootCmd.AddCommand(resetCmd)
+	resetCmd.Flags().BoolVar(&resetDryRun, "dry-run", false, "Show what would be removed without deleting")
+}
+
+func runReset(cmd *cobra.Command, args []string) {
+	manager, ok := cleaner.FindManagerRoot(args[0])
+	if !ok {
+		fmt.Printf("Unknown version manager: %s\n", args[0])
+		fmt.Printf("Supported managers: %s\n", strings.Join(cleaner.ManagerNames(), ", "))
+		return
+	}
+
+	root := scanner.ExpandHome(manager.Root())
+	if !scanner.PathExists(root) {
+		fmt.Printf("%s is not installed (%s not found)\n", manager.Name, root)
+		return
+	}
+
+	if err := manager.CheckResettable(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Only one clean or reset may modify caches at a time
+	if !resetDryRun {
+		cleanLock, err := acquireCleanLock()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer cleanLock.Release()
+	}
+
+	ctx, cancel := commandContext(context.Background(), scanner.NewSizeCache())
+	defer cancel()
+
+	contents := manager.Contents(ctx)
+	var totalSize int64
+	for _, item := range contents {
+		totalSize += item.Size
+	}
+
+	if resetDryRun {
+		fmt.Printf("Would remove %s (%s):\n", root, output.FormatBytes(totalSize))
+		for _, item := range contents {
+			fmt.Printf("  • %s (%s)\n", item.Description, output.FormatBytes(item.Size))
+		}
+		return
+	}
+
+	if !cleaner.ConfirmReset(manager, contents, totalSize) {
+		fmt.Println("Reset cancelled.")
+		return
+	}
+
+	if err := manager.Remove(); err != nil {
+		fmt.Printf("Error resetting %s: %v\n", manager.Name, err)
+		return
+	}
+
+	fmt.Printf("✅ Removed %s, reclaimed %s\n", root, output.FormatBytes(totalSize))
+	fmt.Printf("Remember to remove %s from your shell startup files.\n", manager.ShellHint)
+}
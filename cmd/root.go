@@ -1,15 +1,35 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"runtime"
+	"time"
 
+	"dependency-hell-cli/internal/config"
+	"dependency-hell-cli/internal/output"
+	"dependency-hell-cli/internal/providers"
+	"dependency-hell-cli/internal/scanner"
+	"dependency-hell-cli/internal/tui"
+
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
 var (
-	version = "0.1.0"
-	verbose bool
+	version           = "0.1.0"
+	verbose           bool
+	execConcurrency   int
+	parallelWalkDepth int
+	scanTimeout       time.Duration
+	launchUI          bool
+	units             string
+	rawSizes          bool
+	useDu             bool
+	offline           bool
+	configPath        string
+	cfg               = &config.Config{}
 )
 
 var rootCmd = &cobra.Command{
@@ -24,6 +44,76 @@ It helps you understand:
   • How much disk space they're consuming
   • Environment variables and configurations`,
 	Version: version,
+	Args:    cobra.NoArgs,
+	Run:     runRoot,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Arguments are valid by now; errors below are about settings, not usage
+		cmd.SilenceUsage = true
+
+		scanner.SetExecConcurrency(execConcurrency)
+		scanner.SetUseDu(useDu)
+		scanner.SetOffline(offline)
+
+		loaded, err := config.Load(configPath)
+		if err != nil {
+			return err
+		}
+		cfg = loaded
+
+		if err := cfg.ValidateCachePaths(providers.CachePathKeys); err != nil {
+			return fmt.Errorf("invalid config %s: %w", configPath, err)
+		}
+		scanner.SetPathTemplates(cfg.CachePaths)
+
+		if cfg.ParallelWalkDepth != nil && !cmd.Flags().Changed("parallel-walk-depth") {
+			parallelWalkDepth = *cfg.ParallelWalkDepth
+		}
+		scanner.SetParallelWalkDepth(parallelWalkDepth)
+
+		// scan --concurrency overrides this in runScan
+		if cfg.Concurrency != nil {
+			scanner.SetWalkWorkers(*cfg.Concurrency)
+		}
+
+		if rawSizes {
+			if cmd.Flags().Changed("units") {
+				return fmt.Errorf("--raw-sizes and --units can't be used together")
+			}
+			units = string(output.UnitsRaw)
+		}
+		return output.SetUnits(output.Units(units))
+	},
+}
+
+// runRoot launches the interactive TUI when run from a terminal, otherwise shows help
+func runRoot(cmd *cobra.Command, args []string) {
+	if !launchUI && !isatty.IsTerminal(os.Stdout.Fd()) {
+		cmd.Help()
+		return
+	}
+
+	if err := tui.Run(context.Background(), loadScanResults, cfg.ApplySafeOverrides); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// loadScanResults scans all providers with a fresh size cache
+func loadScanResults(ctx context.Context) []output.ScanResult {
+	ctx, cancel := commandContext(ctx, scanner.NewSizeCache())
+	defer cancel()
+
+	return scanProviders(ctx, newProviders())
+}
+
+// commandContext returns the context a command's scans and cleans run under: it carries
+// cache so no directory is walked twice, and is cancelled after --timeout
+func commandContext(parent context.Context, cache *scanner.SizeCache) (context.Context, context.CancelFunc) {
+	ctx := scanner.WithSizeCache(parent, cache)
+	if scanTimeout > 0 {
+		return context.WithTimeout(ctx, scanTimeout)
+	}
+	return context.WithCancel(ctx)
 }
 
 // Execute runs the root command
@@ -36,4 +126,13 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", config.DefaultPath(), "config file")
+	rootCmd.Flags().BoolVar(&launchUI, "ui", false, "launch the interactive TUI")
+	rootCmd.PersistentFlags().StringVar(&units, "units", string(output.UnitsDecimal), "size units: decimal (MB, GB), binary (MiB, GiB) or bytes")
+	rootCmd.PersistentFlags().BoolVar(&rawSizes, "raw-sizes", false, "print sizes as plain byte counts (same as --units bytes)")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "never touch the network (for air-gapped machines); subprocesses are told to stay offline too")
+	rootCmd.PersistentFlags().BoolVar(&useDu, "use-du", false, "measure directory sizes with du -sk (faster on large trees, reports disk usage)")
+	rootCmd.PersistentFlags().IntVar(&parallelWalkDepth, "parallel-walk-depth", scanner.DefaultParallelWalkDepth, "directory levels walked before splitting size calculation across workers (advanced, 0 disables)")
+	rootCmd.PersistentFlags().DurationVar(&scanTimeout, "timeout", 0, "stop scan, info, clean and doctor after this long (e.g. 30s) and show partial results; 0 waits indefinitely")
+	rootCmd.PersistentFlags().IntVar(&execConcurrency, "exec-concurrency", runtime.NumCPU(), "maximum number of subprocesses run at the same time")
 }
@@ -1,19 +1,44 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"dependency-hell-cli/internal/core"
 	"dependency-hell-cli/internal/output"
-	"dependency-hell-cli/internal/providers"
+	"dependency-hell-cli/internal/scanner"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	langFilter string
+	langFilter      string
+	scanMaxItems    int
+	showCounts      bool
+	installedOnly   bool
+	deepScan        bool
+	scanLocal       string
+	scanOutput      string
+	scanStrict      bool
+	scanTemp        bool
+	scanTempAge     time.Duration
+	noBreakdown     bool
+	scanConcurrency int
+	scanProfile     bool
+	scanFailEmpty   bool
+)
+
+// Exit statuses of scan, so CI can tell failure classes apart
+const (
+	exitScanTimedOut     = 1 // A provider did not finish before --timeout
+	exitScanWarnings     = 2 // --strict and the scan produced warnings
+	exitScanNotInstalled = 3 // --fail-empty and a scanned language isn't installed
 )
 
 var scanCmd = &cobra.Command{
@@ -25,26 +50,53 @@ their sources (Homebrew, Version Managers, System), and disk usage.
 Examples:
   dhell scan                    # Scan all languages
   dhell scan --lang go          # Scan only Go
-  dhell scan --lang go,node     # Scan Go and Node.js`,
+  dhell scan --lang go,node     # Scan Go and Node.js
+  dhell scan -l go --fail-empty # Fail in CI unless Go is installed
+  dhell scan --local .          # Find build artifacts in the current project
+  dhell scan --temp             # Find go-build*, pip-*, npm-* temp leftovers and crash logs
+  dhell scan --no-breakdown     # One line per language
+  dhell scan --output json      # Export for dhell compare
+  dhell scan --output tsv       # Tab-separated rows for awk and cut
+  dhell scan --output csv       # Comma-separated rows for spreadsheets`,
 	Run: runScan,
 }
 
 func init() {
 	rootCmd.AddCommand(scanCmd)
 	scanCmd.Flags().StringVarP(&langFilter, "lang", "l", "", "Filter languages to scan (comma-separated: go,node,java)")
+	scanCmd.Flags().StringVar(&scanLocal, "local", "", "Scan a project directory for build artifacts instead of global caches")
+	scanCmd.Flags().BoolVar(&scanTemp, "temp", false, "Scan the temp directory for leftovers of compilers and package managers, and for crash logs")
+	scanCmd.Flags().DurationVar(&scanTempAge, "older-than", defaultTempAge, "With --temp, leftovers modified longer ago than this are removable")
+	scanCmd.Flags().BoolVar(&deepScan, "deep", false, "Also probe less-standard cache locations (slower, heuristic)")
+	scanCmd.Flags().BoolVar(&installedOnly, "installed-only", false, "Only show installed languages and note how many were not detected")
+	scanCmd.Flags().BoolVar(&showCounts, "show-counts", false, "Show the number of files in each cache (walks every directory)")
+	scanCmd.Flags().StringVarP(&scanOutput, "output", "o", string(output.FormatTable), "Output format: table, json, tsv or csv")
+	scanCmd.Flags().BoolVar(&scanFailEmpty, "fail-empty", false, "Exit with status 3 if a scanned language (see --lang) is not installed")
+	scanCmd.Flags().BoolVar(&scanStrict, "strict", false, "Exit with status 2 if the scan produced any warnings (unparsed versions, unreadable directories)")
+	scanCmd.Flags().BoolVar(&noBreakdown, "no-breakdown", false, "Show one row per language without the per-cache breakdown")
+	scanCmd.Flags().IntVar(&scanMaxItems, "max-items", 0, "Show only the N largest cache items per language (0 shows all)")
+	scanCmd.Flags().BoolVar(&scanProfile, "profile", false, "Show how long each cache took to measure and list the slowest directories")
+	scanCmd.Flags().IntVar(&scanConcurrency, "concurrency", scanner.DefaultWalkWorkers(), "Workers measuring directory sizes in parallel (the default is reduced to 2 on network filesystems)")
 }
 
 func runScan(cmd *cobra.Command, args []string) {
-	// Initialize all providers
-	allProviders := []core.LanguageProvider{
-		providers.NewGoProvider(),
-		providers.NewNodeProvider(),
-		providers.NewJavaProvider(),
-		providers.NewPythonProvider(),
-		providers.NewPHPProvider(),
-		providers.NewRustProvider(),
+	format, err := output.ParseFormat(scanOutput)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
+	if cmd.Flags().Changed("concurrency") {
+		if scanConcurrency < 1 {
+			fmt.Fprintln(os.Stderr, "--concurrency must be at least 1")
+			os.Exit(1)
+		}
+		scanner.SetWalkWorkers(scanConcurrency)
+	}
+
+	// Initialize all providers
+	allProviders := newProviders()
+
 	// Filter providers if --lang flag is set
 	selectedProviders := filterProviders(allProviders, langFilter)
 
@@ -53,18 +105,183 @@ func runScan(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	if scanLocal != "" {
+		runProjectScan(selectedProviders, scanLocal)
+		return
+	}
+
+	if scanTemp {
+		runTempScan(selectedProviders)
+		return
+	}
+
 	// Show scanning message
 	if verbose {
 		fmt.Println("Scanning development environment...")
 		fmt.Println()
 	}
 
+	// Avoid walking the same directory twice within this scan
+	ctx, cancel := commandContext(context.Background(), scanner.NewSizeCache())
+	defer cancel()
+
 	// Scan all providers concurrently
-	results := scanProviders(selectedProviders)
+	results := scanProviders(ctx, selectedProviders)
+	missing := notInstalledLanguages(results)
+
+	// Drop languages that aren't installed, remembering how many there were
+	notDetected := 0
+	if installedOnly {
+		var installed []output.ScanResult
+		for _, result := range results {
+			if result.Error != nil && !result.TimedOut {
+				notDetected++
+				continue
+			}
+			installed = append(installed, result)
+		}
+		results = installed
+	}
+
+	// Count files in each cache; walks already done for sizes are reused from the cache
+	if showCounts {
+		for _, result := range results {
+			fillFileCounts(ctx, result.DiskUsage)
+		}
+	}
 
 	// Render results
-	output := output.RenderScanResults(results)
+	if format == output.FormatJSON {
+		rendered, err := output.RenderScanJSON(results)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Print(rendered)
+		exitIfIncomplete(results, missing)
+		return
+	}
+	if format == output.FormatTSV {
+		fmt.Print(output.RenderScanResultsTSV(results))
+		exitIfIncomplete(results, missing)
+		return
+	}
+	if format == output.FormatCSV {
+		rendered, err := output.RenderScanResultsCSV(results)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Print(rendered)
+		exitIfIncomplete(results, missing)
+		return
+	}
+
+	output := output.RenderScanResults(results, output.ScanOptions{
+		MaxItems:    scanMaxItems,
+		ShowCounts:  showCounts,
+		NoBreakdown: noBreakdown,
+		Profile:     scanProfile,
+	})
 	fmt.Println(output)
+
+	if notDetected > 0 {
+		fmt.Printf("%d languages not detected\n", notDetected)
+	}
+
+	exitIfIncomplete(results, missing)
+}
+
+// exitIfIncomplete lets CI treat an incomplete scan as a failure. Timeouts always fail;
+// missing languages only fail with --fail-empty, and warnings only with --strict.
+func exitIfIncomplete(results []output.ScanResult, missing []string) {
+	for _, result := range results {
+		if result.TimedOut {
+			os.Exit(exitScanTimedOut)
+		}
+	}
+
+	if scanFailEmpty && len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Not installed: %s\n", strings.Join(missing, ", "))
+		os.Exit(exitScanNotInstalled)
+	}
+
+	if !scanStrict {
+		return
+	}
+	if len(output.SystemWarnings()) > 0 {
+		os.Exit(exitScanWarnings)
+	}
+	for _, result := range results {
+		if len(result.Warnings) > 0 {
+			os.Exit(exitScanWarnings)
+		}
+	}
+}
+
+// notInstalledLanguages names the scanned languages whose binary isn't on PATH. Broken
+// installs don't count: the language is there, it just can't be run.
+func notInstalledLanguages(results []output.ScanResult) []string {
+	var missing []string
+	for _, result := range results {
+		if errors.Is(result.Error, core.ErrNotInstalled) {
+			missing = append(missing, result.Provider.Name())
+		}
+	}
+	return missing
+}
+
+// runProjectScan reports the build artifacts each provider finds under root
+func runProjectScan(providers []core.LanguageProvider, root string) {
+	root, err := projectRoot(root)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	ctx, cancel := commandContext(context.Background(), scanner.NewSizeCache())
+	defer cancel()
+
+	var artifacts []output.ProjectArtifacts
+	for _, provider := range providers {
+		if projectScanner, ok := provider.(core.ProjectScanner); ok {
+			artifacts = append(artifacts, output.ProjectArtifacts{
+				Language: provider.Name(),
+				Items:    projectScanner.ScanProject(ctx, root),
+			})
+		}
+	}
+
+	fmt.Println(output.RenderProjectArtifacts(root, artifacts))
+}
+
+// runTempScan reports the temp directory leftovers and crash logs of the given providers' tools
+func runTempScan(providers []core.LanguageProvider) {
+	selected := make(map[string]bool)
+	for _, provider := range providers {
+		selected[provider.Name()] = true
+	}
+
+	var leftovers []scanner.TempLeftover
+	for _, leftover := range scanner.ScanTempLeftoversAndCrashLogs() {
+		if selected[leftover.Language] {
+			leftovers = append(leftovers, leftover)
+		}
+	}
+
+	fmt.Println(output.RenderTempLeftovers(scanner.TempDirs(), leftovers, scanTempAge))
+}
+
+// projectRoot resolves a --local argument to an absolute directory
+func projectRoot(dir string) (string, error) {
+	root, err := filepath.Abs(scanner.ExpandHome(dir))
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", dir)
+	}
+	return root, nil
 }
 
 // filterProviders filters providers based on language filter
@@ -96,8 +313,28 @@ func filterProviders(providers []core.LanguageProvider, filter string) []core.La
 	return filtered
 }
 
-// scanProviders scans all providers concurrently
-func scanProviders(providers []core.LanguageProvider) []output.ScanResult {
+// fillFileCounts sets the file count of every path-based disk usage item
+func fillFileCounts(ctx context.Context, diskUsage *core.DiskUsage) {
+	if diskUsage == nil {
+		return
+	}
+
+	for i, item := range diskUsage.Items {
+		if item.Path == "" {
+			continue
+		}
+		if _, files, err := scanner.CalculateDirSizeWithCount(ctx, item.Path); err == nil {
+			diskUsage.Items[i].FileCount = files
+		}
+	}
+}
+
+// errScanTimedOut marks providers that timed out before detecting an installation
+var errScanTimedOut = errors.New("scan timed out")
+
+// scanProviders scans all providers concurrently. Once ctx is done (e.g. --timeout), providers
+// stop at their next directory or subprocess; what they found so far is kept and marked timed out.
+func scanProviders(ctx context.Context, providers []core.LanguageProvider) []output.ScanResult {
 	var wg sync.WaitGroup
 	results := make([]output.ScanResult, len(providers))
 
@@ -105,7 +342,16 @@ func scanProviders(providers []core.LanguageProvider) []output.ScanResult {
 		wg.Add(1)
 		go func(index int, p core.LanguageProvider) {
 			defer wg.Done()
-			results[index] = scanProvider(p)
+
+			result := scanProvider(ctx, p)
+			// Anything measured after the deadline is incomplete
+			if ctx.Err() != nil {
+				result.TimedOut = true
+				if len(result.Installations) == 0 {
+					result.Error = errScanTimedOut
+				}
+			}
+			results[index] = result
 		}(i, provider)
 	}
 
@@ -113,33 +359,77 @@ func scanProviders(providers []core.LanguageProvider) []output.ScanResult {
 	return results
 }
 
+// locateBinaries finds and classifies every distinct copy of a provider's binary on PATH
+func locateBinaries(locator core.BinaryLocator) []core.BinaryLocation {
+	var locations []core.BinaryLocation
+
+	for _, path := range scanner.FindAllExecutables(locator.BinaryName()) {
+		realPath, err := scanner.ResolveSymlink(path)
+		if err != nil {
+			realPath = path
+		}
+
+		source, _ := locator.ClassifySource(realPath)
+		locations = append(locations, core.BinaryLocation{
+			Path:     path,
+			RealPath: realPath,
+			Source:   source,
+		})
+	}
+
+	return locations
+}
+
 // scanProvider scans a single provider
-func scanProvider(provider core.LanguageProvider) output.ScanResult {
+func scanProvider(ctx context.Context, provider core.LanguageProvider) output.ScanResult {
 	result := output.ScanResult{
 		Provider: provider,
 	}
+	start := time.Now()
 
 	// Detect installation
-	installations, err := provider.DetectInstalled()
+	installations, err := provider.DetectInstalled(ctx)
 	if err != nil {
 		result.Error = err
+		// A language that is on PATH but can't be used shouldn't vanish from the table silently
+		if kind, ok := core.ProviderErrorKindOf(err); ok {
+			switch kind {
+			case core.Timeout:
+				result.TimedOut = true
+			case core.PermissionDenied:
+				result.Warnings = append(result.Warnings, fmt.Sprintf("installed but not executable by you (%v)", err))
+			case core.Unusable:
+				result.Warnings = append(result.Warnings, fmt.Sprintf("installed but broken (%v)", err))
+			}
+		}
 		return result
 	}
 
 	if len(installations) == 0 {
-		result.Error = fmt.Errorf("not installed")
+		result.Error = core.ErrNotInstalled
 		return result
 	}
 
 	// Store all installations
 	result.Installations = installations
 
+	// Find every copy of the binary on PATH, not just the first
+	if locator, ok := provider.(core.BinaryLocator); ok {
+		result.Locations = locateBinaries(locator)
+		result.WindowsPaths = scanner.FindWindowsExecutables(locator.BinaryName())
+	}
+
+	// A version we couldn't parse is still an installation, but worth pointing out
+	for _, installation := range installations {
+		if installation.Version == "unknown" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("could not parse the version of %s", installation.BinaryPath))
+		}
+	}
+
 	// Get disk usage
-	diskUsage, err := provider.GetGlobalCacheUsage()
+	diskUsage, err := provider.GetGlobalCacheUsage(ctx)
 	if err != nil {
-		if verbose {
-			fmt.Printf("Warning: failed to get disk usage for %s: %v\n", provider.Name(), err)
-		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to get disk usage: %v", err))
 		// Continue with empty disk usage
 		diskUsage = &core.DiskUsage{
 			Items: []core.DiskUsageItem{},
@@ -147,7 +437,83 @@ func scanProvider(provider core.LanguageProvider) output.ScanResult {
 		}
 	}
 
+	// Probe less-standard locations when asked
+	if deepScan {
+		if deepProvider, ok := provider.(core.DeepCacheProvider); ok {
+			addDeepCaches(ctx, diskUsage, deepProvider.DeepCacheCandidates())
+		}
+	}
+
+	// Directories we couldn't fully read are smaller than reported
+	for _, item := range diskUsage.Items {
+		if unreadable := scanner.UnreadableEntries(item.Path); unreadable > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%d entries under %s could not be read, so its size is underreported", unreadable, item.Path))
+		}
+	}
+
 	result.DiskUsage = diskUsage
 
+	// Attribute wall time to each cache, so the slow directory can be pruned or excluded
+	if scanProfile {
+		result.ItemTimes = make(map[string]time.Duration)
+		for _, item := range diskUsage.Items {
+			if elapsed, ok := scanner.WalkTime(item.Path); ok {
+				result.ItemTimes[item.Path] = elapsed
+			}
+		}
+	}
+	result.Elapsed = time.Since(start)
+
 	return result
 }
+
+// addDeepCaches appends heuristic cache items that aren't already reported
+func addDeepCaches(ctx context.Context, diskUsage *core.DiskUsage, candidates []core.CacheCandidate) {
+	known := make(map[string]bool)
+	for _, item := range diskUsage.Items {
+		known[scanner.ExpandHome(item.Path)] = true
+	}
+
+	for _, candidate := range candidates {
+		for _, path := range scanner.DiscoverCandidatePaths(candidate.Pattern) {
+			// Parents of reported items (e.g. a cache whose parts are listed) would count them twice
+			if known[path] || isUnderKnown(path, known) || containsKnown(path, known) {
+				continue
+			}
+			known[path] = true
+
+			size, _ := scanner.CalculateDirSize(ctx, path)
+			if size == 0 {
+				continue
+			}
+
+			diskUsage.Items = append(diskUsage.Items, core.DiskUsageItem{
+				Path:        path,
+				Description: candidate.Description,
+				Size:        size,
+				Heuristic:   true,
+			})
+			diskUsage.Total += size
+		}
+	}
+}
+
+// isUnderKnown reports whether path lives inside an already reported directory
+func isUnderKnown(path string, known map[string]bool) bool {
+	for dir := range known {
+		if dir != "" && strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsKnown reports whether an already reported directory lives inside path
+func containsKnown(path string, known map[string]bool) bool {
+	for dir := range known {
+		if strings.HasPrefix(dir, path+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"dependency-hell-cli/internal/output"
+	"dependency-hell-cli/internal/scanner"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchLang      string
+	watchInterval  time.Duration
+	watchEvents    bool
+	watchThreshold string
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch cache directories and report size changes",
+	Long: `Watch the cache directories of installed languages and print a line
+whenever one changes size significantly, e.g. while a build is running.
+
+By default directories are polled on an interval. With --events, filesystem
+notifications are used instead (debounced), falling back to polling where
+they aren't supported.
+
+Examples:
+  dhell watch                        # Poll all caches every 5s
+  dhell watch --lang go --events     # Use filesystem events for Go caches
+  dhell watch --threshold 50MB       # Only report changes of 50 MB or more`,
+	Args: cobra.NoArgs,
+	Run:  runWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().StringVarP(&watchLang, "lang", "l", "", "Filter languages to watch (comma-separated: go,node,java)")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Second, "Polling interval")
+	watchCmd.Flags().BoolVar(&watchEvents, "events", false, "Use filesystem notifications instead of polling")
+	watchCmd.Flags().StringVar(&watchThreshold, "threshold", "1MB", "Minimum size change to report")
+}
+
+func runWatch(cmd *cobra.Command, args []string) {
+	threshold, err := humanize.ParseBytes(watchThreshold)
+	if err != nil {
+		fmt.Printf("Invalid threshold: %s\n", watchThreshold)
+		return
+	}
+
+	// Stop on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Collect cache directories and their labels
+	labels := make(map[string]string)
+	var paths []string
+	for _, provider := range filterProviders(newProviders(), watchLang) {
+		diskUsage, err := provider.GetGlobalCacheUsage(ctx)
+		if err != nil {
+			continue
+		}
+		for _, item := range diskUsage.Items {
+			labels[item.Path] = fmt.Sprintf("%s %s", provider.Name(), item.Description)
+			paths = append(paths, item.Path)
+		}
+	}
+
+	if len(paths) == 0 {
+		fmt.Println("No cache directories found to watch.")
+		return
+	}
+
+	mode := "polling"
+	if watchEvents {
+		mode = "events"
+	}
+	fmt.Printf("Watching %d cache directories (%s). Press Ctrl-C to stop.\n", len(paths), mode)
+
+	watcher := &scanner.Watcher{
+		Paths:     paths,
+		Interval:  watchInterval,
+		Debounce:  time.Second,
+		Threshold: int64(threshold),
+		UseEvents: watchEvents,
+	}
+
+	err = watcher.Run(ctx, func(event scanner.WatchEvent) {
+		sign := "+"
+		delta := event.Delta()
+		if delta < 0 {
+			sign = "-"
+			delta = -delta
+		}
+		fmt.Printf("[%s] %s: %s → %s (%s%s)\n",
+			time.Now().Format("15:04:05"),
+			labels[event.Path],
+			output.FormatBytes(event.OldSize),
+			output.FormatBytes(event.NewSize),
+			sign,
+			output.FormatBytes(delta))
+	})
+	if err != nil {
+		fmt.Printf("Error watching caches: %v\n", err)
+	}
+}
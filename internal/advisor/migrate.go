@@ -0,0 +1,179 @@
+package advisor
+
+import (
+	"fmt"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// MigrationKey identifies a language installed from a specific source
+type MigrationKey struct {
+	Language string // Provider name (e.g., "Python")
+	Source   core.InstallSource
+}
+
+// Migration represents a recommended move from an installation source to a version manager
+type Migration struct {
+	Language string
+	Version  string
+	Source   core.InstallSource
+	Manager  string
+	Reason   string
+	Commands []string // Copy-pasteable shell commands, never executed by dhell
+}
+
+// managerSetup describes how to install a version manager and select a version with it
+type managerSetup struct {
+	install []string
+	use     []string // "{version}" is replaced with the detected version
+}
+
+// recommendedManagers maps a (language, source) pair to the recommended version manager
+var recommendedManagers = map[MigrationKey]string{
+	{Language: "Golang", Source: core.SourceHomebrew}:  "goenv",
+	{Language: "Golang", Source: core.SourceSystem}:    "goenv",
+	{Language: "Golang", Source: core.SourceManual}:    "goenv",
+	{Language: "Golang", Source: core.SourceUnknown}:   "goenv",
+	{Language: "Node.js", Source: core.SourceHomebrew}: "nvm",
+	{Language: "Node.js", Source: core.SourceSystem}:   "nvm",
+	{Language: "Node.js", Source: core.SourceManual}:   "nvm",
+	{Language: "Node.js", Source: core.SourceUnknown}:  "nvm",
+	{Language: "Java", Source: core.SourceHomebrew}:    "sdkman",
+	{Language: "Java", Source: core.SourceSystem}:      "sdkman",
+	{Language: "Java", Source: core.SourceManual}:      "sdkman",
+	{Language: "Java", Source: core.SourceUnknown}:     "sdkman",
+	{Language: "Python", Source: core.SourceHomebrew}:  "pyenv",
+	{Language: "Python", Source: core.SourceSystem}:    "pyenv",
+	{Language: "Python", Source: core.SourceManual}:    "pyenv",
+	{Language: "Python", Source: core.SourceUnknown}:   "pyenv",
+	{Language: "PHP", Source: core.SourceHomebrew}:     "phpenv",
+	{Language: "PHP", Source: core.SourceSystem}:       "phpenv",
+	{Language: "PHP", Source: core.SourceManual}:       "phpenv",
+	{Language: "PHP", Source: core.SourceUnknown}:      "phpenv",
+	{Language: "Rust", Source: core.SourceHomebrew}:    "rustup",
+	{Language: "Rust", Source: core.SourceSystem}:      "rustup",
+	{Language: "Rust", Source: core.SourceManual}:      "rustup",
+	{Language: "Rust", Source: core.SourceUnknown}:     "rustup",
+}
+
+// managerSetups holds the setup commands for each recommended version manager
+var managerSetups = map[string]managerSetup{
+	"goenv": {
+		install: []string{
+			"git clone https://github.com/go-nv/goenv.git ~/.goenv",
+			`echo 'export GOENV_ROOT="$HOME/.goenv"' >> ~/.zshrc`,
+			`echo 'export PATH="$GOENV_ROOT/bin:$PATH"' >> ~/.zshrc`,
+			`echo 'eval "$(goenv init -)"' >> ~/.zshrc`,
+		},
+		use: []string{
+			"goenv install {version}",
+			"goenv global {version}",
+		},
+	},
+	"nvm": {
+		install: []string{
+			"curl -o- https://raw.githubusercontent.com/nvm-sh/nvm/v0.39.7/install.sh | bash",
+		},
+		use: []string{
+			"nvm install {version}",
+			"nvm alias default {version}",
+		},
+	},
+	"sdkman": {
+		install: []string{
+			`curl -s "https://get.sdkman.io" | bash`,
+			`source "$HOME/.sdkman/bin/sdkman-init.sh"`,
+		},
+		use: []string{
+			"sdk list java | grep {version}",
+			"sdk install java <identifier-from-list>",
+		},
+	},
+	"pyenv": {
+		install: []string{
+			"curl https://pyenv.run | bash",
+			`echo 'export PYENV_ROOT="$HOME/.pyenv"' >> ~/.zshrc`,
+			`echo 'export PATH="$PYENV_ROOT/bin:$PATH"' >> ~/.zshrc`,
+			`echo 'eval "$(pyenv init -)"' >> ~/.zshrc`,
+		},
+		use: []string{
+			"pyenv install {version}",
+			"pyenv global {version}",
+		},
+	},
+	"phpenv": {
+		install: []string{
+			"git clone https://github.com/phpenv/phpenv.git ~/.phpenv",
+			"git clone https://github.com/php-build/php-build ~/.phpenv/plugins/php-build",
+			`echo 'export PATH="$HOME/.phpenv/bin:$PATH"' >> ~/.zshrc`,
+			`echo 'eval "$(phpenv init -)"' >> ~/.zshrc`,
+		},
+		use: []string{
+			"phpenv install {version}",
+			"phpenv global {version}",
+		},
+	},
+	"rustup": {
+		install: []string{
+			"curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh",
+		},
+		use: []string{
+			"rustup toolchain install {version}",
+			"rustup default {version}",
+		},
+	},
+}
+
+// homebrewFormulae maps a language to its Homebrew formula name
+var homebrewFormulae = map[string]string{
+	"Golang":  "go",
+	"Node.js": "node",
+	"Java":    "openjdk",
+	"Python":  "python",
+	"PHP":     "php",
+	"Rust":    "rust",
+}
+
+// NeedsMigration reports whether an installation comes from a source that should be migrated
+func NeedsMigration(installation core.Installation) bool {
+	return core.DetermineStatus(installation.Source) != core.StatusGood
+}
+
+// PlanMigration builds a migration recommendation for a language installation.
+// It returns nil if the installation is already managed or no mapping exists.
+func PlanMigration(language string, installation core.Installation) *Migration {
+	if !NeedsMigration(installation) {
+		return nil
+	}
+
+	manager, ok := recommendedManagers[MigrationKey{Language: language, Source: installation.Source}]
+	if !ok {
+		return nil
+	}
+
+	setup := managerSetups[manager]
+	version := strings.TrimPrefix(installation.Version, "v")
+
+	var commands []string
+	commands = append(commands, setup.install...)
+	for _, command := range setup.use {
+		commands = append(commands, strings.ReplaceAll(command, "{version}", version))
+	}
+
+	// Homebrew installs can be removed once the manager is in place
+	if installation.Source == core.SourceHomebrew {
+		if formula, ok := homebrewFormulae[language]; ok {
+			commands = append(commands, fmt.Sprintf("brew uninstall %s", formula))
+		}
+	}
+
+	return &Migration{
+		Language: language,
+		Version:  installation.Version,
+		Source:   installation.Source,
+		Manager:  manager,
+		Reason:   fmt.Sprintf("%s is from %s; consider %s", language, installation.Source, manager),
+		Commands: commands,
+	}
+}
@@ -2,12 +2,13 @@ package cleaner
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/output"
 	"dependency-hell-cli/internal/scanner"
 )
 
@@ -19,15 +20,21 @@ func ConfirmClean(items []core.CleanableItem, totalSize int64) bool {
 	fmt.Println("You are about to clean:")
 
 	for _, item := range items {
-		if item.Size > 0 {
-			fmt.Printf("  • %s (%s)\n", item.Description, formatSize(item.Size))
+		if item.Size > 0 && item.Reclaim == core.ReclaimUpperBound {
+			fmt.Printf("  • %s (up to %s)\n", item.Description, output.FormatBytes(item.Size))
+		} else if item.Size > 0 {
+			fmt.Printf("  • %s (%s)\n", item.Description, output.FormatBytes(item.Size))
 		} else {
 			fmt.Printf("  • %s\n", item.Description)
 		}
 	}
 
 	fmt.Println()
-	fmt.Printf("Total: %s will be reclaimed\n", formatSize(totalSize))
+	if _, exact := core.EstimateReclaimable(items); exact {
+		fmt.Printf("Total: %s will be reclaimed\n", output.FormatBytes(totalSize))
+	} else {
+		fmt.Printf("Total: up to %s will be reclaimed\n", output.FormatBytes(totalSize))
+	}
 	fmt.Println()
 	fmt.Println("These caches will be rebuilt on next use.")
 	fmt.Println()
@@ -44,16 +51,18 @@ func ConfirmClean(items []core.CleanableItem, totalSize int64) bool {
 }
 
 // CleanItems executes cleaning for the given items
-func CleanItems(items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+func CleanItems(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
 	result := &core.CleanResult{
 		ItemsCleaned:   0,
 		SpaceReclaimed: 0,
 		Errors:         []error{},
+		DryRun:         dryRun,
 	}
 
 	for _, item := range items {
 		if dryRun {
 			// In dry-run mode, just count what would be cleaned
+			result.Items = append(result.Items, item)
 			result.ItemsCleaned++
 			result.SpaceReclaimed += item.Size
 			continue
@@ -63,7 +72,7 @@ func CleanItems(items []core.CleanableItem, dryRun bool) (*core.CleanResult, err
 		var err error
 		if item.Command != "" {
 			// Use command if specified
-			err = RunCleanCommand(item.Command)
+			err = RunCleanCommand(ctx, item.CommandArgs())
 		} else if item.Path != "" {
 			// Otherwise remove directory
 			err = CleanDirectory(item.Path)
@@ -74,6 +83,7 @@ func CleanItems(items []core.CleanableItem, dryRun bool) (*core.CleanResult, err
 			continue
 		}
 
+		result.Items = append(result.Items, item)
 		result.ItemsCleaned++
 		result.SpaceReclaimed += item.Size
 	}
@@ -81,43 +91,24 @@ func CleanItems(items []core.CleanableItem, dryRun bool) (*core.CleanResult, err
 	return result, nil
 }
 
-// CleanDirectory safely removes a directory
+// CleanDirectory safely removes a directory; see scanner.RemoveDir for the guards and the
+// handling of read-only entries such as those in the Go module cache
 func CleanDirectory(path string) error {
-	expandedPath := scanner.ExpandHome(path)
-
-	if !scanner.PathExists(expandedPath) {
+	if !scanner.PathExists(path) {
 		return nil // Already clean
 	}
-
-	return os.RemoveAll(expandedPath)
+	return scanner.RemoveDir(path)
 }
 
-// RunCleanCommand runs a clean command (e.g., go clean -modcache)
-func RunCleanCommand(cmdStr string) error {
-	parts := strings.Fields(cmdStr)
-	if len(parts) == 0 {
+// RunCleanCommand runs a clean command given as its argv (e.g., go clean -modcache)
+func RunCleanCommand(ctx context.Context, args []string) error {
+	if len(args) == 0 {
 		return fmt.Errorf("empty command")
 	}
 
-	cmd := exec.Command(parts[0], parts[1:]...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("command failed: %s (output: %s)", err, string(output))
+	if err := scanner.RunCommand(ctx, args[0], args[1:]...); err != nil {
+		return fmt.Errorf("command failed: %w", err)
 	}
 
 	return nil
 }
-
-// formatSize formats bytes to human-readable size
-func formatSize(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
-}
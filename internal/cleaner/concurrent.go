@@ -0,0 +1,54 @@
+package cleaner
+
+import (
+	"context"
+	"sync"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// Job is one provider's items to clean
+type Job struct {
+	Provider core.LanguageProvider
+	Items    []core.CleanableItem
+}
+
+// Outcome is what the provider of a job returned
+type Outcome struct {
+	Result *core.CleanResult
+	Err    error
+}
+
+// CleanConcurrently cleans (or with dryRun, previews) the jobs on at most workers goroutines.
+// Outcomes keep the order of jobs and total merges them in that order, so the output is
+// the same whichever provider finishes first. Jobs must not share paths (see DedupeOverlapping).
+func CleanConcurrently(ctx context.Context, jobs []Job, dryRun bool, workers int) ([]Outcome, *core.CleanResult) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	outcomes := make([]Outcome, len(jobs))
+	sem := make(chan struct{}, workers)
+
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(index int, job Job) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result, err := job.Provider.Clean(ctx, job.Items, dryRun)
+			outcomes[index] = Outcome{Result: result, Err: err}
+		}(i, job)
+	}
+	wg.Wait()
+
+	total := &core.CleanResult{Errors: []error{}, DryRun: dryRun}
+	for _, outcome := range outcomes {
+		if outcome.Err == nil {
+			total.Merge(outcome.Result)
+		}
+	}
+	return outcomes, total
+}
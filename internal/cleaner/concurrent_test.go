@@ -0,0 +1,116 @@
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// fakeProvider "cleans" items by reporting their sizes after a random delay,
+// failing the items whose description starts with "bad"
+type fakeProvider struct {
+	name    string
+	running *atomic.Int32 // Cleans in progress across all fake providers
+	peak    *atomic.Int32 // Most cleans seen in progress at once
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) DetectInstalled(context.Context) ([]core.Installation, error) { return nil, nil }
+
+func (p *fakeProvider) GetGlobalCacheUsage(context.Context) (*core.DiskUsage, error) {
+	return &core.DiskUsage{}, nil
+}
+
+func (p *fakeProvider) GetEnvVars(context.Context) map[string]string { return nil }
+
+func (p *fakeProvider) GetCleanableItems(context.Context) ([]core.CleanableItem, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) Clean(_ context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	running := p.running.Add(1)
+	defer p.running.Add(-1)
+	for peak := p.peak.Load(); running > peak && !p.peak.CompareAndSwap(peak, running); peak = p.peak.Load() {
+	}
+	time.Sleep(time.Duration(rand.IntN(2000)) * time.Microsecond)
+
+	result := &core.CleanResult{Errors: []error{}, DryRun: dryRun}
+	for _, item := range items {
+		if strings.HasPrefix(item.Description, "bad") {
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %s failed", p.name, item.Description))
+			continue
+		}
+		result.ItemsCleaned++
+		result.SpaceReclaimed += item.Size
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
+
+func TestCleanConcurrentlyMergesInJobOrder(t *testing.T) {
+	var running, peak atomic.Int32
+	var jobs []Job
+	for i := range 12 {
+		jobs = append(jobs, Job{
+			Provider: &fakeProvider{name: fmt.Sprintf("lang%d", i), running: &running, peak: &peak},
+			Items: []core.CleanableItem{
+				{Description: "cache", Size: 100},
+				{Description: "bad cache", Size: 1},
+				{Description: "logs", Size: int64(i)},
+			},
+		})
+	}
+
+	const workers = 3
+	for range 10 {
+		outcomes, total := CleanConcurrently(context.Background(), jobs, false, workers)
+
+		if len(outcomes) != len(jobs) {
+			t.Fatalf("got %d outcomes, want %d", len(outcomes), len(jobs))
+		}
+		// 12 providers clean 100 bytes plus 0..11
+		if total.ItemsCleaned != 24 || total.SpaceReclaimed != 12*100+66 || len(total.Errors) != 12 {
+			t.Fatalf("total = %d items, %d bytes, %d errors; want 24, 1266, 12", total.ItemsCleaned, total.SpaceReclaimed, len(total.Errors))
+		}
+		for i, err := range total.Errors {
+			if want := fmt.Sprintf("lang%d: bad cache failed", i); err.Error() != want {
+				t.Fatalf("error %d = %q, want %q", i, err, want)
+			}
+		}
+	}
+
+	if peak.Load() > workers {
+		t.Errorf("%d cleans ran at once, want at most %d", peak.Load(), workers)
+	}
+}
+
+func TestCleanConcurrentlyKeepsFailedProviders(t *testing.T) {
+	var running, peak atomic.Int32
+	jobs := []Job{
+		{Provider: &fakeProvider{name: "good", running: &running, peak: &peak}, Items: []core.CleanableItem{{Description: "cache", Size: 10}}},
+		{Provider: failingProvider{&fakeProvider{name: "broken", running: &running, peak: &peak}}},
+	}
+
+	outcomes, total := CleanConcurrently(context.Background(), jobs, true, 2)
+	if outcomes[0].Err != nil || outcomes[1].Err == nil {
+		t.Fatalf("outcomes = %+v, want only the second to fail", outcomes)
+	}
+	if !total.DryRun || total.ItemsCleaned != 1 || total.SpaceReclaimed != 10 {
+		t.Errorf("total = %+v, want the good provider's dry run only", total)
+	}
+}
+
+// failingProvider fails every clean
+type failingProvider struct{ *fakeProvider }
+
+func (failingProvider) Clean(context.Context, []core.CleanableItem, bool) (*core.CleanResult, error) {
+	return nil, errors.New("clean failed")
+}
@@ -0,0 +1,89 @@
+package cleaner
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// itemRef locates a path-based cleanable item within the per-provider item lists
+type itemRef struct {
+	group int
+	index int
+	path  string
+}
+
+// DedupeOverlapping removes cleanable items whose path is the same as, or nested
+// under, another item's path, so each directory is cleaned (and counted) once.
+// The surviving item keeps the safest classification of everything it absorbed.
+// Command-based items without a path, and items removing only some files under their path,
+// are left untouched: they don't take the whole directory with them.
+func DedupeOverlapping(groups [][]core.CleanableItem) [][]core.CleanableItem {
+	var refs []itemRef
+	for g, items := range groups {
+		for i, item := range items {
+			if item.Path == "" || len(item.Patterns) > 0 {
+				continue
+			}
+			refs = append(refs, itemRef{group: g, index: i, path: resolvePath(item.Path)})
+		}
+	}
+
+	// Shorter paths first so ancestors are kept before their descendants
+	sort.SliceStable(refs, func(a, b int) bool {
+		return len(refs[a].path) < len(refs[b].path)
+	})
+
+	dropped := make(map[itemRef]bool)
+	var kept []itemRef
+	for _, ref := range refs {
+		keeper := -1
+		for k, candidate := range kept {
+			if isSameOrUnder(ref.path, candidate.path) {
+				keeper = k
+				break
+			}
+		}
+
+		if keeper == -1 {
+			kept = append(kept, ref)
+			continue
+		}
+
+		// Keep the safest classification: unsafe wins
+		owner := &groups[kept[keeper].group][kept[keeper].index]
+		owner.Safe = owner.Safe && groups[ref.group][ref.index].Safe
+		dropped[itemRef{group: ref.group, index: ref.index}] = true
+	}
+
+	deduped := make([][]core.CleanableItem, len(groups))
+	for g, items := range groups {
+		for i, item := range items {
+			if !dropped[itemRef{group: g, index: i}] {
+				deduped[g] = append(deduped[g], item)
+			}
+		}
+	}
+
+	return deduped
+}
+
+// resolvePath returns the absolute, symlink-resolved form of a path
+func resolvePath(path string) string {
+	expandedPath := scanner.ExpandHome(path)
+	if absPath, err := filepath.Abs(expandedPath); err == nil {
+		expandedPath = absPath
+	}
+	if realPath, err := filepath.EvalSymlinks(expandedPath); err == nil {
+		expandedPath = realPath
+	}
+	return filepath.Clean(expandedPath)
+}
+
+// isSameOrUnder reports whether path equals parent or is nested under it
+func isSameOrUnder(path, parent string) bool {
+	return path == parent || strings.HasPrefix(path, parent+string(filepath.Separator))
+}
@@ -0,0 +1,43 @@
+package cleaner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+)
+
+func TestDedupeOverlappingWithinOneProvider(t *testing.T) {
+	root := t.TempDir()
+	cache := filepath.Join(root, "go", "pkg", "mod")
+
+	groups := DedupeOverlapping([][]core.CleanableItem{{
+		{Description: "Module Download Cache", Path: filepath.Join(cache, "cache", "download"), Size: 40, Safe: false},
+		{Description: "Module Cache", Path: cache, Size: 100, Safe: true},
+		{Description: "Build Cache", Path: filepath.Join(root, "go-build"), Size: 10, Safe: true},
+		{Description: "Test Cache", Command: "go clean -testcache", Safe: true},
+	}})
+
+	var descriptions []string
+	for _, item := range groups[0] {
+		descriptions = append(descriptions, item.Description)
+		if item.Description == "Module Cache" && item.Safe {
+			t.Error("Module Cache stayed safe after absorbing an unsafe item")
+		}
+	}
+	if len(descriptions) != 3 || descriptions[0] != "Module Cache" || descriptions[1] != "Build Cache" || descriptions[2] != "Test Cache" {
+		t.Errorf("deduped items = %v, want Module Cache, Build Cache and Test Cache", descriptions)
+	}
+}
+
+func TestDedupeOverlappingAcrossProviders(t *testing.T) {
+	gradle := filepath.Join(t.TempDir(), ".gradle", "caches")
+
+	groups := DedupeOverlapping([][]core.CleanableItem{
+		{{Description: "Gradle Cache", Path: gradle, Size: 100, Safe: true}},
+		{{Description: "Gradle Cache", Path: gradle + string(filepath.Separator), Size: 100, Safe: true}},
+	})
+	if len(groups[0]) != 1 || len(groups[1]) != 0 {
+		t.Errorf("deduped groups = %v, want the shared cache cleaned once, by the first provider", groups)
+	}
+}
@@ -0,0 +1,48 @@
+package cleaner
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"dependency-hell-cli/internal/scanner"
+)
+
+// CheckRemovable refuses to remove paths outside the home directory or outside the allowed roots.
+// The real home directory and its parents are refused even when DHELL_HOME points elsewhere.
+// Symlinks are resolved first so a link cannot redirect a removal elsewhere.
+func CheckRemovable(path string, allowedRoots []string) error {
+	home, err := scanner.HomeDir()
+	if err != nil {
+		return fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	home = resolvePath(home)
+
+	if !filepath.IsAbs(scanner.ExpandHome(path)) {
+		return fmt.Errorf("refusing to remove relative path %s", path)
+	}
+
+	target := resolvePath(path)
+	if filepath.Dir(target) == target {
+		return fmt.Errorf("refusing to remove filesystem root %s", target)
+	}
+	for _, protected := range scanner.ProtectedHomeDirs() {
+		protected = resolvePath(protected)
+		if target == protected {
+			return fmt.Errorf("refusing to remove your home directory %s", target)
+		}
+		if isSameOrUnder(protected, target) {
+			return fmt.Errorf("refusing to remove %s: it contains your home directory", target)
+		}
+	}
+	if !isSameOrUnder(target, home) {
+		return fmt.Errorf("refusing to remove %s: not inside your home directory", target)
+	}
+
+	for _, root := range allowedRoots {
+		if target == resolvePath(scanner.ExpandHome(root)) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("refusing to remove %s: not a known location", target)
+}
@@ -0,0 +1,166 @@
+package cleaner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/output"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// ManagerRoot describes where a version manager keeps all of its data
+type ManagerRoot struct {
+	Name        string
+	DefaultRoot string
+	EnvVar      string   // Overrides the default root when set
+	ShellHint   string   // What to remove from shell startup files afterwards
+	Markers     []string // Entries of which at least one must exist in an overridden root
+}
+
+// managerRoots maps version manager names to their data directories
+var managerRoots = []ManagerRoot{
+	{"pyenv", "~/.pyenv", "PYENV_ROOT", `eval "$(pyenv init -)"`, []string{"versions", "shims", "libexec/pyenv"}},
+	{"nvm", "~/.nvm", "NVM_DIR", "the nvm.sh source line", []string{"nvm.sh", "versions"}},
+	{"goenv", "~/.goenv", "GOENV_ROOT", `eval "$(goenv init -)"`, []string{"versions", "shims", "libexec/goenv"}},
+	{"sdkman", "~/.sdkman", "SDKMAN_DIR", "the sdkman-init.sh source line", []string{"bin/sdkman-init.sh", "candidates"}},
+	{"rustup", "~/.rustup", "RUSTUP_HOME", "~/.cargo/env (cargo binaries are kept)", []string{"toolchains", "settings.toml"}},
+	{"volta", "~/.volta", "VOLTA_HOME", "VOLTA_HOME and its PATH entry", []string{"tools", "bin/volta"}},
+	{"fnm", "~/.local/share/fnm", "FNM_DIR", `eval "$(fnm env)"`, []string{"node-versions"}},
+	{"asdf", "~/.asdf", "ASDF_DATA_DIR", "the asdf.sh source line", []string{"installs", "shims", "asdf.sh"}},
+	{"phpenv", "~/.phpenv", "PHPENV_ROOT", `eval "$(phpenv init -)"`, []string{"versions", "shims", "libexec/phpenv"}},
+}
+
+// FindManagerRoot returns the version manager with the given name
+func FindManagerRoot(name string) (ManagerRoot, bool) {
+	for _, manager := range managerRoots {
+		if manager.Name == strings.ToLower(name) {
+			return manager, true
+		}
+	}
+	return ManagerRoot{}, false
+}
+
+// ManagerNames lists the version managers that can be reset
+func ManagerNames() []string {
+	var names []string
+	for _, manager := range managerRoots {
+		names = append(names, manager.Name)
+	}
+	return names
+}
+
+// Root returns the manager's data directory, honoring its environment variable
+func (m ManagerRoot) Root() string {
+	if m.EnvVar != "" {
+		if root := scanner.GetEnvVar(m.EnvVar); root != "" {
+			return root
+		}
+	}
+	return m.DefaultRoot
+}
+
+// overridden reports whether the environment variable moves the root away from the default
+func (m ManagerRoot) overridden() bool {
+	return !scanner.SamePath(m.Root(), m.DefaultRoot)
+}
+
+// hasMarker reports whether root contains one of the manager's marker entries
+func (m ManagerRoot) hasMarker(root string) bool {
+	for _, marker := range m.Markers {
+		if scanner.PathExists(filepath.Join(scanner.ExpandHome(root), filepath.FromSlash(marker))) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedRoots lists every location a reset may remove. An overridden root is only
+// allowed when it looks like the manager's data directory, so a mistyped variable
+// cannot point a reset at an unrelated directory.
+func (m ManagerRoot) allowedRoots() []string {
+	roots := []string{m.DefaultRoot}
+	if m.overridden() && m.hasMarker(m.Root()) {
+		roots = append(roots, m.Root())
+	}
+	return roots
+}
+
+// CheckResettable verifies that the manager's root may be removed
+func (m ManagerRoot) CheckResettable() error {
+	if m.overridden() && !m.hasMarker(m.Root()) {
+		return fmt.Errorf("refusing to remove %s: %s points to a directory without any %s data (%s)",
+			m.Root(), m.EnvVar, m.Name, strings.Join(m.Markers, ", "))
+	}
+	return CheckRemovable(m.Root(), m.allowedRoots())
+}
+
+// Contents lists the top-level entries of the manager's root with their sizes, largest first
+func (m ManagerRoot) Contents(ctx context.Context) []core.DiskUsageItem {
+	var items []core.DiskUsageItem
+
+	root := scanner.ExpandHome(m.Root())
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return items
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(root, entry.Name())
+		var size int64
+		if entry.IsDir() {
+			size, _ = scanner.CalculateDirSize(ctx, path)
+		} else if info, err := entry.Info(); err == nil {
+			size = info.Size()
+		}
+		items = append(items, core.DiskUsageItem{
+			Path:        path,
+			Description: entry.Name(),
+			Size:        size,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Size > items[j].Size
+	})
+
+	return items
+}
+
+// Remove deletes the manager's root after re-checking the guard
+func (m ManagerRoot) Remove() error {
+	if err := m.CheckResettable(); err != nil {
+		return err
+	}
+	return CleanDirectory(m.Root())
+}
+
+// ConfirmReset requires the user to type the manager name before resetting it
+func ConfirmReset(manager ManagerRoot, contents []core.DiskUsageItem, totalSize int64) bool {
+	fmt.Println()
+	fmt.Printf("⚠️  WARNING: This will permanently delete %s and everything it installed!\n", scanner.ExpandHome(manager.Root()))
+	fmt.Println()
+	fmt.Println("Contents:")
+
+	for _, item := range contents {
+		fmt.Printf("  • %s (%s)\n", item.Description, output.FormatBytes(item.Size))
+	}
+
+	fmt.Println()
+	fmt.Printf("Total: %s will be removed\n", output.FormatBytes(totalSize))
+	fmt.Println()
+	fmt.Printf("Type %q to confirm: ", manager.Name)
+
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	return strings.TrimSpace(response) == manager.Name
+}
@@ -0,0 +1,42 @@
+package cleaner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckResettableOverriddenRoot(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("DHELL_HOME", home)
+	nvm, _ := FindManagerRoot("nvm")
+
+	// An override pointing at an unrelated directory is refused
+	projects := filepath.Join(home, "projects")
+	if err := os.MkdirAll(filepath.Join(projects, "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NVM_DIR", projects)
+	if err := nvm.CheckResettable(); err == nil {
+		t.Fatalf("CheckResettable() with NVM_DIR=%s = nil, want an error", projects)
+	}
+
+	// An override that holds nvm data is allowed
+	custom := filepath.Join(home, "tools", "nvm")
+	if err := os.MkdirAll(filepath.Join(custom, "versions"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NVM_DIR", custom)
+	if err := nvm.CheckResettable(); err != nil {
+		t.Fatalf("CheckResettable() with NVM_DIR=%s = %v, want nil", custom, err)
+	}
+
+	// The default root needs no markers
+	t.Setenv("NVM_DIR", "")
+	if err := os.MkdirAll(filepath.Join(home, ".nvm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := nvm.CheckResettable(); err != nil {
+		t.Fatalf("CheckResettable() of the default root = %v, want nil", err)
+	}
+}
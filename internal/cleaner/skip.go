@@ -0,0 +1,44 @@
+package cleaner
+
+import (
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// SkipItems removes the items whose description matches one of skip, ignoring case, from
+// the per-provider item lists. A trailing parenthesized detail such as a file count doesn't
+// need to be given. It also returns the skip entries that matched nothing, usually typos.
+func SkipItems(groups [][]core.CleanableItem, skip []string) ([][]core.CleanableItem, []string) {
+	matched := make([]bool, len(skip))
+	kept := make([][]core.CleanableItem, len(groups))
+	for g, items := range groups {
+		for _, item := range items {
+			index := matchSkip(item.Description, skip)
+			if index < 0 {
+				kept[g] = append(kept[g], item)
+				continue
+			}
+			matched[index] = true
+		}
+	}
+
+	var unmatched []string
+	for i, description := range skip {
+		if !matched[i] {
+			unmatched = append(unmatched, description)
+		}
+	}
+	return kept, unmatched
+}
+
+// matchSkip returns the index of the skip entry matching description, or -1
+func matchSkip(description string, skip []string) int {
+	base, _, _ := strings.Cut(description, " (")
+	for i, s := range skip {
+		if strings.EqualFold(description, s) || strings.EqualFold(base, s) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,41 @@
+package cleaner
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/output"
+)
+
+func TestSkippedItemsAreNeitherPreviewedNorCleaned(t *testing.T) {
+	groups := [][]core.CleanableItem{
+		{
+			{Description: "Maven Repository (1,024 files)", Path: "/m2/repository", Size: 500},
+			{Description: "Gradle Cache", Path: "/gradle/caches", Size: 200},
+		},
+		{{Description: "NPM Cache", Path: "/npm/_cacache", Size: 100}},
+	}
+
+	kept, unmatched := SkipItems(groups, []string{"maven repository", "NPM CACHE", "Cargo Registry"})
+	if !slices.Equal(unmatched, []string{"Cargo Registry"}) {
+		t.Errorf("unmatched = %v, want only Cargo Registry", unmatched)
+	}
+
+	var running, peak atomic.Int32
+	var jobs []Job
+	for i, items := range kept {
+		if preview := output.RenderCleanPreview("Test", items); strings.Contains(preview, "Maven") || strings.Contains(preview, "NPM") {
+			t.Errorf("preview of group %d shows a skipped item:\n%s", i, preview)
+		}
+		jobs = append(jobs, Job{Provider: &fakeProvider{name: "test", running: &running, peak: &peak}, Items: items})
+	}
+
+	_, total := CleanConcurrently(context.Background(), jobs, false, 2)
+	if len(total.Items) != 1 || total.Items[0].Description != "Gradle Cache" {
+		t.Errorf("cleaned %v, want only the Gradle Cache", total.Items)
+	}
+}
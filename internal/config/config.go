@@ -0,0 +1,142 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// Config holds user settings read from the config file
+type Config struct {
+	// SafeOverrides maps a provider name to cleanable item descriptions and whether
+	// they are safe, e.g. {"java": {"Maven Repository": true}}. Names are case-insensitive.
+	SafeOverrides map[string]map[string]bool `json:"safe_overrides,omitempty"`
+
+	// ParallelWalkDepth is how many directory levels size walks descend before splitting
+	// work across workers. Unset uses the built-in default; --parallel-walk-depth wins.
+	ParallelWalkDepth *int `json:"parallel_walk_depth,omitempty"`
+
+	// Concurrency is how many workers measure directory sizes in parallel, e.g. 2 for a
+	// home directory on NFS. Unset uses the built-in default; scan --concurrency wins.
+	Concurrency *int `json:"concurrency,omitempty"`
+
+	// CachePaths maps a language to path templates that replace its default cache locations,
+	// e.g. {"rust": {"cargo_registry": "${CARGO_HOME}/registry"}}. See scanner.ExpandTemplate.
+	CachePaths map[string]map[string]string `json:"cache_paths,omitempty"`
+}
+
+// DefaultPath returns the config file location, honoring XDG_CONFIG_HOME
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "dhell", "config.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".config", "dhell", "config.json")
+	}
+	return filepath.Join(home, ".config", "dhell", "config.json")
+}
+
+// Load reads the config file at path. A missing file yields an empty config.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// ValidateCachePaths checks that every cache path names a known language and key
+// and that its template expands in the current environment
+func (c *Config) ValidateCachePaths(known map[string][]string) error {
+	for language, paths := range c.CachePaths {
+		keys, ok := known[language]
+		if !ok {
+			return fmt.Errorf("cache_paths: unknown language %q", language)
+		}
+
+		for key, template := range paths {
+			if !slices.Contains(keys, key) {
+				return fmt.Errorf("cache_paths.%s: unknown path %q (known: %s)", language, key, strings.Join(keys, ", "))
+			}
+			if _, err := scanner.ExpandTemplate(template); err != nil {
+				return fmt.Errorf("cache_paths.%s.%s: %w", language, key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ApplySafeOverrides sets the Safe flag of items as configured for the provider.
+// It returns a warning for every item that is unsafe by default but overridden as safe.
+func (c *Config) ApplySafeOverrides(providerName string, items []core.CleanableItem) []string {
+	overrides := c.lookupProvider(providerName)
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	var warnings []string
+	for i, item := range items {
+		safe, ok := lookupFold(overrides, item.Description)
+		if !ok {
+			continue
+		}
+		if safe && !item.Safe {
+			warnings = append(warnings, fmt.Sprintf("%s is unsafe by default but marked safe in your config", item.Description))
+		}
+		items[i].Safe = safe
+	}
+
+	return warnings
+}
+
+// lookupProvider returns the overrides whose key matches the provider name.
+// Keys may be the full name ("Node.js") or the short language argument ("node"): a full
+// name wins, then the longest key the name starts with, so the match never depends on map order.
+func (c *Config) lookupProvider(providerName string) map[string]bool {
+	keys := make([]string, 0, len(c.SafeOverrides))
+	for key := range c.SafeOverrides {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var match string
+	for _, key := range keys {
+		if strings.EqualFold(key, providerName) {
+			return c.SafeOverrides[key]
+		}
+		if key != "" && len(key) > len(match) && strings.HasPrefix(strings.ToLower(providerName), strings.ToLower(key)) {
+			match = key
+		}
+	}
+	if match == "" {
+		return nil
+	}
+	return c.SafeOverrides[match]
+}
+
+// lookupFold finds a value by case-insensitive key
+func lookupFold(values map[string]bool, key string) (bool, bool) {
+	for k, v := range values {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+)
+
+func TestApplySafeOverridesProviderMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]map[string]bool
+		provider  string
+		wantSafe  bool
+	}{
+		{"short name", map[string]map[string]bool{"node": {"Cache": true}}, "Node.js", true},
+		{"full name wins over short name", map[string]map[string]bool{"go": {"Cache": false}, "golang": {"Cache": true}}, "Golang", true},
+		{"longest prefix wins", map[string]map[string]bool{"g": {"Cache": false}, "go": {"Cache": true}}, "Golang", true},
+		{"substring is not a match", map[string]map[string]bool{"ava": {"Cache": true}}, "Java", false},
+		{"case-insensitive", map[string]map[string]bool{"PYTHON": {"cache": true}}, "Python", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Run repeatedly so a match that depends on map order would show up
+			for range 20 {
+				cfg := &Config{SafeOverrides: tt.overrides}
+				items := []core.CleanableItem{{Description: "Cache"}}
+				cfg.ApplySafeOverrides(tt.provider, items)
+				if items[0].Safe != tt.wantSafe {
+					t.Fatalf("Safe = %v, want %v", items[0].Safe, tt.wantSafe)
+				}
+			}
+		})
+	}
+}
+
+func TestApplySafeOverridesWarnsOnUnsafeDefaults(t *testing.T) {
+	cfg := &Config{SafeOverrides: map[string]map[string]bool{"java": {"Maven Repository": true}}}
+	items := []core.CleanableItem{{Description: "Maven Repository"}, {Description: "Gradle Caches", Safe: true}}
+
+	warnings := cfg.ApplySafeOverrides("Java", items)
+	if len(warnings) != 1 || !items[0].Safe {
+		t.Fatalf("warnings = %q, items = %+v; want one warning and Maven Repository safe", warnings, items)
+	}
+}
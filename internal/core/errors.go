@@ -0,0 +1,69 @@
+package core
+
+import "errors"
+
+// ProviderErrorKind classifies why a provider couldn't detect its language
+type ProviderErrorKind int
+
+const (
+	NotInstalled     ProviderErrorKind = iota // The binary isn't on PATH
+	Unusable                                  // The binary is on PATH but fails to run
+	PermissionDenied                          // The binary is on PATH but can't be executed
+	Timeout                                   // The binary didn't answer before the scan was cancelled
+)
+
+// String returns the short description used as the error prefix
+func (k ProviderErrorKind) String() string {
+	switch k {
+	case NotInstalled:
+		return "not installed"
+	case Unusable:
+		return "installation broken"
+	case PermissionDenied:
+		return "permission denied"
+	case Timeout:
+		return "timed out"
+	default:
+		return "unknown error"
+	}
+}
+
+// ProviderError is returned by DetectInstalled so callers can tell failure classes apart.
+// It matches ErrNotInstalled or ErrBrokenInstall with errors.Is depending on its Kind.
+type ProviderError struct {
+	Kind ProviderErrorKind
+	Err  error
+}
+
+// NewProviderError wraps err with a failure kind
+func NewProviderError(kind ProviderErrorKind, err error) *ProviderError {
+	return &ProviderError{Kind: kind, Err: err}
+}
+
+func (e *ProviderError) Error() string {
+	return e.Kind.String() + ": " + e.Err.Error()
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Is keeps the ErrNotInstalled and ErrBrokenInstall sentinels working for callers that predate Kind
+func (e *ProviderError) Is(target error) bool {
+	switch target {
+	case ErrNotInstalled:
+		return e.Kind == NotInstalled
+	case ErrBrokenInstall:
+		return e.Kind != NotInstalled
+	}
+	return false
+}
+
+// ProviderErrorKindOf returns the kind of a provider error, and false for any other error
+func ProviderErrorKindOf(err error) (ProviderErrorKind, bool) {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.Kind, true
+	}
+	return 0, false
+}
@@ -1,24 +1,91 @@
 package core
 
-// LanguageProvider defines the interface that all language providers must implement
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrNotInstalled is wrapped by DetectInstalled errors when the language isn't installed at all
+var ErrNotInstalled = errors.New("not installed")
+
+// ErrBrokenInstall is wrapped by DetectInstalled errors when the language's binary is on PATH
+// but can't be run, e.g. a dangling shim or a version command that fails
+var ErrBrokenInstall = errors.New("installation broken")
+
+// LanguageProvider defines the interface that all language providers must implement.
+// The directory walks and subprocesses of a call stop once its ctx is done (e.g. --timeout).
 type LanguageProvider interface {
 	Name() string
-	DetectInstalled() ([]Installation, error)
-	GetGlobalCacheUsage() (*DiskUsage, error)
-	GetEnvVars() map[string]string
+	DetectInstalled(ctx context.Context) ([]Installation, error)
+	GetGlobalCacheUsage(ctx context.Context) (*DiskUsage, error)
+	GetEnvVars(ctx context.Context) map[string]string
 
 	// Phase 2: Cleaning support
-	GetCleanableItems() ([]CleanableItem, error)
-	Clean(items []CleanableItem) (*CleanResult, error)
+	GetCleanableItems(ctx context.Context) ([]CleanableItem, error)
+	Clean(ctx context.Context, items []CleanableItem, dryRun bool) (*CleanResult, error) // dryRun reports without deleting
+}
+
+// InfoSection is an extra titled list of paths shown by the info command
+type InfoSection struct {
+	Title string
+	Note  string // Optional: a caveat shown under the title
+	Items []DiskUsageItem
+}
+
+// InfoSectionProvider is implemented by providers that have extra details to show in info
+type InfoSectionProvider interface {
+	GetInfoSections(ctx context.Context) []InfoSection
+}
+
+// BreakdownProvider is implemented by providers that can split a cache by the kind of data in it.
+// This usually means opening every file, so info only asks for it with --breakdown.
+type BreakdownProvider interface {
+	GetBreakdown(ctx context.Context) []InfoSection
+}
+
+// BinaryLocator is implemented by providers that can classify any binary of their language,
+// not only the one first on PATH
+type BinaryLocator interface {
+	BinaryName() string
+	ClassifySource(realPath string) (InstallSource, string)
+}
+
+// CacheCandidate is a less-standard location where a language may keep caches.
+// Pattern may contain ~, $TMPDIR, $XDG_CACHE_HOME, $XDG_DATA_HOME and glob wildcards.
+type CacheCandidate struct {
+	Pattern     string
+	Description string
+}
+
+// DeepCacheProvider is implemented by providers that can suggest extra cache locations for --deep scans
+type DeepCacheProvider interface {
+	DeepCacheCandidates() []CacheCandidate
+}
+
+// ProjectScanner is implemented by providers that find regenerable build artifacts
+// inside a project tree (scan --local, clean --local)
+type ProjectScanner interface {
+	ScanProject(ctx context.Context, root string) []CleanableItem
+}
+
+// BinaryLocation represents one copy of a language binary found on PATH
+type BinaryLocation struct {
+	Path     string // Path as found in the PATH directory
+	RealPath string // Path after resolving symlinks
+	Source   InstallSource
 }
 
 // Installation represents a detected installation of a language/runtime
 type Installation struct {
-	Version     string
-	Source      InstallSource
-	BinaryPath  string
-	ManagerPath string
-	ManagerName string // Specific version manager name (e.g., "goenv", "nvm", "pyenv")
+	Version      string        `json:"version"`
+	Source       InstallSource `json:"source"`
+	BinaryPath   string        `json:"binary_path"`
+	ManagerPath  string        `json:"manager_path,omitempty"`
+	ManagerName  string        `json:"manager_name,omitempty"`  // Specific version manager name (e.g., "goenv", "nvm", "pyenv")
+	Distribution string        `json:"distribution,omitempty"`  // Vendor build of the runtime (e.g., "Temurin", "Zulu"), if known
+	Arch         string        `json:"arch,omitempty"`          // Homebrew prefix architecture ("arm64", "x86_64"), if installed via Homebrew
+	SourceReason string        `json:"source_reason,omitempty"` // Why the source was classified as it was (e.g., "binary resolves under .pyenv")
 }
 
 // InstallSource represents where the language was installed from
@@ -29,20 +96,24 @@ const (
 	SourceHomebrew       InstallSource = "Homebrew"
 	SourceSystem         InstallSource = "System"
 	SourceManual         InstallSource = "Manual"
+	SourceWindows        InstallSource = "Windows" // Native Windows install reached from WSL via /mnt/<drive>
 	SourceUnknown        InstallSource = "Unknown"
 )
 
 // DiskUsage represents disk space usage information
 type DiskUsage struct {
-	Items []DiskUsageItem
-	Total int64
+	Items []DiskUsageItem `json:"items"`
+	Total int64           `json:"total"`
 }
 
 // DiskUsageItem represents a single disk usage entry
 type DiskUsageItem struct {
-	Path        string
-	Description string
-	Size        int64
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	Size        int64  `json:"size"`
+	FileCount   int64  `json:"file_count,omitempty"` // Only filled in when file counts are requested
+	Heuristic   bool   `json:"heuristic,omitempty"`  // Found by --deep discovery rather than a known location
+	Value       string `json:"value,omitempty"`      // A setting shown in place of a path, e.g. "pnpm@8.15.4"; such items have no Path
 }
 
 // Status represents the health status of an installation
@@ -73,7 +144,7 @@ func DetermineStatus(source InstallSource) Status {
 	switch source {
 	case SourceVersionManager:
 		return StatusGood
-	case SourceHomebrew:
+	case SourceHomebrew, SourceWindows:
 		return StatusWarning
 	case SourceSystem, SourceUnknown:
 		return StatusBad
@@ -87,8 +158,60 @@ type CleanableItem struct {
 	Path        string
 	Description string
 	Size        int64
-	Command     string // Optional: command to run instead of rm -rf
-	Safe        bool   // Whether it's safe to delete without extra confirmation
+	Command     string   // Optional: command to run instead of rm -rf
+	Args        []string // Optional: argv of Command when an argument must not be split on whitespace
+	Patterns    []string // Optional: only the files under Path whose names match these globs are removed
+	Safe        bool     // Whether it's safe to delete without extra confirmation
+	Reclaim     ReclaimKind
+	RebuildCost string // Optional: what it costs to get the data back, e.g. "re-downloads modules on next build"
+}
+
+// CommandArgs returns the argv of the item's command: Args when set, otherwise Command split on
+// whitespace. It is empty for items without a command.
+func (i CleanableItem) CommandArgs() []string {
+	if len(i.Args) > 0 {
+		return i.Args
+	}
+	return strings.Fields(i.Command)
+}
+
+// ReclaimKind describes how trustworthy a cleanable item's size is and whether the data comes back
+type ReclaimKind int
+
+const (
+	ReclaimUnknown     ReclaimKind = iota // Not stated; Size is shown without claiming either way
+	ReclaimExact                          // Exactly Size is freed and nothing rebuilds it (e.g., orphaned virtualenvs)
+	ReclaimUpperBound                     // At most Size is freed (e.g., prune commands that keep referenced entries)
+	ReclaimRegenerable                    // Exactly Size is freed and tools rebuild it on demand (e.g., download caches)
+)
+
+// String returns the name used for the kind in the external provider protocol, "" when unknown
+func (k ReclaimKind) String() string {
+	switch k {
+	case ReclaimExact:
+		return "exact"
+	case ReclaimUpperBound:
+		return "upper_bound"
+	case ReclaimRegenerable:
+		return "regenerable"
+	default:
+		return ""
+	}
+}
+
+// EstimateReclaimable returns the reclaimable size of items and whether it is exact.
+// Directory removals reclaim exactly their size; prune-style commands only reclaim up to it,
+// and an item of unknown kind makes no promise, so the total isn't exact either.
+func EstimateReclaimable(items []CleanableItem) (int64, bool) {
+	var total int64
+	exact := true
+	for _, item := range items {
+		total += item.Size
+		if item.Reclaim == ReclaimUpperBound || item.Reclaim == ReclaimUnknown {
+			exact = false
+		}
+	}
+	return total, exact
 }
 
 // CleanResult represents the result of a cleaning operation
@@ -96,4 +219,17 @@ type CleanResult struct {
 	ItemsCleaned   int
 	SpaceReclaimed int64
 	Errors         []error
+	DryRun         bool            // Nothing was deleted; counts are what would have been cleaned
+	Items          []CleanableItem // The items cleaned, or that would have been in a dry run
+}
+
+// Merge adds another result's counts and errors to r, keeping r's errors first
+func (r *CleanResult) Merge(other *CleanResult) {
+	if other == nil {
+		return
+	}
+	r.ItemsCleaned += other.ItemsCleaned
+	r.SpaceReclaimed += other.SpaceReclaimed
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Items = append(r.Items, other.Items...)
 }
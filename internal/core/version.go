@@ -0,0 +1,99 @@
+package core
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compares two version strings numerically, component by component, and returns
+// -1, 0 or 1. A leading "v" or "go" is ignored, and a pre-release ("1.22.0-rc1", "1.22rc1") sorts
+// before its release. Versions without digits compare equal to everything.
+func CompareVersions(a, b string) int {
+	numbersA, preA := splitVersion(a)
+	numbersB, preB := splitVersion(b)
+	if len(numbersA) == 0 || len(numbersB) == 0 {
+		return 0
+	}
+
+	for i := 0; i < max(len(numbersA), len(numbersB)); i++ {
+		var x, y int
+		if i < len(numbersA) {
+			x = numbersA[i]
+		}
+		if i < len(numbersB) {
+			y = numbersB[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case preA && !preB:
+		return -1
+	case !preA && preB:
+		return 1
+	}
+	return 0
+}
+
+// SortVersionsNewestFirst sorts version strings from newest to oldest. Versions that
+// CompareVersions can't order, such as "system" or "miniconda3-latest", keep their relative order.
+func SortVersionsNewestFirst(versions []string) {
+	slices.SortStableFunc(versions, func(a, b string) int {
+		return CompareVersions(b, a)
+	})
+}
+
+// splitVersion returns the dot-separated numbers of a version and whether it is a pre-release.
+// Build metadata after "+" is dropped.
+func splitVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(version), "go"), "v")
+	version, _, _ = strings.Cut(version, "+")
+
+	var numbers []int
+	for _, part := range strings.Split(version, ".") {
+		digits := part
+		for i, r := range part {
+			if r < '0' || r > '9' {
+				digits = part[:i]
+				break
+			}
+		}
+		number, err := strconv.Atoi(digits)
+		if err != nil {
+			return numbers, true
+		}
+		numbers = append(numbers, number)
+		if digits != part {
+			// Anything after the number ("-rc1", "beta2") marks a pre-release
+			return numbers, true
+		}
+	}
+	return numbers, false
+}
+
+// NewerInactive returns the highest version installed by the same version manager as the
+// active installation (the first one) when it is newer than the active version
+func NewerInactive(installations []Installation) (Installation, bool) {
+	if len(installations) < 2 || installations[0].Source != SourceVersionManager {
+		return Installation{}, false
+	}
+
+	active := installations[0]
+	newest := active
+	for _, installation := range installations[1:] {
+		if installation.Source != active.Source || installation.ManagerName != active.ManagerName {
+			continue
+		}
+		if CompareVersions(installation.Version, newest.Version) > 0 {
+			newest = installation
+		}
+	}
+
+	return newest, newest.Version != active.Version
+}
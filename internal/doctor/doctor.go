@@ -0,0 +1,117 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// Severity represents how serious a detected conflict is
+type Severity string
+
+const (
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+// severityRank orders severities from least to most serious
+var severityRank = map[Severity]int{
+	SeverityLow:    1,
+	SeverityMedium: 2,
+	SeverityHigh:   3,
+}
+
+// ParseSeverity validates a severity name such as "medium"
+func ParseSeverity(name string) (Severity, error) {
+	severity := Severity(name)
+	if _, ok := severityRank[severity]; !ok {
+		return "", fmt.Errorf("unknown severity %q (want low, medium or high)", name)
+	}
+	return severity, nil
+}
+
+// AtLeast reports whether s is as serious as threshold or more
+func (s Severity) AtLeast(threshold Severity) bool {
+	return severityRank[s] >= severityRank[threshold]
+}
+
+// Conflict represents a problem detected in the development environment
+type Conflict struct {
+	Language    string
+	Severity    Severity
+	Type        string
+	Description string
+	Remediation string
+	Reclaimable int64 // Bytes freed by following the remediation, if known
+}
+
+// Check inspects a provider and its detected installations for conflicts
+type Check func(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict
+
+// checks lists all registered doctor checks
+var checks = []Check{
+	checkDuplicateHomebrew,
+	checkStaleHomebrewVersions,
+	checkJavaHome,
+	checkGoBin,
+	checkGoInstalls,
+	checkComposerGlobalBin,
+	checkNodeGlobalBins,
+	checkOrphanedBinaries,
+}
+
+// LanguageReport holds the installations detected for a language during a doctor run
+type LanguageReport struct {
+	Language      string
+	Installations []core.Installation
+}
+
+// Report represents the outcome of a doctor run
+type Report struct {
+	Conflicts []Conflict
+	Languages []LanguageReport
+}
+
+// Run runs all checks against the given providers, stopping their walks and subprocesses once ctx is done
+func Run(ctx context.Context, providers []core.LanguageProvider) *Report {
+	report := &Report{}
+
+	for _, provider := range providers {
+		installations, err := provider.DetectInstalled(ctx)
+		if err != nil {
+			installations = nil
+		}
+
+		if len(installations) > 0 {
+			report.Languages = append(report.Languages, LanguageReport{
+				Language:      provider.Name(),
+				Installations: installations,
+			})
+		}
+
+		report.Conflicts = append(report.Conflicts, CheckProvider(ctx, provider, installations)...)
+	}
+
+	return report
+}
+
+// HasConflictAtLeast reports whether any conflict is at least as serious as threshold
+func (r *Report) HasConflictAtLeast(threshold Severity) bool {
+	for _, conflict := range r.Conflicts {
+		if conflict.Severity.AtLeast(threshold) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckProvider runs all checks against a single provider and its installations
+func CheckProvider(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict {
+	var conflicts []Conflict
+	for _, check := range checks {
+		conflicts = append(conflicts, check(ctx, provider, installations)...)
+	}
+	return conflicts
+}
@@ -0,0 +1,99 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/providers"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// checkGoBin flags binaries installed in both GOBIN and $GOPATH/bin.
+// Only one copy is updated by go install, while PATH order decides which one runs.
+func checkGoBin(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict {
+	goProvider, ok := provider.(*providers.GoProvider)
+	if !ok || len(installations) == 0 {
+		return nil
+	}
+
+	dirs := goProvider.BinDirs(ctx)
+	overlap := dirs.OverlappingBinaries()
+	if len(overlap) == 0 {
+		return nil
+	}
+
+	return []Conflict{{
+		Language: provider.Name(),
+		Severity: SeverityMedium,
+		Type:     "gobin-overlap",
+		Description: fmt.Sprintf("GOBIN (%s) and GOPATH/bin (%s) both contain: %s; %s",
+			dirs.GOBIN, dirs.GopathBin, strings.Join(overlap, ", "), describePathOrder(dirs)),
+		Remediation: fmt.Sprintf("go install writes to GOBIN; remove the stale copies from %s", dirs.GopathBin),
+	}}
+}
+
+// checkGoInstalls flags Go installed from several sources at once, e.g. goenv, Homebrew and
+// the official installer in /usr/local/go. Only the one PATH resolves to is used by the shell.
+func checkGoInstalls(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict {
+	if _, ok := provider.(*providers.GoProvider); !ok || len(installations) == 0 {
+		return nil
+	}
+
+	// goenv's versions are one install source however many there are
+	var sources []string
+	seen := make(map[string]bool)
+	for _, installation := range installations {
+		label := goInstallLabel(installation)
+		if !seen[label] {
+			seen[label] = true
+			sources = append(sources, label)
+		}
+	}
+	if len(sources) < 2 {
+		return nil
+	}
+
+	active := installations[0]
+	return []Conflict{{
+		Language: provider.Name(),
+		Severity: SeverityMedium,
+		Type:     "multiple-go-installs",
+		Description: fmt.Sprintf("Go is installed %d ways: %s; PATH resolves to %s (%s, %s)",
+			len(sources), strings.Join(sources, ", "), active.BinaryPath, goInstallLabel(active), active.Version),
+		Remediation: "Keep one: brew uninstall go, or sudo rm -rf /usr/local/go, and let a single source (ideally goenv) manage versions",
+	}}
+}
+
+// goInstallLabel names where a Go installation came from, e.g. "goenv" or "Homebrew (arm64)"
+func goInstallLabel(installation core.Installation) string {
+	switch {
+	case installation.ManagerName != "":
+		return installation.ManagerName
+	case installation.Arch != "":
+		return fmt.Sprintf("%s (%s)", installation.Source, installation.Arch)
+	case installation.Source == core.SourceManual:
+		return "manual (/usr/local/go)"
+	}
+	return string(installation.Source)
+}
+
+// describePathOrder explains which of the two bin directories wins on PATH
+func describePathOrder(dirs providers.GoBinDirs) string {
+	gobinIndex := scanner.PathIndex(dirs.GOBIN)
+	gopathIndex := scanner.PathIndex(dirs.GopathBin)
+
+	switch {
+	case gobinIndex == -1 && gopathIndex == -1:
+		return "neither is on PATH"
+	case gopathIndex == -1:
+		return "only GOBIN is on PATH"
+	case gobinIndex == -1:
+		return "only GOPATH/bin is on PATH, so freshly installed binaries are not used"
+	case gobinIndex < gopathIndex:
+		return "GOBIN comes first on PATH"
+	default:
+		return "GOPATH/bin comes first on PATH, so freshly installed binaries are shadowed"
+	}
+}
@@ -0,0 +1,84 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/providers"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// maxOtherFormulae caps how many non-language formulae are named in the stale versions conflict
+const maxOtherFormulae = 5
+
+// checkDuplicateHomebrew flags languages installed in both the arm64 and x86_64 Homebrew prefixes.
+// This happens on Apple Silicon machines that also run an Intel Homebrew under Rosetta.
+func checkDuplicateHomebrew(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict {
+	locator, ok := provider.(core.BinaryLocator)
+	if !ok {
+		return nil
+	}
+
+	installs := scanner.FindHomebrewInstalls(locator.BinaryName())
+	if len(installs) < 2 {
+		return nil
+	}
+
+	var locations []string
+	for arch, path := range installs {
+		locations = append(locations, fmt.Sprintf("%s (%s)", path, arch))
+	}
+	sort.Strings(locations)
+
+	return []Conflict{{
+		Language:    provider.Name(),
+		Severity:    SeverityMedium,
+		Type:        "duplicate-homebrew",
+		Description: fmt.Sprintf("%s is installed by both Homebrews: %s", provider.Name(), strings.Join(locations, ", ")),
+		Remediation: "Uninstall it from the Homebrew you don't use (arch -x86_64 /usr/local/bin/brew uninstall ... for the Intel one)",
+	}}
+}
+
+// checkStaleHomebrewVersions flags formulae whose old versions are still in the Cellar.
+// A single brew list --versions run covers every formula; those belonging to a language are named first.
+func checkStaleHomebrewVersions(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict {
+	if _, ok := provider.(*providers.HomebrewProvider); !ok || len(installations) == 0 {
+		return nil
+	}
+
+	stale, err := providers.StaleHomebrewFormulae(ctx)
+	if err != nil || len(stale) == 0 {
+		return nil
+	}
+
+	var languageFormulae, otherFormulae []string
+	var total int64
+	for _, formula := range stale {
+		total += formula.Size
+		if formula.Language == "" {
+			otherFormulae = append(otherFormulae, formula.Name)
+			continue
+		}
+		languageFormulae = append(languageFormulae, fmt.Sprintf("%s (%s): %s kept besides %s",
+			formula.Name, formula.Language, strings.Join(formula.StaleVersions(), ", "), formula.Current))
+	}
+
+	details := languageFormulae
+	if len(otherFormulae) > maxOtherFormulae {
+		details = append(details, fmt.Sprintf("%s and %d more", strings.Join(otherFormulae[:maxOtherFormulae], ", "), len(otherFormulae)-maxOtherFormulae))
+	} else if len(otherFormulae) > 0 {
+		details = append(details, strings.Join(otherFormulae, ", "))
+	}
+
+	return []Conflict{{
+		Language:    provider.Name(),
+		Severity:    SeverityLow,
+		Type:        "stale-homebrew-versions",
+		Description: fmt.Sprintf("%d formulae keep old versions in the Cellar: %s", len(stale), strings.Join(details, "; ")),
+		Remediation: "brew cleanup removes every version but the linked one (pinned formulae are kept)",
+		Reclaimable: total,
+	}}
+}
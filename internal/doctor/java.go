@@ -0,0 +1,38 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/providers"
+)
+
+// checkJavaHome flags a JAVA_HOME that points to a different JDK than java on PATH.
+// Maven and Gradle use JAVA_HOME, so builds then run on another JDK than the shell.
+func checkJavaHome(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict {
+	java, ok := provider.(*providers.JavaProvider)
+	if !ok || len(installations) == 0 {
+		return nil
+	}
+
+	mismatch := java.CheckJavaHome(ctx, installations[0])
+	if mismatch == nil {
+		return nil
+	}
+
+	// Same version in two places is confusing; different versions break builds
+	severity := SeverityHigh
+	if mismatch.PathVersion == mismatch.JavaHomeVersion {
+		severity = SeverityMedium
+	}
+
+	return []Conflict{{
+		Language: provider.Name(),
+		Severity: severity,
+		Type:     "java-home-mismatch",
+		Description: fmt.Sprintf("java on PATH is %s from %s, but JAVA_HOME points to %s (%s)",
+			mismatch.PathVersion, mismatch.PathHome, mismatch.JavaHome, mismatch.JavaHomeVersion),
+		Remediation: fmt.Sprintf("Point JAVA_HOME at the JDK on PATH (export JAVA_HOME=%s) or put $JAVA_HOME/bin first on PATH", mismatch.PathHome),
+	}}
+}
@@ -0,0 +1,44 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/providers"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// checkNodeGlobalBins flags CLIs installed globally by more than one Node package manager.
+// Upgrading through one manager leaves the other copy behind, and PATH order decides which runs.
+func checkNodeGlobalBins(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict {
+	node, ok := provider.(*providers.NodeProvider)
+	if !ok || len(installations) == 0 {
+		return nil
+	}
+
+	var conflicts []Conflict
+	for _, conflict := range node.GlobalBinConflicts(ctx) {
+		var owners []string
+		for _, bin := range conflict.Bins {
+			owners = append(owners, fmt.Sprintf("%s (%s)", bin.Manager, bin.Dir))
+		}
+
+		winner := conflict.Bins[0]
+		runs := "none of them is on PATH"
+		if scanner.PathIndex(winner.Dir) != -1 {
+			runs = fmt.Sprintf("%s's copy runs first on PATH", winner.Manager)
+		}
+
+		conflicts = append(conflicts, Conflict{
+			Language:    provider.Name(),
+			Severity:    SeverityMedium,
+			Type:        "node-global-bin-conflict",
+			Description: fmt.Sprintf("%s is installed globally by %s; %s", conflict.Name, strings.Join(owners, ", "), runs),
+			Remediation: fmt.Sprintf("Keep %s in one package manager and uninstall the other global copies", conflict.Name),
+		})
+	}
+
+	return conflicts
+}
@@ -0,0 +1,121 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/providers"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// versionManagerDirs are path fragments of the directories version managers install each
+// language's versions into. A PATH entry or symlink pointing inside one of them into a version
+// that no longer exists is left over from an uninstall.
+var versionManagerDirs = map[string][]string{
+	"Golang":  {"/.goenv/versions/"},
+	"Node.js": {"/.nvm/versions/node/", "/.nodenv/versions/", "/.fnm/node-versions/", "/.volta/tools/image/node/"},
+	"Java":    {"/.sdkman/candidates/java/"},
+	"Python":  {"/.pyenv/versions/"},
+	"PHP":     {"/.phpenv/versions/"},
+	"Rust":    {"/.rustup/toolchains/"},
+}
+
+// shimSelector is implemented by providers whose version manager selects versions through shims
+type shimSelector interface {
+	ShimSelection() (providers.ShimSelection, bool)
+}
+
+// checkOrphanedBinaries flags PATH entries, symlinks and shim selections that still point at
+// a version the version manager has uninstalled. The binary then fails or silently falls
+// through to another copy further down PATH.
+func checkOrphanedBinaries(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict {
+	var conflicts []Conflict
+
+	fragments := versionManagerDirs[provider.Name()]
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || scanner.PathExists(dir) || !inVersionManagerDir(dir, fragments) {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{
+			Language:    provider.Name(),
+			Severity:    SeverityMedium,
+			Type:        "orphaned-path-entry",
+			Description: fmt.Sprintf("PATH includes %s, a version that no longer exists", dir),
+			Remediation: "Open a new shell or select an installed version (e.g. nvm use, sdk use) so PATH is rebuilt; remove the entry if your shell profile adds it",
+		})
+	}
+
+	if locator, ok := provider.(core.BinaryLocator); ok {
+		for _, link := range danglingLinks(locator.BinaryName()) {
+			target, _ := os.Readlink(link)
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(link), target)
+			}
+			if !inVersionManagerDir(target, fragments) {
+				continue
+			}
+			conflicts = append(conflicts, Conflict{
+				Language:    provider.Name(),
+				Severity:    SeverityMedium,
+				Type:        "orphaned-binary",
+				Description: fmt.Sprintf("%s links to %s, which was uninstalled", link, target),
+				Remediation: fmt.Sprintf("rm %s, or point it at an installed version", link),
+			})
+		}
+	}
+
+	if selector, ok := provider.(shimSelector); ok {
+		if selection, ok := selector.ShimSelection(); ok && scanner.PathIndex(selection.ShimsDir) >= 0 && !selection.Installed() {
+			remediation := fmt.Sprintf("Select an installed version (%s versions lists them) with %s global or %s local, then run %s rehash",
+				selection.Manager, selection.Manager, selection.Manager, selection.Manager)
+			if !filepath.IsAbs(selection.Origin) {
+				remediation = fmt.Sprintf("Unset %s or set it to an installed version (%s versions lists them)", selection.Origin, selection.Manager)
+			}
+			conflicts = append(conflicts, Conflict{
+				Language: provider.Name(),
+				Severity: SeverityHigh,
+				Type:     "orphaned-shim-version",
+				Description: fmt.Sprintf("%s selects %s (from %s) but %s no longer exists, so its shims fail",
+					selection.Manager, selection.Version, selection.Origin, selection.Dir),
+				Remediation: remediation,
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// inVersionManagerDir reports whether path lies inside one of the version directories
+func inVersionManagerDir(path string, fragments []string) bool {
+	path = filepath.ToSlash(scanner.ExpandHome(path))
+	for _, fragment := range fragments {
+		if strings.Contains(path+"/", fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// danglingLinks returns the symlinks named name on PATH whose target is gone.
+// exec.LookPath skips them, so they never show up as installations.
+func danglingLinks(name string) []string {
+	var links []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		candidate := filepath.Join(dir, name)
+		info, err := os.Lstat(candidate)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			links = append(links, candidate)
+		}
+	}
+	return links
+}
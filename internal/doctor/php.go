@@ -0,0 +1,58 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/providers"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// checkComposerGlobalBin flags global Composer binaries that shadow or are shadowed by other copies.
+// A global phpunit first on PATH silently replaces the version pinned by a project's vendor/bin.
+func checkComposerGlobalBin(ctx context.Context, provider core.LanguageProvider, installations []core.Installation) []Conflict {
+	php, ok := provider.(*providers.PHPProvider)
+	if !ok || len(installations) == 0 {
+		return nil
+	}
+
+	binDir := php.ComposerGlobalBin()
+	if scanner.PathIndex(binDir) == -1 {
+		return nil
+	}
+
+	var conflicts []Conflict
+	for _, name := range php.ComposerGlobalBinaries() {
+		var others []string
+		for _, path := range scanner.FindAllExecutables(name) {
+			if !scanner.SamePath(filepath.Dir(path), binDir) {
+				others = append(others, path)
+			}
+		}
+
+		// The project in the current directory may pin its own copy
+		if cwd, err := os.Getwd(); err == nil {
+			if local := filepath.Join(cwd, "vendor", "bin", name); scanner.PathExists(local) {
+				others = append(others, local)
+			}
+		}
+
+		if len(others) == 0 {
+			continue
+		}
+
+		conflicts = append(conflicts, Conflict{
+			Language:    provider.Name(),
+			Severity:    SeverityMedium,
+			Type:        "composer-global-shadowing",
+			Description: fmt.Sprintf("Global Composer binary %s (%s) also exists at %s", name, binDir, strings.Join(others, ", ")),
+			Remediation: fmt.Sprintf("Run project tools via vendor/bin/%s or composer exec, or remove the global package with composer global remove", name),
+		})
+	}
+
+	return conflicts
+}
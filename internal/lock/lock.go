@@ -0,0 +1,110 @@
+package lock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"dependency-hell-cli/internal/scanner"
+)
+
+// ErrLocked is returned when another process holds the lock
+var ErrLocked = errors.New("lock is held by another process")
+
+// Lock is an exclusive, cross-process file lock
+type Lock struct {
+	path string
+	file *os.File
+}
+
+// Holder describes the process that acquired a lock
+type Holder struct {
+	PID      int
+	Acquired time.Time
+}
+
+// DefaultPath returns the lock file guarding clean operations ($XDG_CACHE_HOME/dhell/clean.lock)
+func DefaultPath() string {
+	cache := scanner.ExpandHome(scanner.XDGCacheHome())
+	if !filepath.IsAbs(cache) {
+		return filepath.Join(os.TempDir(), "dhell", "clean.lock")
+	}
+	return filepath.Join(cache, "dhell", "clean.lock")
+}
+
+// Acquire takes the lock at path without blocking. A lock is never taken over however long
+// it has been held: a slow clean may legitimately run for hours, and on Unix the lock is
+// released by the kernel when its holder exits, so only a live process can be holding it.
+func Acquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	l, err := tryLock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.writeHolder(); err != nil {
+		_ = l.Release()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Release unlocks and removes the lock file
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+
+	err := l.release()
+	l.file = nil
+	return err
+}
+
+// ReadHolder returns the process recorded in the lock file
+func ReadHolder(path string) (Holder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Holder{}, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return Holder{}, fmt.Errorf("malformed lock file %s", path)
+	}
+
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return Holder{}, fmt.Errorf("malformed lock file %s: %w", path, err)
+	}
+	acquired, err := time.Parse(time.RFC3339, fields[1])
+	if err != nil {
+		return Holder{}, fmt.Errorf("malformed lock file %s: %w", path, err)
+	}
+
+	return Holder{PID: pid, Acquired: acquired}, nil
+}
+
+// writeHolder records the current process and time in the lock file
+func (l *Lock) writeHolder() error {
+	if err := l.file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := l.file.WriteAt([]byte(fmt.Sprintf("%d %s\n", os.Getpid(), time.Now().Format(time.RFC3339))), 0)
+	return err
+}
+
+// removeIfExists deletes path, ignoring a missing file
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
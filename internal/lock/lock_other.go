@@ -0,0 +1,38 @@
+//go:build !unix
+
+package lock
+
+import (
+	"os"
+)
+
+// tryLock creates the lock file exclusively; its existence marks the lock as held.
+// Unlike flock, the file is not released if the process dies; ForceUnlock removes it then.
+func tryLock(path string) (*Lock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, ErrLocked
+		}
+		return nil, err
+	}
+
+	return &Lock{path: path, file: file}, nil
+}
+
+// release closes the lock file and removes it
+func (l *Lock) release() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	return removeIfExists(l.path)
+}
+
+// ReleasedOnExit reports whether a lock is released when its holder exits, even by crashing
+const ReleasedOnExit = false
+
+// ForceUnlock removes the lock file regardless of who holds it; a crashed holder
+// leaves the file behind, and nothing else can tell it from a live one
+func ForceUnlock(path string) error {
+	return removeIfExists(path)
+}
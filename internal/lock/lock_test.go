@@ -0,0 +1,21 @@
+package lock
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultPathFollowsXDGCacheHome(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+	if got, want := DefaultPath(), filepath.Join(cache, "dhell", "clean.lock"); got != want {
+		t.Errorf("DefaultPath() = %s, want %s", got, want)
+	}
+
+	home := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("DHELL_HOME", home)
+	if got, want := DefaultPath(), filepath.Join(home, ".cache", "dhell", "clean.lock"); got != want {
+		t.Errorf("DefaultPath() with DHELL_HOME = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+//go:build unix
+
+package lock
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLock opens the lock file and takes a non-blocking exclusive flock on it
+func tryLock(path string) (*Lock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, ErrLocked
+		}
+		return nil, err
+	}
+
+	// The previous holder may have removed the file after we opened it;
+	// a lock on an unlinked file guards nothing
+	opened, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	current, err := os.Stat(path)
+	if err != nil || !os.SameFile(opened, current) {
+		file.Close()
+		return nil, ErrLocked
+	}
+
+	return &Lock{path: path, file: file}, nil
+}
+
+// release removes the lock file while still holding the flock, then unlocks it
+func (l *Lock) release() error {
+	defer l.file.Close()
+
+	removeErr := removeIfExists(l.path)
+	if err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN); err != nil {
+		return err
+	}
+	return removeErr
+}
+
+// ReleasedOnExit reports whether a lock is released when its holder exits, even by crashing
+const ReleasedOnExit = true
+
+// ForceUnlock removes a leftover lock file. A lock still held by a running process is
+// not removed: the next Acquire would lock a new file and run alongside it.
+func ForceUnlock(path string) error {
+	l, err := tryLock(path)
+	if err != nil {
+		return err
+	}
+	return l.release()
+}
@@ -0,0 +1,78 @@
+//go:build unix
+
+package lock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAcquireNeverTakesOverAHeldLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clean.lock")
+	held, err := Acquire(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer held.Release()
+
+	// However old the holder's record, a held flock means the holder is still running
+	old := fmt.Sprintf("%d %s\n", os.Getpid(), time.Now().Add(-48*time.Hour).Format(time.RFC3339))
+	if _, err := held.file.WriteAt([]byte(old), 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Acquire(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("Acquire of a lock held for two days = %v, want ErrLocked", err)
+	}
+}
+
+func TestAcquireLeftoverLockFile(t *testing.T) {
+	// A crashed clean leaves its lock file behind, but its flock died with it
+	path := filepath.Join(t.TempDir(), "clean.lock")
+	if err := os.WriteFile(path, []byte("99999 2024-01-01T00:00:00Z\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire of a leftover lock file = %v, want it acquired", err)
+	}
+	if holder, err := ReadHolder(path); err != nil || holder.PID != os.Getpid() {
+		t.Errorf("ReadHolder = %+v, %v; want this process", holder, err)
+	}
+	if err := l.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("lock file still exists after Release: %v", err)
+	}
+}
+
+func TestForceUnlockLeavesAHeldLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clean.lock")
+	held, err := Acquire(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ForceUnlock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("ForceUnlock of a held lock = %v, want ErrLocked", err)
+	}
+	if _, err := Acquire(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("Acquire after ForceUnlock of a held lock = %v, want ErrLocked", err)
+	}
+
+	held.Release()
+	if err := os.WriteFile(path, []byte("99999 2024-01-01T00:00:00Z\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ForceUnlock(path); err != nil {
+		t.Fatalf("ForceUnlock of a leftover lock file = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("leftover lock file still exists after ForceUnlock: %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dependency-hell-cli/internal/scanner"
+)
+
+// RenderBenchmark renders size calculation timings with their throughput,
+// relative to the first (sequential) method
+func RenderBenchmark(path string, timings []scanner.WalkTiming) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Size calculation benchmark: %s\n\n", path))
+	output.WriteString(fmt.Sprintf(" %-34s %12s %12s %14s %16s %8s\n", "METHOD", "SIZE", "FILES", "TIME", "THROUGHPUT", "SPEEDUP"))
+
+	for _, timing := range timings {
+		seconds := timing.Duration.Seconds()
+
+		throughput := "-"
+		if seconds > 0 {
+			if timing.Files > 0 {
+				throughput = fmt.Sprintf("%s files/s", FormatCount(int64(float64(timing.Files)/seconds)))
+			} else {
+				throughput = fmt.Sprintf("%s/s", FormatBytes(int64(float64(timing.Size)/seconds)))
+			}
+		}
+
+		files := "-"
+		if timing.Files > 0 {
+			files = FormatCount(timing.Files)
+		}
+
+		speedup := "-"
+		if base := timings[0].Duration; timing.Duration > 0 {
+			speedup = fmt.Sprintf("%.2fx", base.Seconds()/seconds)
+		}
+
+		output.WriteString(fmt.Sprintf(" %-34s %12s %12s %14s %16s %8s\n",
+			timing.Method, FormatBytes(timing.Size), files, timing.Duration.Round(time.Millisecond), throughput, speedup))
+	}
+
+	return output.String()
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// collapsedItems summarizes breakdown items hidden by a --max-items limit
+type collapsedItems struct {
+	Count int
+	Size  int64
+}
+
+// String renders the summary line, e.g. "…and 3 more (1.2 GB total)"
+func (c collapsedItems) String() string {
+	return fmt.Sprintf("…and %d more (%s total)", c.Count, FormatBytes(c.Size))
+}
+
+// limitItems returns the non-empty items to show in a breakdown.
+// With maxItems > 0 only the largest maxItems are kept and the rest are collapsed;
+// otherwise all items are returned in their original order.
+func limitItems(items []core.DiskUsageItem, maxItems int) ([]core.DiskUsageItem, collapsedItems) {
+	var nonEmpty []core.DiskUsageItem
+	for _, item := range items {
+		if item.Size > 0 {
+			nonEmpty = append(nonEmpty, item)
+		}
+	}
+
+	if maxItems <= 0 || len(nonEmpty) <= maxItems {
+		return nonEmpty, collapsedItems{}
+	}
+
+	sort.SliceStable(nonEmpty, func(i, j int) bool {
+		return nonEmpty[i].Size > nonEmpty[j].Size
+	})
+
+	var collapsed collapsedItems
+	for _, item := range nonEmpty[maxItems:] {
+		collapsed.Count++
+		collapsed.Size += item.Size
+	}
+
+	return nonEmpty[:maxItems], collapsed
+}
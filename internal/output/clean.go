@@ -7,7 +7,6 @@ import (
 	"dependency-hell-cli/internal/core"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/dustin/go-humanize"
 )
 
 // RenderCleanPreview shows what would be cleaned in dry-run mode
@@ -29,7 +28,7 @@ func RenderCleanPreview(language string, items []core.CleanableItem) string {
 	// Items list
 	output.WriteString("The following items will be cleaned:\n\n")
 
-	var totalSize int64
+	totalSize, exact := core.EstimateReclaimable(items)
 	for _, item := range items {
 		icon := "🗑️ "
 		desc := item.Description
@@ -43,9 +42,20 @@ func RenderCleanPreview(language string, items []core.CleanableItem) string {
 		}
 
 		if item.Size > 0 {
-			size := humanize.Bytes(uint64(item.Size))
-			output.WriteString(fmt.Sprintf("      Size: %s\n", size))
-			totalSize += item.Size
+			size := FormatBytes(item.Size)
+			switch item.Reclaim {
+			case core.ReclaimUpperBound:
+				output.WriteString(fmt.Sprintf("      Size: up to %s (only unused entries are removed)\n", size))
+			case core.ReclaimRegenerable:
+				output.WriteString(fmt.Sprintf("      Size: %s (rebuilt automatically when needed)\n", size))
+			case core.ReclaimExact:
+				output.WriteString(fmt.Sprintf("      Size: %s (exact, not regenerated)\n", size))
+			default:
+				output.WriteString(fmt.Sprintf("      Size: %s\n", size))
+			}
+		}
+		if item.RebuildCost != "" {
+			output.WriteString(fmt.Sprintf("      Rebuild cost: %s\n", item.RebuildCost))
 		}
 
 		if !item.Safe {
@@ -60,11 +70,11 @@ func RenderCleanPreview(language string, items []core.CleanableItem) string {
 
 	// Total
 	if totalSize > 0 {
-		totalStr := humanize.Bytes(uint64(totalSize))
+		totalStr := FormatBytes(totalSize)
 		total := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#FFA500")).
-			Render(fmt.Sprintf("Total space to reclaim: %s", totalStr))
+			Render(fmt.Sprintf("Total space to reclaim: %s%s", upToPrefix(exact), totalStr))
 		output.WriteString(total + "\n\n")
 	}
 
@@ -77,9 +87,27 @@ func RenderCleanPreview(language string, items []core.CleanableItem) string {
 	return output.String()
 }
 
-// RenderCleanResult shows the result of cleaning operation
-func RenderCleanResult(result *core.CleanResult, items []core.CleanableItem) string {
+// upToPrefix returns the qualifier for sizes that are only an upper bound
+func upToPrefix(exact bool) string {
+	if exact {
+		return ""
+	}
+	return "up to "
+}
+
+// regeneratesSuffix notes items whose data is rebuilt on demand
+func regeneratesSuffix(kind core.ReclaimKind) string {
+	if kind == core.ReclaimRegenerable {
+		return ", rebuilt when needed"
+	}
+	return ""
+}
+
+// RenderCleanResult shows the result of cleaning operation; only the items in the result,
+// which leaves out those that failed, are listed as cleaned
+func RenderCleanResult(result *core.CleanResult) string {
 	var output strings.Builder
+	items := result.Items
 
 	if result.ItemsCleaned == 0 {
 		output.WriteString("❌ No items were cleaned.\n")
@@ -97,8 +125,8 @@ func RenderCleanResult(result *core.CleanResult, items []core.CleanableItem) str
 	output.WriteString("Cleaned:\n")
 	for _, item := range items {
 		if item.Size > 0 {
-			size := humanize.Bytes(uint64(item.Size))
-			output.WriteString(fmt.Sprintf("  ✓ %s (%s)\n", item.Description, size))
+			size := FormatBytes(item.Size)
+			output.WriteString(fmt.Sprintf("  ✓ %s (%s%s%s)\n", item.Description, upToPrefix(item.Reclaim != core.ReclaimUpperBound), size, regeneratesSuffix(item.Reclaim)))
 		} else {
 			output.WriteString(fmt.Sprintf("  ✓ %s\n", item.Description))
 		}
@@ -107,11 +135,12 @@ func RenderCleanResult(result *core.CleanResult, items []core.CleanableItem) str
 	// Total space reclaimed
 	if result.SpaceReclaimed > 0 {
 		output.WriteString("\n")
-		totalStr := humanize.Bytes(uint64(result.SpaceReclaimed))
+		_, exact := core.EstimateReclaimable(items)
+		totalStr := FormatBytes(result.SpaceReclaimed)
 		total := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#FFA500")).
-			Render(fmt.Sprintf("Total space reclaimed: %s", totalStr))
+			Render(fmt.Sprintf("Total space reclaimed: %s%s", upToPrefix(exact), totalStr))
 		output.WriteString(total + "\n")
 	}
 
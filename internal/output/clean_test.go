@@ -0,0 +1,37 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+)
+
+func TestRenderCleanPreviewReclaimKinds(t *testing.T) {
+	tests := []struct {
+		kind core.ReclaimKind
+		want string
+	}{
+		{core.ReclaimExact, "Size: 1.0 kB (exact, not regenerated)\n"},
+		{core.ReclaimUpperBound, "Size: up to 1.0 kB (only unused entries are removed)\n"},
+		{core.ReclaimRegenerable, "Size: 1.0 kB (rebuilt automatically when needed)\n"},
+		{core.ReclaimUnknown, "Size: 1.0 kB\n"},
+	}
+
+	for _, tt := range tests {
+		preview := RenderCleanPreview("Go", []core.CleanableItem{{Path: "/cache", Description: "Cache", Size: 1000, Safe: true, Reclaim: tt.kind}})
+		if !strings.Contains(preview, tt.want) {
+			t.Errorf("preview of a %q item lacks %q:\n%s", tt.kind, tt.want, preview)
+		}
+	}
+}
+
+func TestZeroReclaimKindIsUnknown(t *testing.T) {
+	var item core.CleanableItem
+	if item.Reclaim != core.ReclaimUnknown || item.Reclaim.String() != "" {
+		t.Errorf("zero Reclaim = %d %q, want ReclaimUnknown", item.Reclaim, item.Reclaim)
+	}
+	if _, exact := core.EstimateReclaimable([]core.CleanableItem{{Size: 1}}); exact {
+		t.Error("EstimateReclaimable of an item of unknown kind claims to be exact")
+	}
+}
@@ -0,0 +1,128 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LanguageComparison is one language as found on two machines
+type LanguageComparison struct {
+	Language    string
+	A, B        *LanguageExport // nil when the language isn't installed on that machine
+	Differences []string        // Empty when both machines match
+}
+
+// CompareExports pairs up the languages of two scan exports and lists how each differs.
+// Languages are ordered as in a, followed by those only found in b.
+func CompareExports(a, b *ScanExport) []LanguageComparison {
+	installedA, installedB := installedLanguages(a), installedLanguages(b)
+
+	byName := make(map[string]*LanguageExport)
+	for _, language := range installedB {
+		byName[language.Language] = language
+	}
+
+	var comparisons []LanguageComparison
+	seen := make(map[string]bool)
+	for _, language := range installedA {
+		seen[language.Language] = true
+		comparisons = append(comparisons, compareLanguage(language.Language, language, byName[language.Language]))
+	}
+	for _, language := range installedB {
+		if !seen[language.Language] {
+			comparisons = append(comparisons, compareLanguage(language.Language, nil, language))
+		}
+	}
+
+	return comparisons
+}
+
+// installedLanguages returns the languages of an export that were found on the machine
+func installedLanguages(export *ScanExport) []*LanguageExport {
+	var installed []*LanguageExport
+	for i := range export.Languages {
+		if export.Languages[i].Installed() {
+			installed = append(installed, &export.Languages[i])
+		}
+	}
+	return installed
+}
+
+// compareLanguage lists the differences between two machines' installs of a language
+func compareLanguage(name string, a, b *LanguageExport) LanguageComparison {
+	comparison := LanguageComparison{Language: name, A: a, B: b}
+
+	switch {
+	case a == nil || b == nil:
+		comparison.Differences = append(comparison.Differences, "installed on one machine only")
+	default:
+		if a.Version != b.Version {
+			comparison.Differences = append(comparison.Differences, versionDrift(a.Version, b.Version)+" version drift")
+		}
+		if exportSource(*a) != exportSource(*b) {
+			comparison.Differences = append(comparison.Differences, "different source")
+		}
+	}
+
+	return comparison
+}
+
+// versionDrift names the most significant version component that differs (major, minor or patch)
+func versionDrift(a, b string) string {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i, name := range []string{"major", "minor"} {
+		if i >= len(partsA) || i >= len(partsB) || partsA[i] != partsB[i] {
+			return name
+		}
+	}
+	return "patch"
+}
+
+// exportSource returns the version manager of an exported language, or its source without one
+func exportSource(language LanguageExport) string {
+	if language.Manager != "" {
+		return language.Manager
+	}
+	return language.Source
+}
+
+// RenderComparison renders two scan exports side by side, one row per language
+func RenderComparison(labelA, labelB string, a, b *ScanExport) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Comparing %s (%s, %s) with %s (%s, %s)\n\n", labelA, a.OS, a.Arch, labelB, b.OS, b.Arch))
+	output.WriteString(fmt.Sprintf(" %-11s %-28s %-28s %s\n", "LANGUAGE", strings.ToUpper(labelA), strings.ToUpper(labelB), "DIFFERENCE"))
+	output.WriteString(strings.Repeat("─", 100) + "\n")
+
+	differing := 0
+	comparisons := CompareExports(a, b)
+	for _, comparison := range comparisons {
+		difference := "✅ same"
+		if len(comparison.Differences) > 0 {
+			difference = "⚠️  " + strings.Join(comparison.Differences, ", ")
+			differing++
+		}
+
+		output.WriteString(fmt.Sprintf(" %-11s %-28s %-28s %s\n",
+			comparison.Language, comparisonCell(comparison.A), comparisonCell(comparison.B), difference))
+	}
+	output.WriteString(strings.Repeat("─", 100) + "\n")
+
+	if differing == 0 {
+		output.WriteString("✅ Both machines have the same languages, versions and sources.\n")
+	} else {
+		output.WriteString(fmt.Sprintf("%d of %d languages differ\n", differing, len(comparisons)))
+	}
+
+	return output.String()
+}
+
+// comparisonCell renders one machine's install of a language, or a dash when it has none
+func comparisonCell(language *LanguageExport) string {
+	if language == nil {
+		return "—"
+	}
+	return fmt.Sprintf("%s (%s)", language.Version, exportSource(*language))
+}
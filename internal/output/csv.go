@@ -0,0 +1,24 @@
+package output
+
+import (
+	"encoding/csv"
+	"strings"
+)
+
+// csvHeader names the columns of scan --output csv
+var csvHeader = []string{"language", "version", "source", "item_description", "item_path", "size_bytes"}
+
+// RenderScanResultsCSV renders installed languages as CSV for spreadsheets that track disk growth:
+// a header, then per language a Total row followed by one row per cache item.
+// Sizes are raw byte counts so they can be summed; quoting is left to encoding/csv.
+func RenderScanResultsCSV(results []ScanResult) (string, error) {
+	var output strings.Builder
+	writer := csv.NewWriter(&output)
+	if err := writer.Write(csvHeader); err != nil {
+		return "", err
+	}
+	if err := writer.WriteAll(scanRows(results)); err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
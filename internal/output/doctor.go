@@ -0,0 +1,106 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/doctor"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// severityStatus maps a conflict severity to the matching status indicator
+var severityStatus = map[doctor.Severity]core.Status{
+	doctor.SeverityLow:    core.StatusGood,
+	doctor.SeverityMedium: core.StatusWarning,
+	doctor.SeverityHigh:   core.StatusBad,
+}
+
+// RenderDoctorReport renders the conflicts found by the doctor checks.
+// With explain set, it also shows why each language's source was classified as it was.
+func RenderDoctorReport(report *doctor.Report, explain bool) string {
+	var output strings.Builder
+	conflicts := report.Conflicts
+
+	// Header
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(1, 2).
+		Render("Environment Doctor")
+
+	border := strings.Repeat("─", 60)
+	output.WriteString("╭" + border + "╮\n")
+	output.WriteString("│" + lipgloss.NewStyle().Width(60).Align(lipgloss.Center).Render(header) + "│\n")
+	output.WriteString("╰" + border + "╯\n\n")
+
+	if explain {
+		output.WriteString(lipgloss.NewStyle().Bold(true).Render("Source Classification:") + "\n")
+		for _, language := range report.Languages {
+			installation := language.Installations[0]
+			output.WriteString(fmt.Sprintf("  • %s: %s because %s\n", language.Language, installation.Source, installation.SourceReason))
+		}
+		output.WriteString("\n")
+	}
+
+	if len(conflicts) == 0 {
+		output.WriteString("✅ No conflicts detected.\n")
+		return output.String()
+	}
+
+	for _, conflict := range conflicts {
+		output.WriteString(renderConflict(conflict) + "\n")
+	}
+
+	summary := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFA500")).
+		Render(fmt.Sprintf("%d conflict(s) found", len(conflicts)))
+	output.WriteString(summary + "\n")
+
+	return output.String()
+}
+
+// conflictJSON is the JSON shape of a doctor conflict
+type conflictJSON struct {
+	Language    string `json:"language"`
+	Severity    string `json:"severity"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Remediation string `json:"remediation"`
+	Reclaimable int64  `json:"reclaimable_bytes,omitempty"`
+}
+
+// RenderDoctorJSON renders the conflicts found by the doctor checks as a JSON array
+func RenderDoctorJSON(report *doctor.Report) (string, error) {
+	conflicts := make([]conflictJSON, 0, len(report.Conflicts))
+	for _, conflict := range report.Conflicts {
+		conflicts = append(conflicts, conflictJSON{
+			Language:    conflict.Language,
+			Severity:    string(conflict.Severity),
+			Type:        conflict.Type,
+			Description: conflict.Description,
+			Remediation: conflict.Remediation,
+			Reclaimable: conflict.Reclaimable,
+		})
+	}
+	return renderJSON(conflicts)
+}
+
+// renderConflict renders a single conflict with its description and remediation
+func renderConflict(conflict doctor.Conflict) string {
+	var output strings.Builder
+
+	icon := severityStatus[conflict.Severity].GetStatusIcon()
+	output.WriteString(fmt.Sprintf("%s %s %s\n", icon, LanguageStyle.Render(conflict.Language), DiskUsageDescStyle.Render("["+conflict.Type+"]")))
+	output.WriteString(fmt.Sprintf("  %s\n", conflict.Description))
+	if conflict.Reclaimable > 0 {
+		output.WriteString(fmt.Sprintf("  Reclaimable: %s\n", FormatBytes(conflict.Reclaimable)))
+	}
+	if conflict.Remediation != "" {
+		output.WriteString(fmt.Sprintf("  → %s\n", conflict.Remediation))
+	}
+
+	return output.String()
+}
@@ -0,0 +1,137 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// LanguageStatus tells consumers of a scan export how detection of a language went
+type LanguageStatus string
+
+const (
+	StatusInstalled    LanguageStatus = "installed"
+	StatusNotInstalled LanguageStatus = "not_installed" // Benign: the language simply isn't there
+	StatusBroken       LanguageStatus = "broken"        // The binary is on PATH but can't be run
+	StatusError        LanguageStatus = "error"         // Detection failed or timed out; actionable
+)
+
+// ScanExport is the JSON form of a scan, written by scan --output json and read by compare
+type ScanExport struct {
+	Host      string           `json:"host"`
+	OS        string           `json:"os"`
+	Arch      string           `json:"arch"`
+	ScannedAt time.Time        `json:"scanned_at"`
+	Languages []LanguageExport `json:"languages"`
+}
+
+// LanguageExport is one scanned language in a scan export
+type LanguageExport struct {
+	Language   string         `json:"language"`
+	Status     LanguageStatus `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	Version    string         `json:"version,omitempty"` // Active version
+	Source     string         `json:"source,omitempty"`  // Install source of the active version
+	Manager    string         `json:"manager,omitempty"`
+	Versions   []string       `json:"versions,omitempty"` // Every detected version, active first
+	DiskUsage  int64          `json:"disk_usage"`
+	Incomplete bool           `json:"incomplete,omitempty"` // --timeout hit before every cache was measured
+
+	BinaryPath    string               `json:"binary_path,omitempty"`   // Active binary as found on PATH
+	Installations []core.Installation  `json:"installations,omitempty"` // Every detected installation, active first
+	Items         []core.DiskUsageItem `json:"items,omitempty"`         // The caches making up disk_usage
+}
+
+// Installed reports whether the language was found, treating exports that predate status as installed
+func (l LanguageExport) Installed() bool {
+	return l.Status == StatusInstalled || l.Status == ""
+}
+
+// NewScanExport builds the export of every scanned language in results
+func NewScanExport(results []ScanResult) *ScanExport {
+	host, _ := os.Hostname()
+	osInfo, arch, _ := getSystemInfo()
+
+	export := &ScanExport{
+		Host:      host,
+		OS:        osInfo,
+		Arch:      arch,
+		ScannedAt: time.Now().UTC().Truncate(time.Second),
+		Languages: []LanguageExport{},
+	}
+
+	for _, result := range results {
+		status := resultStatus(result)
+		if status != StatusInstalled {
+			language := LanguageExport{Language: result.Provider.Name(), Status: status}
+			if status != StatusNotInstalled && result.Error != nil {
+				language.Error = result.Error.Error()
+			}
+			export.Languages = append(export.Languages, language)
+			continue
+		}
+
+		active := result.Installations[0]
+		language := LanguageExport{
+			Language:   result.Provider.Name(),
+			Status:     StatusInstalled,
+			Version:    active.Version,
+			Source:     string(active.Source),
+			Manager:    active.ManagerName,
+			Incomplete: result.TimedOut,
+		}
+		for _, installation := range result.Installations {
+			language.Versions = append(language.Versions, installation.Version)
+		}
+		language.BinaryPath = active.BinaryPath
+		language.Installations = result.Installations
+		if result.DiskUsage != nil {
+			language.DiskUsage = result.DiskUsage.Total
+			language.Items = result.DiskUsage.Items
+		}
+
+		export.Languages = append(export.Languages, language)
+	}
+
+	return export
+}
+
+// resultStatus classifies a scan result for the export. A language whose scan timed out after
+// it was detected is installed; its export is marked incomplete instead.
+func resultStatus(result ScanResult) LanguageStatus {
+	switch {
+	case result.Error == nil && len(result.Installations) > 0:
+		return StatusInstalled
+	case result.TimedOut:
+		return StatusError
+	case result.Error == nil, errors.Is(result.Error, core.ErrNotInstalled):
+		return StatusNotInstalled
+	case errors.Is(result.Error, core.ErrBrokenInstall):
+		return StatusBroken
+	default:
+		return StatusError
+	}
+}
+
+// RenderScanJSON renders scan results as a ScanExport
+func RenderScanJSON(results []ScanResult) (string, error) {
+	return renderJSON(NewScanExport(results))
+}
+
+// ReadScanExport loads a scan export written by scan --output json
+func ReadScanExport(path string) (*ScanExport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var export ScanExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("%s is not a dhell scan export: %w", path, err)
+	}
+	return &export, nil
+}
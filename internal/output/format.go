@@ -0,0 +1,51 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Units selects how byte sizes are rendered
+type Units string
+
+const (
+	UnitsDecimal Units = "decimal" // kB, MB, GB (powers of 1000)
+	UnitsBinary  Units = "binary"  // KiB, MiB, GiB (powers of 1024)
+	UnitsRaw     Units = "bytes"   // Plain integer byte counts, for scripts parsing the output
+)
+
+// byteUnits is the unit system used by FormatBytes
+var byteUnits = UnitsDecimal
+
+// SetUnits selects the unit system used by all renderers
+func SetUnits(units Units) error {
+	switch units {
+	case UnitsDecimal, UnitsBinary, UnitsRaw:
+		byteUnits = units
+		return nil
+	default:
+		return fmt.Errorf("unknown units %q (supported: decimal, binary, bytes)", units)
+	}
+}
+
+// FormatBytes renders a byte count in the selected unit system
+func FormatBytes(size int64) string {
+	if size < 0 {
+		size = 0
+	}
+
+	switch byteUnits {
+	case UnitsBinary:
+		return humanize.IBytes(uint64(size))
+	case UnitsRaw:
+		return strconv.FormatInt(size, 10)
+	}
+	return humanize.Bytes(uint64(size))
+}
+
+// FormatCount formats a file count with thousands separators
+func FormatCount(count int64) string {
+	return humanize.Comma(count)
+}
@@ -0,0 +1,101 @@
+package output
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// fakeProvider is a language provider with nothing to detect, for rendering its results
+type fakeProvider struct{}
+
+func (fakeProvider) Name() string { return "Test" }
+
+func (fakeProvider) DetectInstalled(context.Context) ([]core.Installation, error) { return nil, nil }
+
+func (fakeProvider) GetGlobalCacheUsage(context.Context) (*core.DiskUsage, error) {
+	return &core.DiskUsage{}, nil
+}
+
+func (fakeProvider) GetEnvVars(context.Context) map[string]string { return nil }
+
+func (fakeProvider) GetCleanableItems(context.Context) ([]core.CleanableItem, error) {
+	return nil, nil
+}
+
+func (fakeProvider) Clean(context.Context, []core.CleanableItem, bool) (*core.CleanResult, error) {
+	return nil, nil
+}
+
+// humanizedSize matches a size with a unit suffix, such as "4.2 GB" or "512 KiB"
+var humanizedSize = regexp.MustCompile(`\d\s?([kKMGTPE]i?)?B\b`)
+
+func TestRawSizesHaveNoUnitSuffixes(t *testing.T) {
+	if err := SetUnits(UnitsRaw); err != nil {
+		t.Fatal(err)
+	}
+	defer SetUnits(UnitsDecimal)
+
+	usage := &core.DiskUsage{
+		Items: []core.DiskUsageItem{
+			{Path: "/cache/small", Description: "Small Cache", Size: 512},
+			{Path: "/cache/large", Description: "Large Cache", Size: 4_200_000_000},
+		},
+		Total: 4_200_000_512,
+	}
+	installation := core.Installation{Version: "1.0.0", BinaryPath: "/usr/bin/test", Source: core.SourceSystem}
+	items := []core.CleanableItem{
+		{Path: "/cache/large", Description: "Large Cache", Size: 4_200_000_000, Safe: true, Reclaim: core.ReclaimRegenerable},
+		{Path: "/cache/small", Description: "Small Cache", Size: 512, Reclaim: core.ReclaimUpperBound},
+	}
+
+	rendered := map[string]string{
+		"table": RenderScanResults([]ScanResult{{
+			Provider:      fakeProvider{},
+			Installations: []core.Installation{installation},
+			DiskUsage:     usage,
+		}}, ScanOptions{}),
+		"info":         RenderInfo(fakeProvider{}, &installation, usage, InfoOptions{}),
+		"clean":        RenderCleanPreview("Test", items),
+		"clean result": RenderCleanResult(&core.CleanResult{ItemsCleaned: 2, SpaceReclaimed: 4_200_000_512, Items: items}),
+	}
+	for name, text := range rendered {
+		if match := humanizedSize.FindString(text); match != "" {
+			t.Errorf("%s output has the humanized size %q with --raw-sizes:\n%s", name, match, text)
+		}
+	}
+	if got := FormatBytes(4_200_000_512); got != "4200000512" {
+		t.Errorf("FormatBytes = %q, want 4200000512", got)
+	}
+}
+
+func TestFormatBytesUnits(t *testing.T) {
+	defer SetUnits(UnitsDecimal)
+
+	tests := []struct {
+		units Units
+		size  int64
+		want  string
+	}{
+		// A GiB is 1.07 GB, so it rounds up in decimal units
+		{UnitsDecimal, 1073741824, "1.1 GB"},
+		{UnitsBinary, 1073741824, "1.0 GiB"},
+		{UnitsDecimal, 1000000000, "1.0 GB"},
+		{UnitsBinary, 1000000000, "954 MiB"},
+		{UnitsRaw, 1073741824, "1073741824"},
+	}
+	for _, tt := range tests {
+		if err := SetUnits(tt.units); err != nil {
+			t.Fatal(err)
+		}
+		if got := FormatBytes(tt.size); got != tt.want {
+			t.Errorf("FormatBytes(%d) in %s units = %q, want %q", tt.size, tt.units, got, tt.want)
+		}
+	}
+
+	if err := SetUnits("metric"); err == nil {
+		t.Error("SetUnits(metric) succeeded, want an error")
+	}
+}
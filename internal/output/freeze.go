@@ -0,0 +1,69 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// FreezeEntry represents a language pinned to its active version
+type FreezeEntry struct {
+	Tool    string
+	Version string
+	Source  string
+}
+
+// toolNames maps provider names to asdf/mise tool identifiers
+var toolNames = map[string]string{
+	"Golang":  "golang",
+	"Node.js": "nodejs",
+	"Java":    "java",
+	"Python":  "python",
+	"PHP":     "php",
+	"Rust":    "rust",
+}
+
+// NewFreezeEntry builds a freeze entry for a provider's active installation
+func NewFreezeEntry(providerName string, installation core.Installation) FreezeEntry {
+	tool, ok := toolNames[providerName]
+	if !ok {
+		tool = strings.ToLower(providerName)
+	}
+
+	source := string(installation.Source)
+	if installation.ManagerName != "" {
+		source = installation.ManagerName
+	}
+
+	return FreezeEntry{
+		Tool:    tool,
+		Version: strings.TrimPrefix(installation.Version, "v"),
+		Source:  source,
+	}
+}
+
+// RenderToolVersions renders entries in the .tool-versions format used by asdf and mise
+func RenderToolVersions(entries []FreezeEntry) string {
+	var output strings.Builder
+
+	for _, entry := range entries {
+		output.WriteString(fmt.Sprintf("%s %s\n", entry.Tool, entry.Version))
+	}
+
+	return output.String()
+}
+
+// RenderLockfile renders entries in the dhell.lock format, which also records the source
+func RenderLockfile(entries []FreezeEntry) string {
+	var output strings.Builder
+
+	output.WriteString("# dhell.lock - generated by dhell freeze\n")
+	output.WriteString("# tool version source\n")
+	for _, entry := range entries {
+		source := strings.ReplaceAll(entry.Source, " ", "-")
+		output.WriteString(fmt.Sprintf("%s %s %s\n", entry.Tool, entry.Version, source))
+	}
+
+	return output.String()
+}
@@ -5,13 +5,27 @@ import (
 	"strings"
 
 	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/doctor"
+	"dependency-hell-cli/internal/scanner"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/dustin/go-humanize"
 )
 
+// InfoOptions controls optional sections of the info output
+type InfoOptions struct {
+	Explain   bool                // Show why the install source was classified as it was
+	Conflicts []doctor.Conflict   // Environment problems to warn about
+	MaxItems  int                 // Largest cache locations to show; 0 shows all
+	Deltas    map[string]int64    // Size change per cache path since the last refresh (info --watch); "" is the total
+	Newer     string              // Newer version installed by the same version manager but not active, if any
+	Breakdown []core.InfoSection  // What the caches contain, from info --breakdown
+	Others    []core.Installation // Installed versions besides the active one
+	EnvVars   map[string]string   // The provider's environment variables
+	Sections  []core.InfoSection  // Provider-specific sections (see core.InfoSectionProvider)
+}
+
 // RenderInfo renders detailed information about a language installation
-func RenderInfo(provider core.LanguageProvider, installation *core.Installation, diskUsage *core.DiskUsage) string {
+func RenderInfo(provider core.LanguageProvider, installation *core.Installation, diskUsage *core.DiskUsage, opts InfoOptions) string {
 	var output strings.Builder
 
 	// Header
@@ -28,10 +42,46 @@ func RenderInfo(provider core.LanguageProvider, installation *core.Installation,
 
 	// Version and Source
 	output.WriteString(fmt.Sprintf("Version: %s\n", installation.Version))
+	if opts.Newer != "" {
+		output.WriteString(fmt.Sprintf("⬆️  %s installed but %s active\n", opts.Newer, installation.Version))
+	}
+	if installation.Distribution != "" {
+		output.WriteString(fmt.Sprintf("Distribution: %s\n", installation.Distribution))
+	}
 
 	status := core.DetermineStatus(installation.Source)
 	statusIcon := status.GetStatusIcon()
-	output.WriteString(fmt.Sprintf("Source: %s %s\n\n", statusIcon, installation.Source))
+	if installation.Arch != "" {
+		output.WriteString(fmt.Sprintf("Source: %s %s (%s)\n\n", statusIcon, installation.Source, installation.Arch))
+	} else {
+		output.WriteString(fmt.Sprintf("Source: %s %s\n\n", statusIcon, installation.Source))
+	}
+
+	if opts.Explain && installation.SourceReason != "" {
+		output.WriteString(fmt.Sprintf("Why: classified as %s because %s\n\n", installation.Source, installation.SourceReason))
+	}
+
+	// Every other version installed, e.g. by nvm or pyenv
+	if len(opts.Others) > 0 {
+		output.WriteString(lipgloss.NewStyle().Bold(true).Render("Installed Versions:") + "\n")
+		output.WriteString(fmt.Sprintf("  • %s (active): %s\n", installation.Version, installation.BinaryPath))
+		for _, other := range opts.Others {
+			label := other.Version
+			if other.ManagerName != "" {
+				label += " (" + other.ManagerName + ")"
+			}
+			output.WriteString(fmt.Sprintf("  • %s: %s\n", label, other.BinaryPath))
+		}
+		output.WriteString("\n")
+	}
+
+	// Environment warnings, e.g. JAVA_HOME pointing to another JDK
+	if len(opts.Conflicts) > 0 {
+		output.WriteString(lipgloss.NewStyle().Bold(true).Render("Warnings:") + "\n")
+		for _, conflict := range opts.Conflicts {
+			output.WriteString(renderConflict(conflict) + "\n")
+		}
+	}
 
 	// Binary Paths
 	output.WriteString(lipgloss.NewStyle().Bold(true).Render("Binary Paths:") + "\n")
@@ -42,8 +92,18 @@ func RenderInfo(provider core.LanguageProvider, installation *core.Installation,
 	}
 	output.WriteString("\n")
 
+	// Symlink chain from the PATH binary to the real file
+	if chain, _ := scanner.SymlinkChain(installation.BinaryPath); len(chain) > 1 {
+		output.WriteString(lipgloss.NewStyle().Bold(true).Render("Symlink Chain:") + "\n")
+		output.WriteString(fmt.Sprintf("  • %s\n", chain[0]))
+		for _, hop := range chain[1:] {
+			output.WriteString(fmt.Sprintf("    → %s\n", hop))
+		}
+		output.WriteString("\n")
+	}
+
 	// Environment Variables
-	envVars := provider.GetEnvVars()
+	envVars := opts.EnvVars
 	if len(envVars) > 0 {
 		output.WriteString(lipgloss.NewStyle().Bold(true).Render("Environment Variables:") + "\n")
 		for key, value := range envVars {
@@ -52,27 +112,76 @@ func RenderInfo(provider core.LanguageProvider, installation *core.Installation,
 		output.WriteString("\n")
 	}
 
+	// Provider-specific sections
+	for _, section := range opts.Sections {
+		output.WriteString(renderInfoSection(section))
+	}
+
 	// Cache Locations
 	if diskUsage != nil && len(diskUsage.Items) > 0 {
 		output.WriteString(lipgloss.NewStyle().Bold(true).Render("Cache Locations:") + "\n")
-		for _, item := range diskUsage.Items {
-			if item.Size > 0 {
-				size := humanize.Bytes(uint64(item.Size))
-				output.WriteString(fmt.Sprintf("  • %s: %s (%s)\n", item.Description, item.Path, size))
+		items, collapsed := limitItems(diskUsage.Items, opts.MaxItems)
+		for _, item := range items {
+			size := FormatBytes(item.Size)
+			if item.FileCount > 0 {
+				size += fmt.Sprintf(", files: %s", FormatCount(item.FileCount))
 			}
+			output.WriteString(fmt.Sprintf("  • %s: %s (%s)%s\n", item.Description, item.Path, size, formatDelta(opts.Deltas[item.Path])))
+		}
+		if collapsed.Count > 0 {
+			output.WriteString(fmt.Sprintf("  %s\n", collapsed))
 		}
 		output.WriteString("\n")
 	}
 
+	// What the caches contain
+	for _, section := range opts.Breakdown {
+		output.WriteString(renderInfoSection(section))
+	}
+
 	// Total Disk Usage
 	if diskUsage != nil && diskUsage.Total > 0 {
-		totalSize := humanize.Bytes(uint64(diskUsage.Total))
+		totalSize := FormatBytes(diskUsage.Total)
 		total := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#FFA500")).
-			Render(fmt.Sprintf("Total Disk Usage: %s", totalSize))
+			Render(fmt.Sprintf("Total Disk Usage: %s%s", totalSize, formatDelta(opts.Deltas[""])))
 		output.WriteString(total + "\n")
 	}
 
 	return output.String()
 }
+
+// renderInfoSection renders a titled list of paths, with sizes where known
+func renderInfoSection(section core.InfoSection) string {
+	var output strings.Builder
+	output.WriteString(lipgloss.NewStyle().Bold(true).Render(section.Title+":") + "\n")
+	if section.Note != "" {
+		output.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Render("  "+section.Note) + "\n")
+	}
+	for _, item := range section.Items {
+		if item.Value != "" {
+			output.WriteString(fmt.Sprintf("  • %s: %s\n", item.Description, item.Value))
+			continue
+		}
+		if item.Size == 0 {
+			output.WriteString(fmt.Sprintf("  • %s: %s\n", item.Description, item.Path))
+			continue
+		}
+		size := FormatBytes(item.Size)
+		output.WriteString(fmt.Sprintf("  • %s: %s (%s)\n", item.Description, item.Path, size))
+	}
+	output.WriteString("\n")
+	return output.String()
+}
+
+// formatDelta renders a size change as " [+12 MB]", or "" when nothing changed
+func formatDelta(delta int64) string {
+	switch {
+	case delta > 0:
+		return fmt.Sprintf(" [+%s]", FormatBytes(delta))
+	case delta < 0:
+		return fmt.Sprintf(" [-%s]", FormatBytes(-delta))
+	}
+	return ""
+}
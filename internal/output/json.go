@@ -0,0 +1,34 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Format selects how a command prints its results
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatTSV   Format = "tsv" // Only supported by scan
+	FormatCSV   Format = "csv" // Only supported by scan
+)
+
+// ParseFormat validates an --output value
+func ParseFormat(name string) (Format, error) {
+	switch Format(name) {
+	case FormatTable, FormatJSON, FormatTSV, FormatCSV:
+		return Format(name), nil
+	}
+	return "", fmt.Errorf("unknown output format %q (want table, json, tsv or csv)", name)
+}
+
+// renderJSON encodes v as indented JSON followed by a newline
+func renderJSON(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"dependency-hell-cli/internal/advisor"
+	"dependency-hell-cli/internal/core"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderMigrationPlan renders migration recommendations with copy-pasteable commands
+func RenderMigrationPlan(migrations []advisor.Migration) string {
+	var output strings.Builder
+
+	if len(migrations) == 0 {
+		output.WriteString("✅ All detected languages are managed by a version manager.\n")
+		return output.String()
+	}
+
+	// Header
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(1, 2).
+		Render("Migration Advisor")
+
+	border := strings.Repeat("─", 60)
+	output.WriteString("╭" + border + "╮\n")
+	output.WriteString("│" + lipgloss.NewStyle().Width(60).Align(lipgloss.Center).Render(header) + "│\n")
+	output.WriteString("╰" + border + "╯\n\n")
+
+	for _, migration := range migrations {
+		status := core.DetermineStatus(migration.Source)
+		title := fmt.Sprintf("%s %s %s", status.GetStatusIcon(), migration.Language, migration.Version)
+		output.WriteString(LanguageStyle.Render(title) + "\n")
+		output.WriteString(fmt.Sprintf("  %s\n\n", migration.Reason))
+
+		for _, command := range migration.Commands {
+			output.WriteString(fmt.Sprintf("    %s\n", command))
+		}
+		output.WriteString("\n")
+	}
+
+	// Footer
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888")).
+		Render("These commands are suggestions only; dhell never runs them.")
+	output.WriteString(footer + "\n")
+
+	return output.String()
+}
+
+// RenderMigrationScript renders migration recommendations as a reviewable shell script
+func RenderMigrationScript(migrations []advisor.Migration) string {
+	var output strings.Builder
+
+	output.WriteString("#!/bin/sh\n")
+	output.WriteString("# Generated by dhell migrate. Review before running.\n")
+	output.WriteString("set -e\n")
+
+	for _, migration := range migrations {
+		output.WriteString("\n")
+		output.WriteString(fmt.Sprintf("# %s\n", migration.Reason))
+		for _, command := range migration.Commands {
+			output.WriteString(command + "\n")
+		}
+	}
+
+	return output.String()
+}
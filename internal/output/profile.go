@@ -0,0 +1,59 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxProfileItems is how many of the slowest cache walks scan --profile lists
+const maxProfileItems = 5
+
+// renderProfile lists how long each provider took and the slowest cache walks of the scan
+func renderProfile(results []ScanResult) string {
+	type walk struct {
+		language string
+		path     string
+		elapsed  time.Duration
+	}
+
+	var output strings.Builder
+	var walks []walk
+	var providers []string
+	for _, result := range results {
+		if result.Elapsed > 0 {
+			providers = append(providers, fmt.Sprintf("%s %s", result.Provider.Name(), formatElapsed(result.Elapsed)))
+		}
+		for path, elapsed := range result.ItemTimes {
+			walks = append(walks, walk{result.Provider.Name(), path, elapsed})
+		}
+	}
+	if len(providers) == 0 {
+		return ""
+	}
+
+	output.WriteString(fmt.Sprintf("⏱️  Provider times: %s\n", strings.Join(providers, ", ")))
+
+	sort.Slice(walks, func(i, j int) bool {
+		return walks[i].elapsed > walks[j].elapsed
+	})
+	if len(walks) > maxProfileItems {
+		walks = walks[:maxProfileItems]
+	}
+	if len(walks) > 0 {
+		output.WriteString("⏱️  Slowest cache walks:\n")
+		for _, w := range walks {
+			output.WriteString(fmt.Sprintf("  • %s %s: %s\n", formatElapsed(w.elapsed), w.language, w.path))
+		}
+	}
+	return output.String()
+}
+
+// formatElapsed rounds a duration for display, e.g. "1.24s" or "35ms"
+func formatElapsed(d time.Duration) string {
+	if d >= time.Second {
+		return d.Round(10 * time.Millisecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
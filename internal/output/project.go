@@ -0,0 +1,62 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProjectArtifacts holds the build artifacts a provider found in a project tree
+type ProjectArtifacts struct {
+	Language string
+	Items    []core.CleanableItem
+}
+
+// RenderProjectArtifacts renders the artifacts found by scan --local, with paths relative to root
+func RenderProjectArtifacts(root string, artifacts []ProjectArtifacts) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Project artifacts in %s\n\n", root))
+
+	var total int64
+	found := false
+	for _, language := range artifacts {
+		if len(language.Items) == 0 {
+			continue
+		}
+		found = true
+
+		var size int64
+		for _, item := range language.Items {
+			size += item.Size
+		}
+		total += size
+		output.WriteString(fmt.Sprintf("%s %s\n", LanguageStyle.Render(language.Language), DiskUsageDescStyle.Render(FormatBytes(size))))
+
+		for _, item := range language.Items {
+			path := item.Path
+			if rel, err := filepath.Rel(root, item.Path); err == nil {
+				path = rel
+			}
+			output.WriteString(fmt.Sprintf("  ↳ %s: %s (%s)\n", item.Description, path, FormatBytes(item.Size)))
+		}
+		output.WriteString("\n")
+	}
+
+	if !found {
+		output.WriteString("No build artifacts found.\n")
+		return output.String()
+	}
+
+	summary := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFA500")).
+		Render(fmt.Sprintf("Total: %s (remove with dhell clean <language> --local %s)", FormatBytes(total), root))
+	output.WriteString(summary + "\n")
+
+	return output.String()
+}
@@ -4,10 +4,11 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"time"
 
 	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
 
-	"github.com/dustin/go-humanize"
 	"github.com/shirou/gopsutil/v3/host"
 )
 
@@ -16,11 +17,25 @@ type ScanResult struct {
 	Provider      core.LanguageProvider
 	Installations []core.Installation // Changed to array to support multiple versions
 	DiskUsage     *core.DiskUsage
+	Locations     []core.BinaryLocation // Every distinct copy of the binary on PATH
+	WindowsPaths  []string              // Windows-side copies of the binary, only looked for under WSL
+	Warnings      []string              // Non-fatal problems hit while scanning, shown below the table
 	Error         error
+	TimedOut      bool                     // The scan was cancelled by --timeout before this provider finished
+	Elapsed       time.Duration            // Wall time of the whole provider scan
+	ItemTimes     map[string]time.Duration // Time spent measuring each disk usage item, by path (scan --profile)
+}
+
+// ScanOptions controls how scan results are rendered
+type ScanOptions struct {
+	MaxItems    int  // Largest breakdown items to show per language; 0 shows all
+	ShowCounts  bool // Show the number of files next to each breakdown item
+	NoBreakdown bool // Omit the ↳ breakdown rows, leaving one row per language
+	Profile     bool // Show how long each item took to measure and list the slowest ones
 }
 
 // RenderScanResults renders the scan results as a formatted table
-func RenderScanResults(results []ScanResult) string {
+func RenderScanResults(results []ScanResult, opts ScanOptions) string {
 	var output strings.Builder
 
 	// Filter out results with errors (uninstalled languages)
@@ -33,17 +48,21 @@ func RenderScanResults(results []ScanResult) string {
 
 	// If no valid results, show message
 	if len(validResults) == 0 {
-		return "No languages detected in your environment.\n"
+		return "No languages detected in your environment.\n" + renderTimedOut(results) + renderWarnings(results, nil)
 	}
 
 	// Get system info
-	osInfo, arch := getSystemInfo()
+	osInfo, arch, systemWarnings := getSystemInfo()
 
 	// Header
 	output.WriteString("                                     \n")
 	output.WriteString("  Dependency Hell Analyzer (v0.1.0)  \n")
 	output.WriteString("                                     \n")
-	output.WriteString(fmt.Sprintf("OS: %s (%s)\n\n", osInfo, arch))
+	output.WriteString(fmt.Sprintf("OS: %s (%s)\n", osInfo, arch))
+	if scanner.IsWSL() {
+		output.WriteString("Environment: WSL — results describe the Linux side; Windows installs under /mnt are noted per language\n")
+	}
+	output.WriteString("\n")
 
 	// Table header
 	output.WriteString(" STATUS   LANGUAGE     VERSION         SOURCE             DISK USAGE                                  \n")
@@ -51,27 +70,67 @@ func RenderScanResults(results []ScanResult) string {
 
 	// Table rows - only for valid results
 	for _, result := range validResults {
-		rows := renderResultRows(result)
+		rows := renderResultRows(result, opts)
 		for _, row := range rows {
 			output.WriteString(row + "\n")
 		}
 		output.WriteString("────────────────────────────────────────────────────────────────────────────────────────────────────\n")
 	}
 
+	output.WriteString(renderTimedOut(results))
+	if opts.Profile {
+		output.WriteString(renderProfile(results))
+	}
+	output.WriteString(renderWarnings(results, systemWarnings))
+
 	return output.String()
 }
 
-// getSystemInfo gets OS and architecture information
-func getSystemInfo() (string, string) {
-	info, err := host.Info()
-	if err != nil {
-		return runtime.GOOS, runtime.GOARCH
+// renderWarnings lists the non-fatal problems of the whole scan in one place,
+// starting with those hit while building the header
+func renderWarnings(results []ScanResult, systemWarnings []string) string {
+	warnings := systemWarnings
+	for _, result := range results {
+		for _, warning := range result.Warnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", result.Provider.Name(), warning))
+		}
+	}
+	if len(warnings) == 0 {
+		return ""
 	}
 
-	platform := info.Platform
-	if info.PlatformVersion != "" {
-		platform = fmt.Sprintf("%s %s", info.Platform, info.PlatformVersion)
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("⚠️  %d warnings (results may be incomplete):\n", len(warnings)))
+	for _, warning := range warnings {
+		output.WriteString(fmt.Sprintf("  • %s\n", warning))
+	}
+	return output.String()
+}
+
+// renderTimedOut notes the providers that did not finish before --timeout
+func renderTimedOut(results []ScanResult) string {
+	var names []string
+	for _, result := range results {
+		if result.TimedOut {
+			names = append(names, result.Provider.Name())
+		}
+	}
+	if len(names) == 0 {
+		return ""
 	}
+	return fmt.Sprintf("⏱️  Timed out before finishing: %s (results incomplete)\n", strings.Join(names, ", "))
+}
+
+// SystemWarnings returns the problems hit while reading OS details for the scan header
+func SystemWarnings() []string {
+	_, _, warnings := getSystemInfo()
+	return warnings
+}
+
+// getSystemInfo gets OS and architecture information, falling back to the Go runtime's
+// values and returning a warning when the host can't be queried
+func getSystemInfo() (string, string, []string) {
+	var warnings []string
 
 	arch := runtime.GOARCH
 	if arch == "amd64" {
@@ -80,11 +139,52 @@ func getSystemInfo() (string, string) {
 		arch = "ARM64"
 	}
 
-	return platform, arch
+	info, err := host.Info()
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("could not read OS details, showing %s instead: %v", runtime.GOOS, err))
+		return runtime.GOOS, arch, warnings
+	}
+	if info.Platform == "" {
+		warnings = append(warnings, fmt.Sprintf("could not identify the OS distribution, showing %s instead", runtime.GOOS))
+		return runtime.GOOS, arch, warnings
+	}
+
+	platform := info.Platform
+	if info.PlatformVersion != "" {
+		platform = fmt.Sprintf("%s %s", info.Platform, info.PlatformVersion)
+	}
+
+	return platform, arch, warnings
+}
+
+// Widths of the scan table's columns, each counting the space that opens it
+const (
+	statusColumnWidth   = 8
+	languageColumnWidth = 12
+	versionColumnWidth  = 15
+	sourceColumnWidth   = 18
+	detailColumnWidth   = 44
+)
+
+// detailIndent is the blank space before the detail column on the rows below a language's first
+var detailIndent = strings.Repeat(" ", statusColumnWidth+languageColumnWidth+versionColumnWidth+sourceColumnWidth)
+
+// tableCell left-aligns text in a column of the given width, truncating it with … when it
+// doesn't fit, so the columns after it stay aligned
+func tableCell(text string, width int) string {
+	if runes := []rune(text); len(runes) > width-1 {
+		text = string(runes[:width-2]) + "…"
+	}
+	return fmt.Sprintf(" %-*s", width-1, text)
+}
+
+// detailRow renders a row that only fills the detail column. Details are not truncated.
+func detailRow(text string) string {
+	return detailIndent + fmt.Sprintf(" %-*s", detailColumnWidth-1, text)
 }
 
 // renderResultRows renders result rows (can be multiple for disk usage breakdown)
-func renderResultRows(result ScanResult) []string {
+func renderResultRows(result ScanResult, opts ScanOptions) []string {
 	var rows []string
 
 	installations := result.Installations
@@ -106,49 +206,99 @@ func renderResultRows(result ScanResult) []string {
 	sourceDisplay := string(installations[0].Source)
 	if installations[0].ManagerName != "" {
 		sourceDisplay = installations[0].ManagerName
+	} else if installations[0].Arch != "" {
+		sourceDisplay = fmt.Sprintf("%s (%s)", installations[0].Source, installations[0].Arch)
 	}
 
 	// First row with main info
-	statusStr := fmt.Sprintf(" %-7s", statusIcon)
-	languageStr := fmt.Sprintf(" %-11s", result.Provider.Name())
-	versionStr := fmt.Sprintf(" %-14s", versionInfo)
-	sourceStr := fmt.Sprintf(" %-17s", sourceDisplay)
+	statusStr := fmt.Sprintf(" %-*s", statusColumnWidth-1, statusIcon)
+	languageStr := fmt.Sprintf(" %-*s", languageColumnWidth-1, result.Provider.Name())
+	versionStr := fmt.Sprintf(" %-*s", versionColumnWidth-1, versionInfo)
+	sourceStr := fmt.Sprintf(" %-*s", sourceColumnWidth-1, sourceDisplay)
 
-	// Disk usage - show total first
-	totalSize := humanize.Bytes(uint64(diskUsage.Total))
-	diskUsageStr := fmt.Sprintf(" Total: %-38s", totalSize)
+	// Disk usage - show total first; an installed language may simply not have cached anything yet
+	totalSize := FormatBytes(diskUsage.Total)
+	if diskUsage.Total == 0 {
+		totalSize += " (installed, no caches yet)"
+	}
+	diskUsageStr := fmt.Sprintf(" Total: %-*s", detailColumnWidth-len(" Total: "), totalSize)
 
 	firstRow := statusStr + languageStr + versionStr + sourceStr + diskUsageStr
 	rows = append(rows, firstRow)
 
-	// If multiple versions, show each version
+	// If multiple versions, show each version; the active one carries the distribution
 	if len(installations) > 1 {
 		for i, inst := range installations {
 			activeMarker := ""
 			if i == 0 {
 				activeMarker = " (active)"
+				if inst.Distribution != "" {
+					activeMarker = fmt.Sprintf(" (active, %s)", inst.Distribution)
+				}
+			} else if inst.Source != installations[0].Source || inst.Arch != installations[0].Arch {
+				// Installed alongside the active one from another source
+				activeMarker = fmt.Sprintf(" (%s)", inst.Source)
 			}
-			versionLine := fmt.Sprintf("  • %s%s", inst.Version, activeMarker)
+			rows = append(rows, detailRow(fmt.Sprintf("  • %s%s", inst.Version, activeMarker)))
+		}
+	}
+
+	// Point out a newer version the version manager has installed but doesn't select
+	if newer, ok := core.NewerInactive(installations); ok {
+		rows = append(rows, detailRow(fmt.Sprintf("  ⬆️  %s installed but %s active", newer.Version, installations[0].Version)))
+	}
 
-			emptyPrefix := strings.Repeat(" ", 8+12+15+18)
-			versionCell := fmt.Sprintf(" %-43s", versionLine)
+	// Warn when the binary exists in several PATH locations
+	if len(result.Locations) > 1 {
+		rows = append(rows, detailRow(fmt.Sprintf("  ⚠️  %s found in %d locations:", result.Provider.Name(), len(result.Locations))))
 
-			row := emptyPrefix + versionCell
-			rows = append(rows, row)
+		for _, location := range result.Locations {
+			rows = append(rows, detailRow(fmt.Sprintf("    %s (%s)", location.Path, location.Source)))
 		}
 	}
 
-	// Additional rows for disk usage breakdown
-	for _, item := range diskUsage.Items {
-		if item.Size > 0 {
-			size := humanize.Bytes(uint64(item.Size))
-			desc := fmt.Sprintf("  ↳ %s: %s", item.Description, size)
+	// Under WSL, point out native Windows copies that are easy to confuse with the Linux ones
+	if len(result.WindowsPaths) > 0 {
+		rows = append(rows, detailRow(fmt.Sprintf("  🪟 Windows-side %s also installed:", result.Provider.Name())))
+
+		for _, path := range result.WindowsPaths {
+			rows = append(rows, detailRow("    "+path))
+		}
+	}
 
-			emptyPrefix := strings.Repeat(" ", 8+12+15+18)
-			diskCell := fmt.Sprintf(" %-43s", desc)
+	// Additional rows for disk usage breakdown
+	var items []core.DiskUsageItem
+	var collapsed collapsedItems
+	if !opts.NoBreakdown {
+		items, collapsed = limitItems(diskUsage.Items, opts.MaxItems)
+	}
+	for _, item := range items {
+		size := FormatBytes(item.Size)
+		desc := fmt.Sprintf("  ↳ %s: %s", item.Description, size)
+		if item.Heuristic {
+			desc += " (heuristic)"
+		}
+		if opts.ShowCounts && item.FileCount > 0 {
+			desc += fmt.Sprintf(" (files: %s)", FormatCount(item.FileCount))
+		}
+		if elapsed, ok := result.ItemTimes[item.Path]; opts.Profile && ok {
+			desc += fmt.Sprintf(" [%s]", formatElapsed(elapsed))
+		}
+		rows = append(rows, detailRow(desc))
+	}
+	if collapsed.Count > 0 {
+		rows = append(rows, detailRow("  "+collapsed.String()))
+	}
 
-			row := emptyPrefix + diskCell
-			rows = append(rows, row)
+	// A single installation shows its distribution under the version, reusing the first
+	// detail row if present; every detail row starts with the blank detailIndent
+	if distribution := installations[0].Distribution; distribution != "" && len(installations) == 1 {
+		const distributionStart = statusColumnWidth + languageColumnWidth
+		distributionCell := strings.Repeat(" ", distributionStart) + tableCell(distribution, versionColumnWidth)
+		if len(rows) > 1 {
+			rows[1] = distributionCell + rows[1][distributionStart+versionColumnWidth:]
+		} else {
+			rows = append(rows, distributionCell)
 		}
 	}
 
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"dependency-hell-cli/internal/core"
+)
+
+func TestDistributionCellKeepsColumnsAligned(t *testing.T) {
+	usage := &core.DiskUsage{
+		Total: 1000,
+		Items: []core.DiskUsageItem{
+			{Path: "/cache/a", Description: "Cache A", Size: 600},
+			{Path: "/cache/b", Description: "Cache B", Size: 400},
+		},
+	}
+	render := func(installations ...core.Installation) []string {
+		return renderResultRows(ScanResult{Provider: fakeProvider{}, Installations: installations, DiskUsage: usage}, ScanOptions{})
+	}
+	detailColumn := func(row string) int {
+		return utf8.RuneCountInString(row[:strings.Index(row, "↳")])
+	}
+
+	plain := render(core.Installation{Version: "21.0.2", Source: core.SourceSystem})
+	vendored := render(core.Installation{Version: "21.0.2", Source: core.SourceSystem, Distribution: "Eclipse Temurin"})
+	if len(vendored) != len(plain) {
+		t.Fatalf("distribution changed the row count: %d, want %d", len(vendored), len(plain))
+	}
+	for i := 1; i < len(plain); i++ {
+		if got, want := detailColumn(vendored[i]), detailColumn(plain[i]); got != want {
+			t.Errorf("row %d detail starts at column %d, want %d:\n%s", i, got, want, vendored[i])
+		}
+	}
+
+	cell := vendored[1][statusColumnWidth+languageColumnWidth:]
+	cell = string([]rune(cell)[:versionColumnWidth])
+	if !strings.HasPrefix(cell, " Eclipse Temur") || utf8.RuneCountInString(cell) != versionColumnWidth {
+		t.Errorf("distribution cell = %q, want it fitted to %d columns", cell, versionColumnWidth)
+	}
+
+	multiple := render(
+		core.Installation{Version: "21.0.2", Source: core.SourceSystem, Distribution: "Eclipse Temurin"},
+		core.Installation{Version: "17.0.9", Source: core.SourceSystem, Distribution: "Amazon Corretto"},
+	)
+	if strings.Contains(multiple[1][:statusColumnWidth+languageColumnWidth+versionColumnWidth], "Eclipse") {
+		t.Errorf("vendor of the active version shown under the version count:\n%s", multiple[1])
+	}
+	if !strings.Contains(multiple[1], "21.0.2 (active, Eclipse Temurin)") {
+		t.Errorf("active version row lacks its distribution:\n%s", multiple[1])
+	}
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dependency-hell-cli/internal/scanner"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderTempLeftovers renders the tool leftovers and crash logs found by scan --temp, grouped by tool.
+// Leftovers last modified more than olderThan ago are marked as removable.
+func RenderTempLeftovers(dirs []string, leftovers []scanner.TempLeftover, olderThan time.Duration) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Temp leftovers in %s, and crash logs\n\n", strings.Join(dirs, ", ")))
+
+	if len(leftovers) == 0 {
+		output.WriteString("No temp leftovers or crash logs found.\n")
+		return output.String()
+	}
+
+	var total, removable int64
+	for start := 0; start < len(leftovers); {
+		// Leftovers are sorted by language and tool, so each group is a contiguous run
+		end := start
+		var size int64
+		for end < len(leftovers) && leftovers[end].Tool == leftovers[start].Tool {
+			size += leftovers[end].Size
+			end++
+		}
+		total += size
+
+		group := leftovers[start:end]
+		header := fmt.Sprintf("%s (%s)", group[0].Tool, group[0].Language)
+		output.WriteString(fmt.Sprintf("%s %s\n", LanguageStyle.Render(header), DiskUsageDescStyle.Render(FormatBytes(size))))
+
+		for _, leftover := range group {
+			age := leftover.Age()
+			note := "in use or recent, kept"
+			if age > olderThan {
+				note = "removable"
+				if leftover.Unsafe {
+					note = "removable, asks first"
+				}
+				removable += leftover.Size
+			}
+			output.WriteString(fmt.Sprintf("  ↳ %s (%s, modified %s ago, %s)\n", leftover.Path, FormatBytes(leftover.Size), formatAge(age), note))
+		}
+		output.WriteString("\n")
+		start = end
+	}
+
+	summary := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFA500")).
+		Render(fmt.Sprintf("Total: %s, %s older than %s (remove with dhell clean all --temp)", FormatBytes(total), FormatBytes(removable), formatAge(olderThan)))
+	output.WriteString(summary + "\n")
+
+	return output.String()
+}
+
+// formatAge renders a duration coarsely, e.g. "3d", "5h" or "12m"
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%dm", int(d/time.Minute))
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tsvHeader names the columns of scan --output tsv
+var tsvHeader = []string{"language", "version", "source", "item", "path", "bytes"}
+
+// tsvTotalItem is the item column of the row carrying a language's total
+const tsvTotalItem = "Total"
+
+// RenderScanResultsTSV renders installed languages as tab-separated rows for awk and cut:
+// a header, then per language a Total row followed by one row per cache item.
+// Sizes are plain byte counts, and tabs and newlines inside values are replaced by spaces.
+func RenderScanResultsTSV(results []ScanResult) string {
+	var output strings.Builder
+	writeTSVRow(&output, tsvHeader...)
+	for _, row := range scanRows(results) {
+		writeTSVRow(&output, row...)
+	}
+	return output.String()
+}
+
+// scanRows flattens installed languages into a Total row followed by one row per cache item,
+// in the column order language, version, source, item, path, bytes. TSV and CSV share it.
+func scanRows(results []ScanResult) [][]string {
+	var rows [][]string
+	for _, result := range results {
+		if result.Error != nil || len(result.Installations) == 0 {
+			continue
+		}
+
+		active := result.Installations[0]
+		language := result.Provider.Name()
+		source := string(active.Source)
+		if active.ManagerName != "" {
+			source = active.ManagerName
+		}
+
+		var total int64
+		if result.DiskUsage != nil {
+			total = result.DiskUsage.Total
+		}
+		rows = append(rows, []string{language, active.Version, source, tsvTotalItem, active.BinaryPath, fmt.Sprint(total)})
+
+		if result.DiskUsage == nil {
+			continue
+		}
+		for _, item := range result.DiskUsage.Items {
+			rows = append(rows, []string{language, active.Version, source, item.Description, item.Path, fmt.Sprint(item.Size)})
+		}
+	}
+	return rows
+}
+
+// writeTSVRow writes one row, sanitizing each value so it stays in its column
+func writeTSVRow(output *strings.Builder, values ...string) {
+	for i, value := range values {
+		if i > 0 {
+			output.WriteByte('\t')
+		}
+		output.WriteString(sanitizeTSV(value))
+	}
+	output.WriteByte('\n')
+}
+
+// tsvReplacer turns the characters that would break a row into spaces
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// sanitizeTSV makes a value safe for a tab-separated column
+func sanitizeTSV(value string) string {
+	return tsvReplacer.Replace(value)
+}
@@ -0,0 +1,157 @@
+package providers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// CargoInstall is a crate installed with cargo install, as recorded in Cargo's install manifest
+type CargoInstall struct {
+	Crate   string
+	Version string
+	Source  string   // "crates.io", "git", "path" or "registry"
+	Bins    []string // Binaries the crate put in ~/.cargo/bin
+}
+
+// cargoHome returns the Cargo home directory, honoring CARGO_HOME
+func (p *RustProvider) cargoHome() string {
+	if home, ok := scanner.ConfiguredPath("rust", "cargo_home"); ok {
+		return home
+	}
+	if home := scanner.GetEnvVar("CARGO_HOME"); home != "" {
+		return home
+	}
+	return "~/.cargo"
+}
+
+// CargoInstalls lists the crates installed with cargo install, sorted by name.
+// It reads .crates2.json and falls back to the older .crates.toml.
+func (p *RustProvider) CargoInstalls() []CargoInstall {
+	home := scanner.ExpandHome(p.cargoHome())
+
+	installs, err := readCrates2JSON(filepath.Join(home, ".crates2.json"))
+	if err != nil {
+		installs, _ = readCratesTOML(filepath.Join(home, ".crates.toml"))
+	}
+
+	sort.Slice(installs, func(i, j int) bool { return installs[i].Crate < installs[j].Crate })
+	return installs
+}
+
+// readCrates2JSON parses the install manifest written by current Cargo versions
+func readCrates2JSON(path string) ([]CargoInstall, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest struct {
+		Installs map[string]struct {
+			Bins []string `json:"bins"`
+		} `json:"installs"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	var installs []CargoInstall
+	for key, entry := range manifest.Installs {
+		if install, ok := parseCargoPackageID(key); ok {
+			install.Bins = entry.Bins
+			installs = append(installs, install)
+		}
+	}
+	return installs, nil
+}
+
+// readCratesTOML parses the [v1] table of the legacy install manifest,
+// whose lines look like "ripgrep 14.1.0 (registry+https://...)" = ["rg"]
+func readCratesTOML(path string) ([]CargoInstall, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var installs []CargoInstall
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		key, value, ok := strings.Cut(lines.Text(), "=")
+		if !ok {
+			continue
+		}
+
+		install, ok := parseCargoPackageID(strings.Trim(strings.TrimSpace(key), `"`))
+		if !ok {
+			continue
+		}
+
+		value = strings.Trim(strings.TrimSpace(value), "[]")
+		for _, bin := range strings.Split(value, ",") {
+			if bin = strings.Trim(strings.TrimSpace(bin), `"`); bin != "" {
+				install.Bins = append(install.Bins, bin)
+			}
+		}
+		installs = append(installs, install)
+	}
+	return installs, lines.Err()
+}
+
+// parseCargoPackageID splits a manifest key "name version (kind+url)" into a CargoInstall
+func parseCargoPackageID(id string) (CargoInstall, bool) {
+	fields := strings.Fields(id)
+	if len(fields) < 3 {
+		return CargoInstall{}, false
+	}
+
+	kind, location, _ := strings.Cut(strings.Trim(fields[2], "()"), "+")
+	source := kind
+	if kind == "registry" && strings.Contains(location, "github.com/rust-lang/crates.io-index") ||
+		kind == "sparse" && strings.Contains(location, "index.crates.io") {
+		source = "crates.io"
+	}
+
+	return CargoInstall{Crate: fields[0], Version: fields[1], Source: source}, true
+}
+
+// GetInfoSections lists the binaries installed with cargo install for the info command
+func (p *RustProvider) GetInfoSections(ctx context.Context) []core.InfoSection {
+	installs := p.CargoInstalls()
+	if len(installs) == 0 {
+		return nil
+	}
+
+	binDir := filepath.Join(p.cargoHome(), "bin")
+	section := core.InfoSection{Title: fmt.Sprintf("Cargo Installed Binaries (%d crates)", len(installs))}
+	for _, install := range installs {
+		for _, bin := range install.Bins {
+			path := filepath.Join(scanner.ExpandHome(binDir), bin)
+			description := fmt.Sprintf("%s (%s %s, %s)", bin, install.Crate, install.Version, install.Source)
+
+			info, err := os.Stat(path)
+			if err != nil {
+				description += " (missing from bin directory)"
+				section.Items = append(section.Items, core.DiskUsageItem{Path: path, Description: description})
+				continue
+			}
+
+			// The binary's mtime is when it was last (re)installed
+			section.Items = append(section.Items, core.DiskUsageItem{
+				Path:        path,
+				Description: fmt.Sprintf("%s, installed %s", description, info.ModTime().Format("2006-01-02")),
+				Size:        info.Size(),
+			})
+		}
+	}
+
+	return expandInfoSections([]core.InfoSection{section})
+}
@@ -0,0 +1,112 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// cleanItems is the clean loop shared by the providers. A dry run records every item
+// without touching anything; otherwise each item is cleaned with clean, which returns the
+// bytes it freed, and a failure is collected without stopping the remaining items.
+// Items not yet cleaned when ctx is done fail with its error. The result lists the items
+// that were (or would be) cleaned.
+func cleanItems(ctx context.Context, items []core.CleanableItem, dryRun bool, clean func(context.Context, core.CleanableItem) (int64, error)) *core.CleanResult {
+	result := &core.CleanResult{
+		ItemsCleaned:   0,
+		SpaceReclaimed: 0,
+		Errors:         []error{},
+		DryRun:         dryRun,
+	}
+
+	for _, item := range items {
+		reclaimed := item.Size
+		if !dryRun {
+			err := ctx.Err()
+			if err == nil {
+				reclaimed, err = clean(ctx, item)
+			}
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to clean %s: %w", item.Description, err))
+				continue
+			}
+		}
+
+		result.Items = append(result.Items, item)
+		result.ItemsCleaned++
+		result.SpaceReclaimed += reclaimed
+	}
+
+	return result
+}
+
+// cleanItem runs an item's clean command when it has one, removes the files matching its
+// patterns when it has those, and removes its path otherwise
+func cleanItem(ctx context.Context, item core.CleanableItem) (int64, error) {
+	switch {
+	case item.Command != "":
+		return item.Size, runCleanCommand(ctx, item)
+	case item.Path != "" && len(item.Patterns) > 0:
+		return item.Size, removeMatchingFiles(ctx, item.Path, item.Patterns)
+	case item.Path != "":
+		return item.Size, scanner.RemoveDir(item.Path)
+	}
+	return 0, fmt.Errorf("nothing to clean: no path or command")
+}
+
+// runCleanCommand runs an item's clean command, in the offline environment when --offline
+// is set. An item whose command has no words fails instead of running nothing.
+func runCleanCommand(ctx context.Context, item core.CleanableItem) error {
+	args := item.CommandArgs()
+	if len(args) == 0 {
+		return fmt.Errorf("empty command %q", item.Command)
+	}
+	return scanner.RunCommand(ctx, args[0], args[1:]...)
+}
+
+// matchesAny reports whether a file name matches one of the glob patterns
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// walkMatchingFiles calls fn for every file under root whose name matches one of patterns.
+// It stops between directories once ctx is done and returns its error.
+func walkMatchingFiles(ctx context.Context, root string, patterns []string, fn func(path string, info fs.FileInfo)) error {
+	return filepath.WalkDir(scanner.ExpandHome(root), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			return ctx.Err()
+		}
+		if !matchesAny(d.Name(), patterns) {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			fn(path, info)
+		}
+		return nil
+	})
+}
+
+// removeMatchingFiles deletes the files under root matching patterns and nothing else
+func removeMatchingFiles(ctx context.Context, root string, patterns []string) error {
+	var errs []error
+	err := walkMatchingFiles(ctx, root, patterns, func(path string, info fs.FileInfo) {
+		if err := os.Remove(path); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	return errors.Join(append(errs, err)...)
+}
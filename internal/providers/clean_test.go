@@ -0,0 +1,104 @@
+package providers
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// cleaners are the built-in providers that clean items themselves
+func cleaners() map[string]core.LanguageProvider {
+	return map[string]core.LanguageProvider{
+		"deno":     NewDenoProvider(),
+		"go":       NewGoProvider(),
+		"homebrew": NewHomebrewProvider(),
+		"java":     NewJavaProvider(),
+		"node":     NewNodeProvider(),
+		"python":   NewPythonProvider(),
+		"rust":     NewRustProvider(),
+	}
+}
+
+func TestCleanNeverRemovesHome(t *testing.T) {
+	env := newFakeEnv(t)
+	sentinel := env.writeFile("keep.txt", 10)
+
+	for name, provider := range cleaners() {
+		items := []core.CleanableItem{
+			{Path: "~", Description: "Tilde"},
+			{Path: env.home, Description: "Home"},
+			{Path: env.home + "/..", Description: "Parent of home"},
+		}
+		result, err := provider.Clean(context.Background(), items, false)
+		if err != nil {
+			t.Fatalf("%s: Clean() error = %v", name, err)
+		}
+		if result.ItemsCleaned != 0 || len(result.Errors) != len(items) {
+			t.Errorf("%s: Clean() cleaned %d items with %d errors, want 0 cleaned and %d errors",
+				name, result.ItemsCleaned, len(result.Errors), len(items))
+		}
+		if _, err := os.Stat(sentinel); err != nil {
+			t.Fatalf("%s: home directory was touched: %v", name, err)
+		}
+	}
+}
+
+func TestCleanRejectsBlankCommand(t *testing.T) {
+	newFakeEnv(t)
+
+	for name, provider := range cleaners() {
+		result, err := provider.Clean(context.Background(), []core.CleanableItem{{Description: "Blank", Command: " \t"}}, false)
+		if err != nil {
+			t.Fatalf("%s: Clean() error = %v", name, err)
+		}
+		if result.ItemsCleaned != 0 || len(result.Errors) != 1 {
+			t.Errorf("%s: Clean() of a blank command cleaned %d items with %d errors, want 0 and 1",
+				name, result.ItemsCleaned, len(result.Errors))
+		}
+	}
+}
+
+func TestCommandArgsKeepsArgv(t *testing.T) {
+	item := core.CleanableItem{
+		Command: "/opt/my tools/pip cache remove *",
+		Args:    []string{"/opt/my tools/pip", "cache", "remove", "*"},
+	}
+	if got := item.CommandArgs(); len(got) != 4 || got[0] != "/opt/my tools/pip" {
+		t.Errorf("CommandArgs() = %q, want the Args argv", got)
+	}
+
+	item = core.CleanableItem{Command: "go clean -modcache"}
+	if got := item.CommandArgs(); len(got) != 3 || got[2] != "-modcache" {
+		t.Errorf("CommandArgs() = %q, want the Command words", got)
+	}
+}
+
+func TestCleanItemsResult(t *testing.T) {
+	items := []core.CleanableItem{
+		{Description: "Good", Path: "/cache/good", Size: 100},
+		{Description: "Bad", Path: "/cache/bad", Size: 50},
+	}
+
+	preview := cleanItems(context.Background(), items, true, func(context.Context, core.CleanableItem) (int64, error) {
+		t.Fatal("a dry run must not clean anything")
+		return 0, nil
+	})
+	if !preview.DryRun || preview.ItemsCleaned != 2 || preview.SpaceReclaimed != 150 || len(preview.Items) != 2 {
+		t.Errorf("dry run result = %+v, want both items and 150 bytes", preview)
+	}
+
+	result := cleanItems(context.Background(), items, false, func(_ context.Context, item core.CleanableItem) (int64, error) {
+		if item.Description == "Bad" {
+			return 0, os.ErrPermission
+		}
+		return 80, nil
+	})
+	if result.ItemsCleaned != 1 || result.SpaceReclaimed != 80 || len(result.Errors) != 1 {
+		t.Errorf("clean result = %+v, want 1 item, 80 bytes and 1 error", result)
+	}
+	if len(result.Items) != 1 || result.Items[0].Description != "Good" {
+		t.Errorf("cleaned items = %+v, want only Good", result.Items)
+	}
+}
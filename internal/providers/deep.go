@@ -0,0 +1,72 @@
+package providers
+
+import "dependency-hell-cli/internal/core"
+
+// Less-standard cache locations probed by scan --deep. These are best-effort guesses:
+// tools sharing a directory name or leftovers from uninstalled tools can match too.
+
+// DeepCacheCandidates returns extra Go cache locations
+func (p *GoProvider) DeepCacheCandidates() []core.CacheCandidate {
+	return []core.CacheCandidate{
+		{Pattern: "$XDG_CACHE_HOME/gopls", Description: "gopls Cache"},
+		{Pattern: "~/Library/Caches/gopls", Description: "gopls Cache"},
+		{Pattern: "$TMPDIR/go-build*", Description: "Temporary Build Dirs"},
+	}
+}
+
+// DeepCacheCandidates returns extra Node.js cache locations
+func (p *NodeProvider) DeepCacheCandidates() []core.CacheCandidate {
+	return []core.CacheCandidate{
+		{Pattern: "$XDG_CACHE_HOME/node-gyp", Description: "node-gyp Headers"},
+		{Pattern: "~/.node-gyp", Description: "node-gyp Headers"},
+		{Pattern: "$XDG_CACHE_HOME/typescript", Description: "TypeScript Type Cache"},
+		{Pattern: "~/Library/Caches/typescript", Description: "TypeScript Type Cache"},
+		{Pattern: "$TMPDIR/npm-*", Description: "Temporary npm Dirs"},
+	}
+}
+
+// DeepCacheCandidates returns extra Java cache locations
+func (p *JavaProvider) DeepCacheCandidates() []core.CacheCandidate {
+	return []core.CacheCandidate{
+		{Pattern: "$XDG_CACHE_HOME/coursier", Description: "Coursier Cache"},
+		{Pattern: "~/Library/Caches/Coursier", Description: "Coursier Cache"},
+		{Pattern: "~/.ivy2/cache", Description: "Ivy Cache"},
+		{Pattern: "~/.sbt/boot", Description: "sbt Boot"},
+		{Pattern: "$TMPDIR/gradle-worker-*", Description: "Temporary Gradle Workers"},
+		{Pattern: "/var/cache/maven", Description: "System Maven Cache"},
+	}
+}
+
+// DeepCacheCandidates returns extra Python cache locations
+func (p *PythonProvider) DeepCacheCandidates() []core.CacheCandidate {
+	return []core.CacheCandidate{
+		{Pattern: "$XDG_CACHE_HOME/pypoetry", Description: "Poetry Cache"},
+		{Pattern: "~/Library/Caches/pypoetry", Description: "Poetry Cache"},
+		{Pattern: "$XDG_CACHE_HOME/pipenv", Description: "Pipenv Cache"},
+		{Pattern: "$XDG_CACHE_HOME/uv", Description: "uv Cache"},
+		{Pattern: "$XDG_CACHE_HOME/pre-commit", Description: "pre-commit Environments"},
+		{Pattern: "$XDG_DATA_HOME/virtualenv", Description: "virtualenv Seeds"},
+		{Pattern: "$TMPDIR/pip-*", Description: "Temporary pip Dirs"},
+		{Pattern: "/var/cache/pip", Description: "System pip Cache"},
+	}
+}
+
+// DeepCacheCandidates returns extra PHP cache locations
+func (p *PHPProvider) DeepCacheCandidates() []core.CacheCandidate {
+	return []core.CacheCandidate{
+		{Pattern: "$XDG_CACHE_HOME/composer", Description: "Composer Cache (XDG)"},
+		{Pattern: "~/Library/Caches/composer", Description: "Composer Cache"},
+		{Pattern: "$TMPDIR/phpstan", Description: "PHPStan Cache"},
+		{Pattern: "/var/cache/composer", Description: "System Composer Cache"},
+	}
+}
+
+// DeepCacheCandidates returns extra Rust cache locations
+func (p *RustProvider) DeepCacheCandidates() []core.CacheCandidate {
+	return []core.CacheCandidate{
+		{Pattern: "$XDG_CACHE_HOME/sccache", Description: "sccache Cache"},
+		{Pattern: "~/Library/Caches/Mozilla.sccache", Description: "sccache Cache"},
+		{Pattern: "$XDG_CACHE_HOME/cargo-binstall", Description: "cargo-binstall Cache"},
+		{Pattern: "$TMPDIR/rustc*", Description: "Temporary rustc Dirs"},
+	}
+}
@@ -0,0 +1,189 @@
+package providers
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// DenoProvider implements the LanguageProvider interface for Deno
+type DenoProvider struct{}
+
+// NewDenoProvider creates a new Deno provider
+func NewDenoProvider() *DenoProvider {
+	return &DenoProvider{}
+}
+
+// Name returns the name of the language
+func (p *DenoProvider) Name() string {
+	return "Deno"
+}
+
+// DetectInstalled detects the installed Deno version
+func (p *DenoProvider) DetectInstalled(ctx context.Context) ([]core.Installation, error) {
+	// Check if deno is installed
+	denoPath, err := scanner.FindExecutable("deno")
+	if err != nil {
+		return nil, notInstalledError("deno not found in PATH")
+	}
+
+	// Resolve symlinks
+	realPath, err := scanner.ResolveSymlink(denoPath)
+	if err != nil {
+		realPath = denoPath
+	}
+
+	// Get version
+	version, err := scanner.GetExecutableVersion(ctx, "deno", "--version")
+	if err != nil {
+		return nil, versionError("deno", err)
+	}
+
+	// Determine source
+	source, sourceReason := p.determineSource(realPath)
+
+	installation := core.Installation{
+		Version:      p.parseVersion(version),
+		Source:       source,
+		BinaryPath:   denoPath,
+		ManagerPath:  p.getManagerPath(realPath, source),
+		ManagerName:  p.getManagerName(source),
+		Arch:         scanner.HomebrewArch(realPath),
+		SourceReason: sourceReason,
+	}
+
+	return []core.Installation{installation}, nil
+}
+
+// parseVersion extracts version from deno --version output
+func (p *DenoProvider) parseVersion(output string) string {
+	// Example: "deno 1.40.2 (release, aarch64-apple-darwin)\nv8 12.1.285.6\ntypescript 5.3.3"
+	firstLine, _, _ := strings.Cut(output, "\n")
+	parts := strings.Fields(firstLine)
+	if len(parts) >= 2 && parts[0] == "deno" {
+		return parts[1]
+	}
+	return "unknown"
+}
+
+// BinaryName returns the executable used to detect the language
+func (p *DenoProvider) BinaryName() string {
+	return "deno"
+}
+
+// ClassifySource classifies the install source of any resolved binary path
+func (p *DenoProvider) ClassifySource(realPath string) (core.InstallSource, string) {
+	return p.determineSource(realPath)
+}
+
+// determineSource determines the installation source based on path.
+// The official install script puts deno in ~/.deno/bin.
+func (p *DenoProvider) determineSource(path string) (core.InstallSource, string) {
+	rules := []sourceRule{{".dvm", core.SourceVersionManager}, {".deno", core.SourceManual}}
+	rules = append(rules, homebrewRules...)
+	return classifySource(path, rules)
+}
+
+// getManagerName returns the specific version manager name
+func (p *DenoProvider) getManagerName(source core.InstallSource) string {
+	if source == core.SourceVersionManager {
+		return "dvm"
+	}
+	return ""
+}
+
+// getManagerPath extracts the manager path if applicable
+func (p *DenoProvider) getManagerPath(path string, source core.InstallSource) string {
+	if source == core.SourceVersionManager {
+		if idx := strings.Index(path, ".dvm"); idx != -1 {
+			return path[:idx+4]
+		}
+	}
+	return ""
+}
+
+// denoDir returns the Deno cache directory, honoring DENO_DIR
+func (p *DenoProvider) denoDir() string {
+	if dir, ok := scanner.ConfiguredPath("deno", "deno_dir"); ok {
+		return dir
+	}
+	if dir := scanner.GetEnvVar("DENO_DIR"); dir != "" {
+		return dir
+	}
+	return platformCacheDir(runtime.GOOS, "deno", "")
+}
+
+// denoCacheDirs returns the parts of DENO_DIR reported separately: remote modules
+// (deps/ in older releases), npm packages of the npm: compatibility layer, and emitted JavaScript
+func (p *DenoProvider) denoCacheDirs() []toolDir {
+	dir := p.denoDir()
+	return []toolDir{
+		{Description: "Remote Module Cache", Path: filepath.Join(dir, "remote")},
+		{Description: "Remote Module Cache (legacy deps)", Path: filepath.Join(dir, "deps")},
+		{Description: "npm Cache", Path: filepath.Join(dir, "npm")},
+		{Description: "Compiled Cache (gen)", Path: filepath.Join(dir, "gen")},
+	}
+}
+
+// GetGlobalCacheUsage calculates disk usage for the Deno cache
+func (p *DenoProvider) GetGlobalCacheUsage(ctx context.Context) (*core.DiskUsage, error) {
+	var items []core.DiskUsageItem
+
+	for _, dir := range p.denoCacheDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.DiskUsageItem{
+				Path:        dir.Path,
+				Description: dir.Description,
+				Size:        size,
+			})
+		}
+	}
+
+	return newDiskUsage(items), nil
+}
+
+// GetEnvVars returns relevant environment variables
+func (p *DenoProvider) GetEnvVars(ctx context.Context) map[string]string {
+	vars := make(map[string]string)
+
+	envVars := []string{"DENO_DIR", "DENO_INSTALL", "DENO_INSTALL_ROOT"}
+	for _, name := range envVars {
+		if value := scanner.GetEnvVar(name); value != "" {
+			vars[name] = value
+		}
+	}
+
+	return vars
+}
+
+// GetCleanableItems returns items that can be cleaned for Deno
+func (p *DenoProvider) GetCleanableItems(ctx context.Context) ([]core.CleanableItem, error) {
+	var items []core.CleanableItem
+
+	// Every part is re-downloaded or re-emitted on the next run (safe)
+	for _, dir := range p.denoCacheDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.CleanableItem{
+				Path:        dir.Path,
+				Description: "Deno " + dir.Description,
+				Size:        size,
+				Safe:        true,
+				Reclaim:     core.ReclaimRegenerable,
+				RebuildCost: "re-downloads or recompiles modules on the next deno run",
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// Clean executes cleaning for Deno
+func (p *DenoProvider) Clean(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	return cleanItems(ctx, items, dryRun, cleanItem), nil
+}
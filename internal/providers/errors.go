@@ -0,0 +1,27 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// notInstalledError reports that none of a language's binaries is on PATH
+func notInstalledError(message string) error {
+	return core.NewProviderError(core.NotInstalled, errors.New(message))
+}
+
+// versionError reports that the version command of a binary on PATH failed, classifying why
+func versionError(binary string, err error) error {
+	kind := core.Unusable
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		kind = core.PermissionDenied
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		kind = core.Timeout
+	}
+	return core.NewProviderError(kind, fmt.Errorf("failed to get %s version: %w", binary, err))
+}
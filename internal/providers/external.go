@@ -0,0 +1,322 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// ExternalProviderPrefix is the executable name prefix of external providers on PATH
+const ExternalProviderPrefix = "dhell-provider-"
+
+// External provider protocol
+//
+// dhell runs the executable with the method name as its only argument, writes the
+// request as JSON to stdin (an empty object when the method takes no arguments) and
+// reads a single JSON response from stdout:
+//
+//	{"result": <method result>, "error": "optional message"}
+//
+// Methods and their results:
+//
+//	name                  "Elm"
+//	detect-installed      [externalInstallation, ...]
+//	global-cache-usage    externalDiskUsage
+//	env-vars              {"ELM_HOME": "..."}
+//	cleanable-items       [externalCleanableItem, ...]
+//	clean                 externalCleanResult; request: {"items": [...], "dry_run": bool}
+//
+// A non-empty "error" or a non-zero exit status fails the call.
+
+// externalResponse is the envelope of every external provider response
+type externalResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// externalInstallation is the wire form of core.Installation
+type externalInstallation struct {
+	Version      string `json:"version"`
+	Source       string `json:"source"` // One of the core.InstallSource values, e.g. "Version Manager"
+	BinaryPath   string `json:"binary_path"`
+	ManagerPath  string `json:"manager_path,omitempty"`
+	ManagerName  string `json:"manager_name,omitempty"`
+	Distribution string `json:"distribution,omitempty"`
+	SourceReason string `json:"source_reason,omitempty"`
+}
+
+// externalDiskUsageItem is the wire form of core.DiskUsageItem
+type externalDiskUsageItem struct {
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	Size        *int64 `json:"size,omitempty"` // Measured by dhell when omitted
+}
+
+// externalDiskUsage is the wire form of core.DiskUsage
+type externalDiskUsage struct {
+	Items []externalDiskUsageItem `json:"items"`
+}
+
+// externalCleanableItem is the wire form of core.CleanableItem
+type externalCleanableItem struct {
+	Path        string `json:"path,omitempty"`
+	Description string `json:"description"`
+	Size        int64  `json:"size"`
+	Command     string `json:"command,omitempty"`
+	Safe        bool   `json:"safe"`
+	UpperBound  bool   `json:"upper_bound,omitempty"` // Older form of "reclaim": "upper_bound"
+	Reclaim     string `json:"reclaim,omitempty"`     // "exact", "upper_bound" or "regenerable"
+	RebuildCost string `json:"rebuild_cost,omitempty"`
+}
+
+// reclaimKind converts the wire reclaim fields to a core.ReclaimKind
+func (item externalCleanableItem) reclaimKind() core.ReclaimKind {
+	switch item.Reclaim {
+	case "exact":
+		return core.ReclaimExact
+	case "upper_bound":
+		return core.ReclaimUpperBound
+	case "regenerable":
+		return core.ReclaimRegenerable
+	case "":
+		if item.UpperBound {
+			return core.ReclaimUpperBound
+		}
+	}
+	return core.ReclaimUnknown
+}
+
+// externalCleanRequest is the request sent with the clean method
+type externalCleanRequest struct {
+	Items  []externalCleanableItem `json:"items"`
+	DryRun bool                    `json:"dry_run"`
+}
+
+// externalCleanResult is the wire form of core.CleanResult
+type externalCleanResult struct {
+	ItemsCleaned   int      `json:"items_cleaned"`
+	SpaceReclaimed int64    `json:"space_reclaimed"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+// ExternalProvider implements the LanguageProvider interface by calling an external executable
+type ExternalProvider struct {
+	path string
+
+	nameOnce sync.Once
+	name     string
+}
+
+// NewExternalProvider creates a provider backed by the executable at path
+func NewExternalProvider(path string) *ExternalProvider {
+	return &ExternalProvider{path: path}
+}
+
+// DiscoverExternalProviders finds dhell-provider-* executables on PATH.
+// When the same name appears in several PATH directories, the first one wins.
+func DiscoverExternalProviders() []*ExternalProvider {
+	var found []*ExternalProvider
+	seen := make(map[string]bool)
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(dir, ExternalProviderPrefix+"*"))
+		if err != nil {
+			continue
+		}
+		sort.Strings(matches)
+
+		for _, match := range matches {
+			base := filepath.Base(match)
+			info, err := os.Stat(match)
+			if err != nil || info.IsDir() || info.Mode()&0111 == 0 || seen[base] {
+				continue
+			}
+			seen[base] = true
+			found = append(found, NewExternalProvider(match))
+		}
+	}
+
+	return found
+}
+
+// Name returns the name reported by the executable, or its name suffix if that fails.
+// The name is asked for once, outside any command's deadline.
+func (p *ExternalProvider) Name() string {
+	p.nameOnce.Do(func() {
+		var name string
+		if err := p.call(context.Background(), "name", nil, &name); err != nil || name == "" {
+			name = strings.TrimPrefix(filepath.Base(p.path), ExternalProviderPrefix)
+		}
+		p.name = name
+	})
+	return p.name
+}
+
+// DetectInstalled detects installations through the external executable
+func (p *ExternalProvider) DetectInstalled(ctx context.Context) ([]core.Installation, error) {
+	var wire []externalInstallation
+	if err := p.call(ctx, "detect-installed", nil, &wire); err != nil {
+		return nil, err
+	}
+
+	var installations []core.Installation
+	for _, inst := range wire {
+		source := core.InstallSource(inst.Source)
+		if source == "" {
+			source = core.SourceUnknown
+		}
+		installations = append(installations, core.Installation{
+			Version:      inst.Version,
+			Source:       source,
+			BinaryPath:   inst.BinaryPath,
+			ManagerPath:  inst.ManagerPath,
+			ManagerName:  inst.ManagerName,
+			Distribution: inst.Distribution,
+			SourceReason: inst.SourceReason,
+		})
+	}
+
+	return installations, nil
+}
+
+// GetGlobalCacheUsage gets cache locations from the external executable.
+// Items without a size are measured by dhell.
+func (p *ExternalProvider) GetGlobalCacheUsage(ctx context.Context) (*core.DiskUsage, error) {
+	var wire externalDiskUsage
+	if err := p.call(ctx, "global-cache-usage", nil, &wire); err != nil {
+		return nil, err
+	}
+
+	var items []core.DiskUsageItem
+	for _, item := range wire.Items {
+		var size int64
+		if item.Size != nil {
+			size = *item.Size
+		} else {
+			size, _ = scanner.CalculateDirSize(ctx, item.Path)
+		}
+
+		items = append(items, core.DiskUsageItem{
+			Path:        item.Path,
+			Description: item.Description,
+			Size:        size,
+		})
+	}
+
+	return newDiskUsage(items), nil
+}
+
+// GetEnvVars returns the environment variables reported by the external executable
+func (p *ExternalProvider) GetEnvVars(ctx context.Context) map[string]string {
+	vars := make(map[string]string)
+	if err := p.call(ctx, "env-vars", nil, &vars); err != nil {
+		return map[string]string{}
+	}
+	return vars
+}
+
+// GetCleanableItems gets cleanable items from the external executable
+func (p *ExternalProvider) GetCleanableItems(ctx context.Context) ([]core.CleanableItem, error) {
+	var wire []externalCleanableItem
+	if err := p.call(ctx, "cleanable-items", nil, &wire); err != nil {
+		return nil, err
+	}
+
+	var items []core.CleanableItem
+	for _, item := range wire {
+		items = append(items, core.CleanableItem{
+			Path:        item.Path,
+			Description: item.Description,
+			Size:        item.Size,
+			Command:     item.Command,
+			Safe:        item.Safe,
+			Reclaim:     item.reclaimKind(),
+			RebuildCost: item.RebuildCost,
+		})
+	}
+
+	return items, nil
+}
+
+// Clean asks the external executable to clean the items
+func (p *ExternalProvider) Clean(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	request := externalCleanRequest{DryRun: dryRun}
+	for _, item := range items {
+		request.Items = append(request.Items, externalCleanableItem{
+			Path:        item.Path,
+			Description: item.Description,
+			Size:        item.Size,
+			Command:     item.Command,
+			Safe:        item.Safe,
+			UpperBound:  item.Reclaim == core.ReclaimUpperBound,
+			Reclaim:     item.Reclaim.String(),
+			RebuildCost: item.RebuildCost,
+		})
+	}
+
+	var wire externalCleanResult
+	if err := p.call(ctx, "clean", request, &wire); err != nil {
+		return nil, err
+	}
+
+	// The protocol only reports counts, so the result lists every requested item
+	result := &core.CleanResult{
+		ItemsCleaned:   wire.ItemsCleaned,
+		SpaceReclaimed: wire.SpaceReclaimed,
+		Errors:         []error{},
+		DryRun:         dryRun,
+		Items:          items,
+	}
+	for _, message := range wire.Errors {
+		result.Errors = append(result.Errors, errors.New(message))
+	}
+
+	return result, nil
+}
+
+// call runs a protocol method and decodes its result into out
+func (p *ExternalProvider) call(ctx context.Context, method string, request interface{}, out interface{}) error {
+	if request == nil {
+		request = struct{}{}
+	}
+	input, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	output, err := scanner.CommandOutputWithInput(ctx, input, p.path, method)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return fmt.Errorf("%s %s: %s", filepath.Base(p.path), method, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return fmt.Errorf("%s %s: %w", filepath.Base(p.path), method, err)
+	}
+
+	var response externalResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return fmt.Errorf("%s %s: invalid response: %w", filepath.Base(p.path), method, err)
+	}
+	if response.Error != "" {
+		return fmt.Errorf("%s %s: %s", filepath.Base(p.path), method, response.Error)
+	}
+	if len(response.Result) == 0 {
+		return fmt.Errorf("%s %s: empty result", filepath.Base(p.path), method)
+	}
+
+	return json.Unmarshal(response.Result, out)
+}
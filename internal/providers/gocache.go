@@ -0,0 +1,144 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// goCacheKind is a kind of GOCACHE output, recognized by the first bytes of the file
+type goCacheKind struct {
+	Description string
+	Magics      [][]byte
+}
+
+// goCacheKinds are checked in order; outputs matching none are counted as vet results and others.
+// GOCACHE doesn't record which action produced an output, so this is an estimate from file headers.
+var goCacheKinds = []goCacheKind{
+	{"Compiled Packages", [][]byte{[]byte("!<arch>\n")}},
+	{"Linked Binaries (go run, go test)", [][]byte{
+		[]byte("\x7fELF"),
+		{0xcf, 0xfa, 0xed, 0xfe}, // Mach-O 64-bit
+		{0xca, 0xfe, 0xba, 0xbe}, // Mach-O universal
+		[]byte("MZ"),             // PE
+	}},
+	{"Test Results", [][]byte{[]byte("PASS"), []byte("=== RUN")}},
+}
+
+// goCacheOtherDescription covers vet facts and anything else the headers don't identify
+const goCacheOtherDescription = "Vet Results and Other Outputs (estimate)"
+
+// goCacheIndexDescription covers the action entries (-a files) that point at outputs
+const goCacheIndexDescription = "Action Index"
+
+// goCacheHeaderSize is enough bytes to tell the kinds apart
+const goCacheHeaderSize = 8
+
+// GetBreakdown splits the build cache into compiled packages, linked binaries, test results
+// and the rest, which is mostly go vet's analysis results
+func (p *GoProvider) GetBreakdown(ctx context.Context) []core.InfoSection {
+	gocache := p.getGoEnv(ctx, "GOCACHE")
+	if gocache == "" {
+		return nil
+	}
+
+	sizes := goCacheBreakdown(gocache)
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	section := core.InfoSection{Title: "Build Cache Breakdown (from file headers)"}
+	var descriptions []string
+	for _, kind := range goCacheKinds {
+		descriptions = append(descriptions, kind.Description)
+	}
+	descriptions = append(descriptions, goCacheOtherDescription, goCacheIndexDescription)
+	for _, description := range descriptions {
+		if size := sizes[description]; size > 0 {
+			section.Items = append(section.Items, core.DiskUsageItem{
+				Path:        gocache,
+				Description: description,
+				Size:        size,
+			})
+		}
+	}
+
+	return []core.InfoSection{section}
+}
+
+// goCacheBreakdown sums the GOCACHE entries per kind. Only the two-hex-digit entry
+// directories are read, so the fuzz corpus and the trim marker are left out.
+func goCacheBreakdown(gocache string) map[string]int64 {
+	sizes := make(map[string]int64)
+
+	dirs, err := filepath.Glob(filepath.Join(gocache, "[0-9a-f][0-9a-f]"))
+	if err != nil {
+		return sizes
+	}
+
+	header := make([]byte, goCacheHeaderSize)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			switch {
+			case strings.HasSuffix(entry.Name(), "-a"):
+				if info, err := entry.Info(); err == nil {
+					sizes[goCacheIndexDescription] += info.Size()
+				}
+			case strings.HasSuffix(entry.Name(), "-d") && entry.IsDir():
+				// Newer Go versions keep linked executables as <id>-d/<name>
+				files, _ := os.ReadDir(path)
+				for _, file := range files {
+					addGoCacheOutput(sizes, filepath.Join(path, file.Name()), file, header)
+				}
+			case strings.HasSuffix(entry.Name(), "-d"):
+				addGoCacheOutput(sizes, path, entry, header)
+			}
+		}
+	}
+
+	return sizes
+}
+
+// addGoCacheOutput adds the size of one output file to the total of its kind
+func addGoCacheOutput(sizes map[string]int64, path string, entry os.DirEntry, header []byte) {
+	info, err := entry.Info()
+	if err != nil || !info.Mode().IsRegular() {
+		return
+	}
+	n := readHeader(path, header)
+	sizes[goCacheKindOf(header[:n])] += info.Size()
+}
+
+// readHeader reads up to len(buf) bytes from the start of a file, returning how many it read
+func readHeader(path string, buf []byte) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	n, _ := io.ReadFull(file, buf)
+	return n
+}
+
+// goCacheKindOf returns the description of the kind whose magic starts header
+func goCacheKindOf(header []byte) string {
+	for _, kind := range goCacheKinds {
+		for _, magic := range kind.Magics {
+			if bytes.HasPrefix(header, magic) {
+				return kind.Description
+			}
+		}
+	}
+	return goCacheOtherDescription
+}
@@ -1,9 +1,11 @@
 package providers
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	"dependency-hell-cli/internal/core"
@@ -24,11 +26,11 @@ func (p *GoProvider) Name() string {
 }
 
 // DetectInstalled detects installed Go versions
-func (p *GoProvider) DetectInstalled() ([]core.Installation, error) {
+func (p *GoProvider) DetectInstalled(ctx context.Context) ([]core.Installation, error) {
 	// Check if go is installed
 	goPath, err := scanner.FindExecutable("go")
 	if err != nil {
-		return nil, fmt.Errorf("go not found in PATH")
+		return nil, notInstalledError("go not found in PATH")
 	}
 
 	// Resolve symlinks to get actual path
@@ -38,76 +40,202 @@ func (p *GoProvider) DetectInstalled() ([]core.Installation, error) {
 	}
 
 	// Get version
-	version, err := scanner.GetExecutableVersion("go", "version")
+	version, err := scanner.GetExecutableVersion(ctx, "go", "version")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get go version: %w", err)
+		return nil, versionError("go", err)
 	}
 
-	// Parse version (e.g., "go version go1.21.3 darwin/arm64")
-	parts := strings.Fields(version)
-	versionStr := "unknown"
-	if len(parts) >= 3 {
-		versionStr = strings.TrimPrefix(parts[2], "go")
-	}
+	versionStr := p.parseVersion(version)
 
 	// Determine source
-	source := p.determineSource(realPath)
+	source, sourceReason := p.determineSource(realPath)
 	managerName := p.getManagerName(realPath, source)
 
 	installation := core.Installation{
-		Version:     versionStr,
-		Source:      source,
-		BinaryPath:  goPath,
-		ManagerPath: p.getManagerPath(realPath, source),
-		ManagerName: managerName,
+		Version:      versionStr,
+		Source:       source,
+		BinaryPath:   goPath,
+		ManagerPath:  p.getManagerPath(realPath, source),
+		ManagerName:  managerName,
+		Arch:         scanner.HomebrewArch(realPath),
+		SourceReason: sourceReason,
+	}
+
+	installations := []core.Installation{installation}
+
+	// Other versions installed by goenv
+	goenvRoot := scanner.ExpandHome(p.goenvRoot())
+	for _, version := range p.listGoenvVersions() {
+		if version == versionStr {
+			continue
+		}
+		installations = append(installations, core.Installation{
+			Version:      version,
+			Source:       core.SourceVersionManager,
+			BinaryPath:   filepath.Join(goenvRoot, "versions", version, "bin", "go"),
+			ManagerPath:  goenvRoot,
+			ManagerName:  "goenv",
+			SourceReason: fmt.Sprintf("installed under %s", filepath.Join(goenvRoot, "versions")),
+		})
+	}
+
+	// Homebrew and manual installs that PATH doesn't resolve to are still on disk
+	installations = append(installations, p.detectShadowedInstalls(ctx, realPath)...)
+
+	return installations, nil
+}
+
+// parseVersion extracts the version from go version output (e.g., "go version go1.21.3 darwin/arm64")
+func (p *GoProvider) parseVersion(output string) string {
+	parts := strings.Fields(output)
+	if len(parts) >= 3 {
+		return strings.TrimPrefix(parts[2], "go")
+	}
+	return "unknown"
+}
+
+// standardGoLocations lists the go binaries of the Homebrew prefixes and the official installer
+func (p *GoProvider) standardGoLocations() []string {
+	installs := scanner.FindHomebrewInstalls("go")
+
+	var locations []string
+	for _, arch := range []string{"arm64", "x86_64"} {
+		if realPath, ok := installs[arch]; ok {
+			locations = append(locations, realPath)
+		}
+	}
+	return append(locations, "/usr/local/go/bin/go")
+}
+
+// detectShadowedInstalls finds Go in the standard Homebrew and manual locations other than
+// activeRealPath, the go that PATH resolves to
+func (p *GoProvider) detectShadowedInstalls(ctx context.Context, activeRealPath string) []core.Installation {
+	var installations []core.Installation
+
+	for _, location := range p.standardGoLocations() {
+		realPath, err := scanner.ResolveSymlink(location)
+		if err != nil || scanner.SamePath(realPath, activeRealPath) {
+			continue
+		}
+
+		versionStr := "unknown"
+		if output, err := scanner.GetExecutableVersion(ctx, realPath, "version"); err == nil {
+			versionStr = p.parseVersion(output)
+		}
+
+		source, sourceReason := p.determineSource(realPath)
+		installations = append(installations, core.Installation{
+			Version:      versionStr,
+			Source:       source,
+			BinaryPath:   realPath,
+			Arch:         scanner.HomebrewArch(realPath),
+			SourceReason: sourceReason + ", not the go on PATH",
+		})
+	}
+
+	return installations
+}
+
+// goenvRoot returns the goenv root directory, honoring GOENV_ROOT
+func (p *GoProvider) goenvRoot() string {
+	if root, ok := scanner.ConfiguredPath("go", "goenv_root"); ok {
+		return root
 	}
+	if root := scanner.GetEnvVar("GOENV_ROOT"); root != "" {
+		return root
+	}
+	return "~/.goenv"
+}
 
-	return []core.Installation{installation}, nil
+// goenvVersionsDir returns the directory holding goenv-installed Go versions
+func (p *GoProvider) goenvVersionsDir() string {
+	return filepath.Join(p.goenvRoot(), "versions")
+}
+
+// listGoenvVersions lists the Go versions installed by goenv
+func (p *GoProvider) listGoenvVersions() []string {
+	var versions []string
+
+	entries, err := os.ReadDir(scanner.ExpandHome(p.goenvVersionsDir()))
+	if err != nil {
+		return versions
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
+	}
+
+	return versions
 }
 
 // getManagerName returns the specific version manager name
 func (p *GoProvider) getManagerName(path string, source core.InstallSource) string {
 	if source == core.SourceVersionManager {
-		if strings.Contains(path, ".goenv") {
+		if strings.Contains(path, ".goenv") || strings.HasPrefix(path, scanner.ExpandHome(p.goenvRoot())) {
 			return "goenv"
 		}
 	}
 	return ""
 }
 
+// BinaryName returns the executable used to detect the language
+func (p *GoProvider) BinaryName() string {
+	return "go"
+}
+
+// ClassifySource classifies the install source of any resolved binary path
+func (p *GoProvider) ClassifySource(realPath string) (core.InstallSource, string) {
+	return p.determineSource(realPath)
+}
+
 // determineSource determines the installation source based on path
-func (p *GoProvider) determineSource(path string) core.InstallSource {
-	if strings.Contains(path, ".goenv") {
-		return core.SourceVersionManager
-	}
-	if strings.Contains(path, "/opt/homebrew") || strings.Contains(path, "/usr/local/Cellar") {
-		return core.SourceHomebrew
-	}
-	if strings.Contains(path, "/usr/local/go") {
-		return core.SourceManual
+func (p *GoProvider) determineSource(path string) (core.InstallSource, string) {
+	// The goenv root wherever it was configured, then the default one by name
+	rules := []sourceRule{
+		{scanner.ExpandHome(p.goenvRoot()), core.SourceVersionManager},
+		{".goenv", core.SourceVersionManager},
 	}
-	return core.SourceUnknown
+	rules = append(rules, homebrewRules...)
+	rules = append(rules, sourceRule{"/usr/local/go", core.SourceManual})
+	return classifySource(path, rules)
 }
 
 // getManagerPath extracts the manager path if applicable
 func (p *GoProvider) getManagerPath(path string, source core.InstallSource) string {
-	if source == core.SourceVersionManager && strings.Contains(path, ".goenv") {
-		// Extract .goenv path
-		if idx := strings.Index(path, ".goenv"); idx != -1 {
-			return path[:idx+6] // Include ".goenv"
-		}
+	if source != core.SourceVersionManager {
+		return ""
+	}
+	if root := scanner.ExpandHome(p.goenvRoot()); strings.HasPrefix(path, root+string(filepath.Separator)) {
+		return root
+	}
+	// Extract .goenv path
+	if idx := strings.Index(path, ".goenv"); idx != -1 {
+		return path[:idx+6] // Include ".goenv"
 	}
 	return ""
 }
 
 // GetGlobalCacheUsage calculates disk usage for Go caches
-func (p *GoProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
+func (p *GoProvider) GetGlobalCacheUsage(ctx context.Context) (*core.DiskUsage, error) {
 	var items []core.DiskUsageItem
 
-	// Get GOROOT (SDK)
-	goroot := p.getGoEnv("GOROOT")
-	if goroot != "" && scanner.PathExists(goroot) {
-		size, _ := scanner.CalculateDirSize(goroot)
+	// goenv versions (includes the active SDK when managed by goenv)
+	goenvVersions := p.goenvVersionsDir()
+	if scanner.PathExists(goenvVersions) {
+		size, _ := scanner.CalculateDirSize(ctx, goenvVersions)
+		items = append(items, core.DiskUsageItem{
+			Path:        goenvVersions,
+			Description: "Goenv Versions",
+			Size:        size,
+		})
+	}
+
+	// Get GOROOT (SDK), unless already counted as a goenv version
+	goroot := p.getGoEnv(ctx, "GOROOT")
+	if goroot != "" && scanner.PathExists(goroot) && !strings.HasPrefix(goroot, scanner.ExpandHome(goenvVersions)) {
+		size, _ := scanner.CalculateDirSize(ctx, goroot)
 		items = append(items, core.DiskUsageItem{
 			Path:        goroot,
 			Description: "SDK",
@@ -115,28 +243,36 @@ func (p *GoProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Get GOCACHE (Build cache)
-	gocache := p.getGoEnv("GOCACHE")
+	// Get GOCACHE (Build cache), reporting the fuzz corpus inside it separately
+	gocache := p.getGoEnv(ctx, "GOCACHE")
 	if gocache != "" && scanner.PathExists(gocache) {
-		size, _ := scanner.CalculateDirSize(gocache)
+		size, _ := scanner.CalculateDirSize(ctx, gocache)
+		fuzzSize, _ := scanner.CalculateDirSize(ctx, fuzzCacheDir(gocache))
 		items = append(items, core.DiskUsageItem{
 			Path:        gocache,
 			Description: "Build Cache",
-			Size:        size,
+			Size:        size - fuzzSize,
 		})
+		if fuzzSize > 0 {
+			items = append(items, core.DiskUsageItem{
+				Path:        fuzzCacheDir(gocache),
+				Description: "Fuzz Cache",
+				Size:        fuzzSize,
+			})
+		}
 	}
 
 	// Get GOMODCACHE (Module cache - the big one!)
-	gomodcache := p.getGoEnv("GOMODCACHE")
+	gomodcache := p.getGoEnv(ctx, "GOMODCACHE")
 	if gomodcache == "" {
 		// Fallback to GOPATH/pkg/mod
-		gopath := p.getGoEnv("GOPATH")
+		gopath := p.getGoEnv(ctx, "GOPATH")
 		if gopath != "" {
 			gomodcache = gopath + "/pkg/mod"
 		}
 	}
 	if gomodcache != "" && scanner.PathExists(gomodcache) {
-		size, _ := scanner.CalculateDirSize(gomodcache)
+		size, _ := scanner.CalculateDirSize(ctx, gomodcache)
 		items = append(items, core.DiskUsageItem{
 			Path:        gomodcache,
 			Description: "Module Cache",
@@ -144,25 +280,33 @@ func (p *GoProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Calculate total
-	var total int64
-	for _, item := range items {
-		total += item.Size
+	// Dev tool caches (linters keep their analysis results outside GOCACHE)
+	for _, cache := range p.toolCaches() {
+		size, _ := scanner.CalculateDirSize(ctx, cache.Path)
+		items = append(items, core.DiskUsageItem{
+			Path:        cache.Path,
+			Description: cache.Description,
+			Size:        size,
+		})
 	}
 
-	return &core.DiskUsage{
-		Items: items,
-		Total: total,
-	}, nil
+	return newDiskUsage(items), nil
 }
 
 // GetEnvVars returns relevant environment variables
-func (p *GoProvider) GetEnvVars() map[string]string {
+func (p *GoProvider) GetEnvVars(ctx context.Context) map[string]string {
 	vars := make(map[string]string)
 
-	envVarNames := []string{"GOROOT", "GOPATH", "GOCACHE", "GOMODCACHE"}
+	envVarNames := []string{"GOROOT", "GOPATH", "GOBIN", "GOCACHE", "GOMODCACHE"}
 	for _, name := range envVarNames {
-		if value := p.getGoEnv(name); value != "" {
+		if value := p.getGoEnv(ctx, name); value != "" {
+			vars[name] = value
+		}
+	}
+
+	// goenv and tool settings are not known to go env
+	for _, name := range []string{"GOENV_ROOT", "GOLANGCI_LINT_CACHE", "STATICCHECK_CACHE"} {
+		if value := scanner.GetEnvVar(name); value != "" {
 			vars[name] = value
 		}
 	}
@@ -170,10 +314,107 @@ func (p *GoProvider) GetEnvVars() map[string]string {
 	return vars
 }
 
+// GoBinDirs holds the directories go install may have written binaries to
+type GoBinDirs struct {
+	GOBIN     string // Empty when GOBIN is unset
+	GopathBin string // bin directory of the first GOPATH entry
+}
+
+// BinDirs resolves GOBIN and the default $GOPATH/bin install directory
+func (p *GoProvider) BinDirs(ctx context.Context) GoBinDirs {
+	dirs := GoBinDirs{GOBIN: p.getGoEnv(ctx, "GOBIN")}
+
+	// go install uses the first GOPATH entry when GOBIN is unset
+	if gopaths := filepath.SplitList(p.getGoEnv(ctx, "GOPATH")); len(gopaths) > 0 && gopaths[0] != "" {
+		dirs.GopathBin = filepath.Join(gopaths[0], "bin")
+	}
+
+	return dirs
+}
+
+// OverlappingBinaries lists executable names present in both GOBIN and $GOPATH/bin
+func (d GoBinDirs) OverlappingBinaries() []string {
+	if d.GOBIN == "" || d.GopathBin == "" || scanner.SamePath(d.GOBIN, d.GopathBin) {
+		return nil
+	}
+
+	inGopath := make(map[string]bool)
+	for _, name := range listExecutables(d.GopathBin) {
+		inGopath[name] = true
+	}
+
+	var overlap []string
+	for _, name := range listExecutables(d.GOBIN) {
+		if inGopath[name] {
+			overlap = append(overlap, name)
+		}
+	}
+
+	return overlap
+}
+
+// listExecutables lists the names of regular files in a directory
+func listExecutables(dir string) []string {
+	var names []string
+
+	entries, err := os.ReadDir(scanner.ExpandHome(dir))
+	if err != nil {
+		return names
+	}
+
+	for _, entry := range entries {
+		if entry.Type().IsRegular() || entry.Type()&os.ModeSymlink != 0 {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names
+}
+
+// toolCaches returns the caches of common Go linters, honoring their cache overrides.
+// Like os.UserCacheDir, which the tools use, they live in the platform's user cache directory.
+func (p *GoProvider) toolCaches() []toolDir {
+	userCache := scanner.XDGCacheHome()
+	switch runtime.GOOS {
+	case "darwin":
+		userCache = "~/Library/Caches"
+	case "windows":
+		if localAppData := scanner.GetEnvVar("LOCALAPPDATA"); localAppData != "" {
+			userCache = localAppData
+		}
+	}
+
+	tools := []struct {
+		description string
+		name        string
+		envVar      string
+	}{
+		{"golangci-lint Cache", "golangci-lint", "GOLANGCI_LINT_CACHE"},
+		{"staticcheck Cache", "staticcheck", "STATICCHECK_CACHE"},
+	}
+
+	var caches []toolDir
+	for _, tool := range tools {
+		path := filepath.Join(userCache, tool.name)
+		if override := scanner.GetEnvVar(tool.envVar); override != "" {
+			path = override
+		}
+		if scanner.PathExists(path) {
+			caches = append(caches, toolDir{Description: tool.description, Path: path})
+		}
+	}
+
+	return caches
+}
+
+// fuzzCacheDir returns the fuzzing corpus cache inside GOCACHE
+func fuzzCacheDir(gocache string) string {
+	return filepath.Join(gocache, "fuzz")
+}
+
 // getGoEnv gets a Go environment variable
-func (p *GoProvider) getGoEnv(name string) string {
-	cmd := exec.Command("go", "env", name)
-	output, err := cmd.Output()
+func (p *GoProvider) getGoEnv(ctx context.Context, name string) string {
+	output, err := scanner.CommandOutput(ctx, "go", "env", name)
 	if err != nil {
 		// Fallback to OS environment variable
 		return os.Getenv(name)
@@ -182,36 +423,66 @@ func (p *GoProvider) getGoEnv(name string) string {
 }
 
 // GetCleanableItems returns items that can be cleaned for Go
-func (p *GoProvider) GetCleanableItems() ([]core.CleanableItem, error) {
+func (p *GoProvider) GetCleanableItems(ctx context.Context) ([]core.CleanableItem, error) {
 	var items []core.CleanableItem
 
 	// Module cache - use go clean -modcache (safe)
-	gomodcache := p.getGoEnv("GOMODCACHE")
+	gomodcache := p.getGoEnv(ctx, "GOMODCACHE")
 	if gomodcache == "" {
-		gopath := p.getGoEnv("GOPATH")
+		gopath := p.getGoEnv(ctx, "GOPATH")
 		if gopath != "" {
 			gomodcache = gopath + "/pkg/mod"
 		}
 	}
 	if gomodcache != "" && scanner.PathExists(gomodcache) {
-		size, _ := scanner.CalculateDirSize(gomodcache)
+		size, _ := scanner.CalculateDirSize(ctx, gomodcache)
 		items = append(items, core.CleanableItem{
 			Description: "Go Module Cache",
 			Command:     "go clean -modcache",
 			Size:        size,
 			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads modules over the network on the next build",
 		})
 	}
 
-	// Build cache - use go clean -cache (safe)
-	gocache := p.getGoEnv("GOCACHE")
+	// Build cache - use go clean -cache (safe); it leaves the fuzz cache alone
+	gocache := p.getGoEnv(ctx, "GOCACHE")
 	if gocache != "" && scanner.PathExists(gocache) {
-		size, _ := scanner.CalculateDirSize(gocache)
+		size, _ := scanner.CalculateDirSize(ctx, gocache)
+		fuzzSize, _ := scanner.CalculateDirSize(ctx, fuzzCacheDir(gocache))
 		items = append(items, core.CleanableItem{
 			Description: "Go Build Cache",
 			Command:     "go clean -cache",
+			Size:        size - fuzzSize,
+			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "recompiles packages on the next build",
+		})
+
+		// Fuzz corpus cache - use go clean -fuzzcache (safe, regenerated by fuzzing)
+		if fuzzSize > 0 {
+			items = append(items, core.CleanableItem{
+				Description: "Go Fuzz Cache",
+				Command:     "go clean -fuzzcache",
+				Size:        fuzzSize,
+				Safe:        true,
+				Reclaim:     core.ReclaimRegenerable,
+				RebuildCost: "loses generated fuzz inputs; fuzzing starts over from the seed corpus",
+			})
+		}
+	}
+
+	// Dev tool caches (safe - rebuilt on the next lint run)
+	for _, cache := range p.toolCaches() {
+		size, _ := scanner.CalculateDirSize(ctx, cache.Path)
+		items = append(items, core.CleanableItem{
+			Path:        cache.Path,
+			Description: cache.Description,
 			Size:        size,
 			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "rebuilt on the next lint run",
 		})
 	}
 
@@ -219,26 +490,6 @@ func (p *GoProvider) GetCleanableItems() ([]core.CleanableItem, error) {
 }
 
 // Clean executes cleaning for Go
-func (p *GoProvider) Clean(items []core.CleanableItem) (*core.CleanResult, error) {
-	result := &core.CleanResult{
-		ItemsCleaned:   0,
-		SpaceReclaimed: 0,
-		Errors:         []error{},
-	}
-
-	for _, item := range items {
-		if item.Command != "" {
-			// Execute go clean command
-			cmd := exec.Command("sh", "-c", item.Command)
-			if err := cmd.Run(); err != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("failed to clean %s: %w", item.Description, err))
-				continue
-			}
-		}
-
-		result.ItemsCleaned++
-		result.SpaceReclaimed += item.Size
-	}
-
-	return result, nil
+func (p *GoProvider) Clean(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	return cleanItems(ctx, items, dryRun, cleanItem), nil
 }
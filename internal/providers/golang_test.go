@@ -0,0 +1,114 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+func TestGoProviderFixture(t *testing.T) {
+	env := newFakeEnv(t)
+	goBinary := env.addBinary("go", "sdk/go/bin/go")
+	env.setOutput("go version", "go version go1.22.3 linux/amd64")
+	env.setOutput("go env GOROOT", env.path("sdk/go"))
+	env.setOutput("go env GOCACHE", env.path(".cache/go-build"))
+	env.setOutput("go env GOMODCACHE", env.path("go/pkg/mod"))
+	env.writeFile("go/pkg/mod/cache/download/example.com/lib/@v/v1.0.0.zip", 4096)
+	env.writeFile(".cache/go-build/ab/ab12-d", 1024)
+
+	provider := NewGoProvider()
+
+	installations, err := provider.DetectInstalled(context.Background())
+	if err != nil {
+		t.Fatalf("DetectInstalled() error = %v", err)
+	}
+	if got := installations[0]; got.Version != "1.22.3" || got.BinaryPath != goBinary {
+		t.Errorf("DetectInstalled() = version %q binary %q, want 1.22.3 %s", got.Version, got.BinaryPath, goBinary)
+	}
+
+	usage, err := provider.GetGlobalCacheUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetGlobalCacheUsage() error = %v", err)
+	}
+	if got := findItem(t, usage, "Module Cache"); got.Size != 4096 || got.Path != env.path("go/pkg/mod") {
+		t.Errorf("Module Cache = %s (%d bytes), want %s (4096 bytes)", got.Path, got.Size, env.path("go/pkg/mod"))
+	}
+	if got := findItem(t, usage, "Build Cache"); got.Size != 1024 {
+		t.Errorf("Build Cache = %d bytes, want 1024", got.Size)
+	}
+}
+
+func TestGoProviderNotInstalled(t *testing.T) {
+	newFakeEnv(t)
+
+	_, err := NewGoProvider().DetectInstalled(context.Background())
+	if !errors.Is(err, core.ErrNotInstalled) {
+		t.Fatalf("DetectInstalled() error = %v, want ErrNotInstalled", err)
+	}
+}
+
+func TestGoenvVersionsReasonShowsExpandedRoot(t *testing.T) {
+	env := newFakeEnv(t)
+	env.addBinary("go", ".goenv/versions/1.22.3/bin/go")
+	env.setOutput("go version", "go version go1.22.3 linux/amd64")
+	env.writeFile(".goenv/versions/1.21.9/bin/go", 0)
+
+	installations, err := NewGoProvider().DetectInstalled(context.Background())
+	if err != nil {
+		t.Fatalf("DetectInstalled() error = %v", err)
+	}
+	for _, installation := range installations {
+		if installation.Version != "1.21.9" {
+			continue
+		}
+		if want := "installed under " + env.path(".goenv/versions"); installation.SourceReason != want {
+			t.Errorf("goenv SourceReason = %q, want %q", installation.SourceReason, want)
+		}
+		if strings.Contains(installation.ManagerPath+installation.BinaryPath, "~") {
+			t.Errorf("goenv installation has a ~ path: %+v", installation)
+		}
+		return
+	}
+	t.Fatalf("DetectInstalled() = %+v, want goenv's 1.21.9 too", installations)
+}
+
+func TestGoToolCachesInFixtureHome(t *testing.T) {
+	env := newFakeEnv(t)
+	env.writeFile(".cache/golangci-lint/ab/cd", 300)
+	t.Setenv("STATICCHECK_CACHE", env.path("staticcheck"))
+	env.writeFile("staticcheck/ef", 200)
+
+	caches := NewGoProvider().toolCaches()
+	if runtime.GOOS == "linux" {
+		if len(caches) != 2 || scanner.ExpandHome(caches[0].Path) != env.path(".cache/golangci-lint") {
+			t.Errorf("toolCaches() = %+v, want golangci-lint under the fixture's ~/.cache", caches)
+		}
+	}
+	if last := caches[len(caches)-1]; last.Description != "staticcheck Cache" || last.Path != env.path("staticcheck") {
+		t.Errorf("staticcheck cache = %+v, want STATICCHECK_CACHE %s", last, env.path("staticcheck"))
+	}
+}
+
+func TestGoenvRootIsRecognizedWherever(t *testing.T) {
+	env := newFakeEnv(t)
+	t.Setenv("GOENV_ROOT", env.path("tools/goenv"))
+	env.addBinary("go", "tools/goenv/versions/1.22.3/bin/go")
+	env.setOutput("go version", "go version go1.22.3 linux/amd64")
+
+	installations, err := NewGoProvider().DetectInstalled(context.Background())
+	if err != nil {
+		t.Fatalf("DetectInstalled() error = %v", err)
+	}
+	active := installations[0]
+	if active.Source != core.SourceVersionManager {
+		t.Errorf("Source = %q, want %q (%s)", active.Source, core.SourceVersionManager, active.SourceReason)
+	}
+	if want := env.path("tools/goenv"); active.ManagerPath != want {
+		t.Errorf("ManagerPath = %q, want %q", active.ManagerPath, want)
+	}
+}
@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// goProfileFiles maps exact file names left by go test and pprof to a description
+var goProfileFiles = map[string]string{
+	"coverage.out": "Coverage Profile",
+	"cover.out":    "Coverage Profile",
+	"c.out":        "Coverage Profile",
+	"cpu.prof":     "CPU Profile",
+	"mem.prof":     "Memory Profile",
+	"block.prof":   "Block Profile",
+	"mutex.prof":   "Mutex Profile",
+	"trace.out":    "Execution Trace",
+}
+
+// goProfileExtensions maps file extensions of test and profiling output to a description
+var goProfileExtensions = map[string]string{
+	".coverprofile": "Coverage Profile",
+	".prof":         "Profile",
+	".pprof":        "Profile",
+	".out":          "Test Output",
+	".test":         "Test Binary",
+}
+
+// ScanProject finds coverage profiles, pprof output and go test -c binaries in Go package
+// directories under root. Files only count when they sit next to Go sources or a go.mod.
+func (p *GoProvider) ScanProject(ctx context.Context, root string) []core.CleanableItem {
+	var items []core.CleanableItem
+	isPackageDir := make(map[string]bool)
+
+	scanner.WalkProject(ctx, root, func(path string, d fs.DirEntry) error {
+		if d.IsDir() {
+			// Vendored and testdata files belong to someone else
+			if d.Name() == "vendor" || d.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		description := goArtifactDescription(d.Name())
+		if description == "" {
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		packageDir, seen := isPackageDir[dir]
+		if !seen {
+			packageDir = scanner.DirContains(dir, "*.go", "go.mod")
+			isPackageDir[dir] = packageDir
+		}
+		if !packageDir {
+			return nil
+		}
+
+		items = append(items, core.CleanableItem{
+			Path:        path,
+			Description: description,
+			Size:        scanner.FileSize(path),
+			Safe:        true,
+			Reclaim:     core.ReclaimExact,
+			RebuildCost: "none; rerun go test or pprof to produce it again",
+		})
+		return nil
+	})
+
+	return items
+}
+
+// goArtifactDescription describes a file name left by go test or pprof, or returns ""
+func goArtifactDescription(name string) string {
+	if description, ok := goProfileFiles[name]; ok {
+		return description
+	}
+	return goProfileExtensions[strings.ToLower(filepath.Ext(name))]
+}
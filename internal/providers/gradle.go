@@ -0,0 +1,134 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"dependency-hell-cli/internal/scanner"
+)
+
+// gradleBuildCachePattern matches a local build cache directory set in an init script,
+// e.g. directory = file("/ci/gradle-cache") or directory = new File('/ci/cache')
+var gradleBuildCachePattern = regexp.MustCompile(`directory\s*=?\s*(?:file\(|new File\(|File\()?\s*["']([^"']+)["']`)
+
+// gradleFileRepoPattern matches file:// repositories set in an init script,
+// e.g. url = uri("file:///opt/mirror") or url 'file:/opt/mirror'
+var gradleFileRepoPattern = regexp.MustCompile(`url\s*=?\s*(?:uri\()?\s*["'](file:[^"']+)["']`)
+
+// gradleInitLocation is a directory configured by a Gradle init script
+type gradleInitLocation struct {
+	Path   string
+	Script string
+}
+
+// gradleUserHome returns the Gradle user home, honoring GRADLE_USER_HOME
+func (p *JavaProvider) gradleUserHome() string {
+	if home, ok := scanner.ConfiguredPath("java", "gradle_home"); ok {
+		return home
+	}
+	if home := scanner.GetEnvVar("GRADLE_USER_HOME"); home != "" {
+		return home
+	}
+	return "~/.gradle"
+}
+
+// gradleCacheDir returns the Gradle dependency and build cache directory
+func (p *JavaProvider) gradleCacheDir() string {
+	return filepath.Join(p.gradleUserHome(), "caches")
+}
+
+// gradleStateDirs returns the directories newer Gradle versions keep in the user home next to
+// caches/: the configuration cache and the data of build scans not yet published
+func (p *JavaProvider) gradleStateDirs() []toolDir {
+	home := p.gradleUserHome()
+	return []toolDir{
+		{Description: "Gradle Configuration Cache", Path: filepath.Join(home, "configuration-cache")},
+		{Description: "Gradle Build Scan Data", Path: filepath.Join(home, "build-scan-data")},
+	}
+}
+
+// gradleInitScripts lists the init scripts Gradle runs from the user home:
+// init.gradle or init.gradle.kts first, then the scripts in init.d in name order
+func (p *JavaProvider) gradleInitScripts() []string {
+	home := scanner.ExpandHome(p.gradleUserHome())
+
+	var scripts []string
+	for _, name := range []string{"init.gradle", "init.gradle.kts"} {
+		if script := filepath.Join(home, name); scanner.PathExists(script) {
+			scripts = append(scripts, script)
+		}
+	}
+
+	var initD []string
+	for _, pattern := range []string{"*.gradle", "*.gradle.kts"} {
+		matches, err := filepath.Glob(filepath.Join(home, "init.d", pattern))
+		if err == nil {
+			initD = append(initD, matches...)
+		}
+	}
+	sort.Strings(initD)
+
+	return append(scripts, initD...)
+}
+
+// gradleInitScriptTopics are the settings an init script can change that affect where
+// Gradle keeps or fetches data, keyed by the text that gives them away
+var gradleInitScriptTopics = []struct {
+	marker string
+	topic  string
+}{
+	{"buildCache", "build cache"},
+	{"repositories", "repositories"},
+	{"projectCacheDir", "project cache directory"},
+	{"buildDir", "build directories"},
+	{"buildDirectory", "build directories"},
+}
+
+// gradleInitScriptCustomizes names what an init script appears to configure, from simple
+// text matches, e.g. ["build cache", "repositories"]
+func gradleInitScriptCustomizes(script string) []string {
+	data, err := os.ReadFile(script)
+	if err != nil {
+		return nil
+	}
+	content := string(data)
+
+	var topics []string
+	seen := make(map[string]bool)
+	for _, t := range gradleInitScriptTopics {
+		if strings.Contains(content, t.marker) && !seen[t.topic] {
+			seen[t.topic] = true
+			topics = append(topics, t.topic)
+		}
+	}
+	return topics
+}
+
+// gradleInitLocations finds local build caches and file mirrors configured in init scripts.
+// Scripts are matched with simple patterns; anything computed at runtime is not detected.
+func (p *JavaProvider) gradleInitLocations() (buildCaches []gradleInitLocation, mirrors []gradleInitLocation) {
+	for _, script := range p.gradleInitScripts() {
+		data, err := os.ReadFile(script)
+		if err != nil {
+			continue
+		}
+		content := string(data)
+
+		if strings.Contains(content, "buildCache") {
+			for _, match := range gradleBuildCachePattern.FindAllStringSubmatch(content, -1) {
+				buildCaches = append(buildCaches, gradleInitLocation{Path: match[1], Script: script})
+			}
+		}
+
+		for _, match := range gradleFileRepoPattern.FindAllStringSubmatch(content, -1) {
+			if path := localMirrorPath(match[1]); path != "" {
+				mirrors = append(mirrors, gradleInitLocation{Path: path, Script: script})
+			}
+		}
+	}
+
+	return buildCaches, mirrors
+}
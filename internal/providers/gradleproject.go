@@ -0,0 +1,75 @@
+package providers
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// gradleProjectFiles mark a directory as the root of a Gradle project or subproject
+var gradleProjectFiles = []string{"build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"}
+
+// gradleProjectDirs maps directory names Gradle creates inside a project to a description
+var gradleProjectDirs = map[string]string{
+	".gradle": "Gradle Project Cache",
+	"build":   "Gradle Build Output",
+}
+
+// gradleConfigurationCache is the directory inside a project's .gradle holding its configuration cache
+const gradleConfigurationCache = "configuration-cache"
+
+// ScanProject finds the .gradle caches and build outputs of Gradle projects under root.
+// Directories only count when they sit next to a build or settings script.
+// A project's configuration cache is reported on its own, and left out of the .gradle size.
+func (p *JavaProvider) ScanProject(ctx context.Context, root string) []core.CleanableItem {
+	var items []core.CleanableItem
+	userHome := scanner.ExpandHome(p.gradleUserHome())
+
+	scanner.WalkProject(ctx, root, func(path string, d fs.DirEntry) error {
+		if !d.IsDir() {
+			return nil
+		}
+
+		description, ok := gradleProjectDirs[d.Name()]
+		if !ok || scanner.SamePath(path, userHome) {
+			return nil
+		}
+		if !scanner.DirContains(filepath.Dir(path), gradleProjectFiles...) {
+			return nil
+		}
+
+		size, _ := scanner.CalculateDirSize(ctx, path)
+		if d.Name() == ".gradle" {
+			configCache := filepath.Join(path, gradleConfigurationCache)
+			if scanner.PathExists(configCache) {
+				configSize, _ := scanner.CalculateDirSize(ctx, configCache)
+				size -= configSize
+				items = append(items, core.CleanableItem{
+					Path:        configCache,
+					Description: "Gradle Configuration Cache",
+					Size:        configSize,
+					Safe:        true,
+					Reclaim:     core.ReclaimRegenerable,
+					RebuildCost: "recomputed on the next build",
+				})
+			}
+		}
+
+		items = append(items, core.CleanableItem{
+			Path:        path,
+			Description: description,
+			Size:        size,
+			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "rebuilt by the next Gradle build",
+		})
+
+		// Everything inside is covered by this item
+		return filepath.SkipDir
+	})
+
+	return items
+}
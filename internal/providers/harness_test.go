@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// fakeEnvVars are cleared for every test so the real environment can't leak into a fixture
+var fakeEnvVars = []string{
+	"GOROOT", "GOPATH", "GOCACHE", "GOMODCACHE", "GOENV_ROOT", "GOLANGCI_LINT_CACHE", "STATICCHECK_CACHE",
+	"NVM_DIR", "VOLTA_HOME", "PNPM_HOME", "COREPACK_HOME", "YARN_CACHE_FOLDER",
+	"npm_config_cache", "NPM_CONFIG_CACHE", "npm_config_logs_dir", "NPM_CONFIG_LOGS_DIR",
+	"XDG_CACHE_HOME", "XDG_DATA_HOME", "XDG_STATE_HOME", "XDG_CONFIG_HOME", "LOCALAPPDATA",
+	"HOMEBREW_CACHE", "JAVA_HOME", "M2_HOME", "MAVEN_HOME", "MAVEN_OPTS", "GRADLE_USER_HOME",
+	"PYENV_ROOT", "PIP_CACHE_DIR", "PYTHON_BUILD_CACHE_PATH", "PYTHON_BUILD_BUILD_PATH", "WORKON_HOME",
+	"COMPOSER_HOME", "COMPOSER_CACHE_DIR", "COMPOSER_BIN_DIR", "CARGO_HOME", "RUSTUP_HOME", "DENO_DIR",
+	"YARN_PREFIX", "PUPPETEER_CACHE_DIR", "HOMEBREW_PREFIX",
+}
+
+// fakeEnv is a fixture home directory with fake binaries. It points DHELL_HOME at a temp
+// directory and stubs the scanner's executable lookup and command functions, so providers
+// can be tested without touching the real home or running real tools.
+type fakeEnv struct {
+	t        *testing.T
+	home     string
+	binaries map[string]string // Binary name to its path under home
+	outputs  map[string]string // Command line ("go env GOCACHE") to its output
+}
+
+// newFakeEnv creates an empty fixture home; stubs are restored when the test ends
+func newFakeEnv(t *testing.T) *fakeEnv {
+	t.Helper()
+
+	env := &fakeEnv{
+		t:        t,
+		home:     t.TempDir(),
+		binaries: make(map[string]string),
+		outputs:  make(map[string]string),
+	}
+	t.Setenv("DHELL_HOME", env.home)
+	for _, name := range fakeEnvVars {
+		t.Setenv(name, "")
+	}
+
+	findExecutable, getVersion, commandOutput := scanner.FindExecutable, scanner.GetExecutableVersion, scanner.CommandOutput
+	t.Cleanup(func() {
+		scanner.FindExecutable, scanner.GetExecutableVersion, scanner.CommandOutput = findExecutable, getVersion, commandOutput
+	})
+
+	// brew --cache, --prefix and list --versions are looked up once per process
+	resetHomebrewCache := func() {
+		homebrewCacheOnce, homebrewCache = sync.Once{}, ""
+		homebrewPrefixOnce, homebrewPrefixDir = sync.Once{}, ""
+		homebrewVersionsOnce, homebrewVersions, homebrewVersionsErr = sync.Once{}, nil, nil
+	}
+	resetHomebrewCache()
+	t.Cleanup(resetHomebrewCache)
+
+	scanner.FindExecutable = func(name string) (string, error) {
+		if path, ok := env.binaries[name]; ok {
+			return path, nil
+		}
+		return "", &exec.Error{Name: name, Err: exec.ErrNotFound}
+	}
+	scanner.GetExecutableVersion = func(_ context.Context, executable string, args ...string) (string, error) {
+		output, err := env.output(executable, args)
+		return strings.TrimSpace(string(output)), err
+	}
+	scanner.CommandOutput = func(_ context.Context, name string, args ...string) ([]byte, error) {
+		return env.output(name, args)
+	}
+
+	return env
+}
+
+// output returns the canned output of a command, failing like a missing tool otherwise
+func (e *fakeEnv) output(executable string, args []string) ([]byte, error) {
+	command := strings.Join(append([]string{filepath.Base(executable)}, args...), " ")
+	if output, ok := e.outputs[command]; ok {
+		return []byte(output), nil
+	}
+	return nil, fmt.Errorf("fake: no output for %q", command)
+}
+
+// path returns the absolute path of a fixture entry
+func (e *fakeEnv) path(rel string) string {
+	return filepath.Join(e.home, filepath.FromSlash(rel))
+}
+
+// addBinary creates an executable at rel and makes the scanner find it under name
+func (e *fakeEnv) addBinary(name, rel string) string {
+	path := e.writeFile(rel, 0)
+	if err := os.Chmod(path, 0755); err != nil {
+		e.t.Fatal(err)
+	}
+	e.binaries[name] = path
+	return path
+}
+
+// setOutput makes command, e.g. "go version", print output
+func (e *fakeEnv) setOutput(command, output string) {
+	e.outputs[command] = output
+}
+
+// writeFile creates a file of size bytes at rel, creating its directories
+func (e *fakeEnv) writeFile(rel string, size int) string {
+	e.t.Helper()
+
+	path := e.path(rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		e.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		e.t.Fatal(err)
+	}
+	return path
+}
+
+// findItem returns the disk usage item with the given description
+func findItem(t *testing.T, usage *core.DiskUsage, description string) core.DiskUsageItem {
+	t.Helper()
+
+	for _, item := range usage.Items {
+		if item.Description == description {
+			return item
+		}
+	}
+	t.Fatalf("no %q item in %+v", description, usage.Items)
+	return core.DiskUsageItem{}
+}
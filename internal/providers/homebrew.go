@@ -0,0 +1,265 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// HomebrewProvider reports Homebrew itself, whose download cache is shared by every formula
+type HomebrewProvider struct{}
+
+// NewHomebrewProvider creates a new Homebrew provider
+func NewHomebrewProvider() *HomebrewProvider {
+	return &HomebrewProvider{}
+}
+
+// Name returns the name of the package manager
+func (p *HomebrewProvider) Name() string {
+	return "Homebrew"
+}
+
+// DetectInstalled detects the Homebrew on PATH
+func (p *HomebrewProvider) DetectInstalled(ctx context.Context) ([]core.Installation, error) {
+	brewPath, err := scanner.FindExecutable("brew")
+	if err != nil {
+		return nil, notInstalledError("brew not found in PATH")
+	}
+
+	realPath, err := scanner.ResolveSymlink(brewPath)
+	if err != nil {
+		realPath = brewPath
+	}
+
+	version, err := scanner.GetExecutableVersion(ctx, "brew", "--version")
+	if err != nil {
+		return nil, versionError("brew", err)
+	}
+
+	installation := core.Installation{
+		Version:      p.parseVersion(version),
+		Source:       core.SourceHomebrew,
+		BinaryPath:   brewPath,
+		Arch:         scanner.HomebrewArch(realPath),
+		SourceReason: fmt.Sprintf("brew is Homebrew itself (%s)", realPath),
+	}
+
+	return []core.Installation{installation}, nil
+}
+
+// parseVersion extracts version from brew --version output
+func (p *HomebrewProvider) parseVersion(output string) string {
+	// Example: "Homebrew 4.2.0"
+	parts := strings.Fields(output)
+	if len(parts) >= 2 && parts[0] == "Homebrew" {
+		return parts[1]
+	}
+	return "unknown"
+}
+
+// GetGlobalCacheUsage calculates disk usage for the Homebrew download cache
+func (p *HomebrewProvider) GetGlobalCacheUsage(ctx context.Context) (*core.DiskUsage, error) {
+	var items []core.DiskUsageItem
+
+	if cache := homebrewCacheDir(ctx); cache != "" && scanner.PathExists(cache) {
+		size, _ := scanner.CalculateDirSize(ctx, cache)
+		items = append(items, core.DiskUsageItem{
+			Path:        cache,
+			Description: "Download Cache",
+			Size:        size,
+		})
+	}
+
+	// Older versions of upgraded formulae kept in the Cellar, each under its own directory
+	// so the Cellar's current versions are never counted or acted on
+	stale, _ := StaleHomebrewFormulae(ctx)
+	for _, formula := range stale {
+		for _, version := range formula.Stale {
+			items = append(items, core.DiskUsageItem{
+				Path:        version.Path,
+				Description: fmt.Sprintf("Old Formula Version (%s %s)", formula.Name, version.Version),
+				Size:        version.Size,
+			})
+		}
+	}
+
+	return newDiskUsage(items), nil
+}
+
+// GetEnvVars returns relevant environment variables
+func (p *HomebrewProvider) GetEnvVars(ctx context.Context) map[string]string {
+	vars := make(map[string]string)
+
+	envVars := []string{"HOMEBREW_PREFIX", "HOMEBREW_CACHE", "HOMEBREW_NO_INSTALL_CLEANUP"}
+	for _, name := range envVars {
+		if value := scanner.GetEnvVar(name); value != "" {
+			vars[name] = value
+		}
+	}
+
+	return vars
+}
+
+// GetCleanableItems returns items that can be cleaned for Homebrew
+func (p *HomebrewProvider) GetCleanableItems(ctx context.Context) ([]core.CleanableItem, error) {
+	var items []core.CleanableItem
+
+	cache := homebrewCacheDir(ctx)
+	if cache == "" || !scanner.PathExists(cache) {
+		return items, nil
+	}
+	size, _ := scanner.CalculateDirSize(ctx, cache)
+	downloadsSize := homebrewDownloadsSize(ctx)
+
+	// brew cleanup --prune=all removes every cached download and the old versions of
+	// upgraded formulae (safe - bottles are re-fetched, the linked versions are kept)
+	items = append(items, core.CleanableItem{
+		Description: "Homebrew Cleanup",
+		Command:     "brew cleanup --prune=all",
+		Size:        downloadsSize + staleHomebrewSize(ctx),
+		Safe:        true,
+		Reclaim:     core.ReclaimUpperBound, // Downloads in use and pinned formulae are kept
+		RebuildCost: "re-downloads bottles when an old version is reinstalled",
+	})
+
+	// Removing the cache directly also drops what cleanup leaves (API metadata, bootsnap);
+	// only that remainder is counted so the two items don't report the same bytes twice
+	items = append(items, core.CleanableItem{
+		Path:        cache,
+		Description: "Homebrew Download Cache",
+		Size:        size - downloadsSize,
+		Safe:        false,
+		Reclaim:     core.ReclaimRegenerable,
+		RebuildCost: "re-downloads bottles and API metadata on the next brew install or update",
+	})
+
+	return items, nil
+}
+
+// Clean executes cleaning for Homebrew
+func (p *HomebrewProvider) Clean(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	return cleanItems(ctx, items, dryRun, cleanItem), nil
+}
+
+var (
+	homebrewCacheOnce sync.Once
+	homebrewCache     string
+)
+
+// homebrewCacheDir returns the Homebrew download cache as reported by brew --cache,
+// or an empty string when brew is not installed
+func homebrewCacheDir(ctx context.Context) string {
+	homebrewCacheOnce.Do(func() {
+		if _, err := scanner.FindExecutable("brew"); err != nil {
+			return
+		}
+		output, err := scanner.CommandOutput(ctx, "brew", "--cache")
+		if err != nil {
+			return
+		}
+		homebrewCache = strings.TrimSpace(string(output))
+	})
+	return homebrewCache
+}
+
+// homebrewDownloadsSize sums the cached bottles and source tarballs of the given formulae,
+// or of every formula when none are given.
+// A formula also matches its versioned variants, so "python" covers "python@3.12".
+func homebrewDownloadsSize(ctx context.Context, formulae ...string) int64 {
+	cache := homebrewCacheDir(ctx)
+	if cache == "" {
+		return 0
+	}
+
+	var total int64
+	for _, dir := range []string{filepath.Join(cache, "downloads"), cache} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			// Top-level entries are mostly symlinks into downloads/
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			if len(formulae) > 0 && !matchesFormula(downloadFormula(entry.Name()), formulae) {
+				continue
+			}
+			if info, err := entry.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+	}
+
+	return total
+}
+
+// GetInfoSections splits the cached downloads by the language their formulae belong to.
+// The rows overlap the Download Cache item, so they are shown in info rather than totalled.
+func (p *HomebrewProvider) GetInfoSections(ctx context.Context) []core.InfoSection {
+	cache := homebrewCacheDir(ctx)
+	if cache == "" {
+		return nil
+	}
+
+	languages := make([]string, 0, len(homebrewFormulae))
+	for language := range homebrewFormulae {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	section := core.InfoSection{
+		Title: "Downloads by Language",
+		Note:  "Part of the Download Cache, not counted again",
+	}
+	for _, language := range languages {
+		formulae := homebrewFormulae[language]
+		if size := homebrewDownloadsSize(ctx, formulae...); size > 0 {
+			section.Items = append(section.Items, core.DiskUsageItem{
+				Path:        filepath.Join(cache, "downloads"),
+				Description: fmt.Sprintf("%s (%s)", language, strings.Join(formulae, ", ")),
+				Size:        size,
+			})
+		}
+	}
+	if len(section.Items) == 0 {
+		return nil
+	}
+
+	return expandInfoSections([]core.InfoSection{section})
+}
+
+// downloadFormula extracts the formula name from a cached download file name.
+// downloads/ entries look like "<sha256>--node--21.1.0.arm64_sonoma.bottle.tar.gz",
+// legacy top-level entries like "node--21.1.0.arm64_sonoma.bottle.tar.gz".
+func downloadFormula(fileName string) string {
+	parts := strings.Split(fileName, "--")
+	switch {
+	case len(parts) >= 3:
+		return parts[1]
+	case len(parts) == 2:
+		return parts[0]
+	}
+	return ""
+}
+
+// matchesFormula reports whether name is one of formulae or a versioned variant of one
+func matchesFormula(name string, formulae []string) bool {
+	if name == "" {
+		return false
+	}
+	for _, formula := range formulae {
+		if name == formula || strings.HasPrefix(name, formula+"@") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package providers
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+func TestHomebrewDownloadsCountedOnce(t *testing.T) {
+	env := newFakeEnv(t)
+	env.addBinary("brew", "homebrew/bin/brew")
+	env.setOutput("brew --cache", env.path("Library/Caches/Homebrew"))
+	env.writeFile("Library/Caches/Homebrew/downloads/aaa--node--21.1.0.arm64_sonoma.bottle.tar.gz", 1000)
+	env.writeFile("Library/Caches/Homebrew/downloads/bbb--python@3.12--3.12.1.arm64_sonoma.bottle.tar.gz", 500)
+	env.writeFile("Library/Caches/Homebrew/downloads/ccc--wget--1.24.5.arm64_sonoma.bottle.tar.gz", 300)
+
+	homebrew := NewHomebrewProvider()
+	usage, err := homebrew.GetGlobalCacheUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetGlobalCacheUsage() error = %v", err)
+	}
+	if got := findItem(t, usage, "Download Cache"); got.Size != 1800 {
+		t.Errorf("Download Cache = %d bytes, want 1800", got.Size)
+	}
+
+	// The language providers no longer report the same bottles again
+	for _, provider := range []interface {
+		GetGlobalCacheUsage(context.Context) (*core.DiskUsage, error)
+	}{NewNodeProvider(), NewPythonProvider()} {
+		usage, err := provider.GetGlobalCacheUsage(context.Background())
+		if err != nil {
+			continue
+		}
+		for _, item := range usage.Items {
+			if strings.HasPrefix(item.Path, env.path("Library/Caches/Homebrew")) {
+				t.Errorf("%s is counted again as %q", item.Path, item.Description)
+			}
+		}
+	}
+
+	sections := homebrew.GetInfoSections(context.Background())
+	if len(sections) != 1 {
+		t.Fatalf("GetInfoSections() = %d sections, want 1", len(sections))
+	}
+	want := map[string]int64{"Node.js (node, yarn, pnpm)": 1000, "Python (python)": 500}
+	if len(sections[0].Items) != len(want) {
+		t.Fatalf("Downloads by Language = %+v, want %v", sections[0].Items, want)
+	}
+	for _, item := range sections[0].Items {
+		if want[item.Description] != item.Size {
+			t.Errorf("%s = %d bytes, want %d", item.Description, item.Size, want[item.Description])
+		}
+	}
+}
+
+func TestHomebrewOldVersionsArePerVersionItems(t *testing.T) {
+	env := newFakeEnv(t)
+	env.addBinary("brew", "homebrew/bin/brew")
+	env.setOutput("brew --prefix", env.path("homebrew"))
+	env.setOutput("brew list --formula --versions", "node 20.11.0 21.6.1\nwget 1.24.5\n")
+	env.writeFile("homebrew/Cellar/node/20.11.0/bin/node", 700)
+	env.writeFile("homebrew/Cellar/node/21.6.1/bin/node", 900)
+	env.writeFile("homebrew/Cellar/wget/1.24.5/bin/wget", 100)
+	if err := os.MkdirAll(env.path("homebrew/opt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(env.path("homebrew/Cellar/node/21.6.1"), env.path("homebrew/opt/node")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	ctx := scanner.WithSizeCache(context.Background(), scanner.NewSizeCache())
+	usage, err := NewHomebrewProvider().GetGlobalCacheUsage(ctx)
+	if err != nil {
+		t.Fatalf("GetGlobalCacheUsage() error = %v", err)
+	}
+
+	got := findItem(t, usage, "Old Formula Version (node 20.11.0)")
+	if got.Path != env.path("homebrew/Cellar/node/20.11.0") || got.Size != 700 {
+		t.Errorf("old node = %s (%d bytes), want %s (700 bytes)", got.Path, got.Size, env.path("homebrew/Cellar/node/20.11.0"))
+	}
+	for _, item := range usage.Items {
+		if item.Path == env.path("homebrew/Cellar") || strings.HasPrefix(item.Path, env.path("homebrew/Cellar/node/21.6.1")) {
+			t.Errorf("%q covers the Cellar's current versions (%s)", item.Description, item.Path)
+		}
+	}
+}
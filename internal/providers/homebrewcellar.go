@@ -0,0 +1,164 @@
+package providers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"dependency-hell-cli/internal/scanner"
+)
+
+// homebrewFormulae lists the Homebrew formulae belonging to each language provider.
+// A formula also covers its versioned variants, so "python" matches "python@3.12".
+var homebrewFormulae = map[string][]string{
+	"Golang":  {"go"},
+	"Node.js": {"node", "yarn", "pnpm"},
+	"Java":    {"openjdk", "maven", "gradle"},
+	"Python":  {"python"},
+	"PHP":     {"php", "composer"},
+	"Rust":    {"rust", "rustup"},
+	"Deno":    {"deno"},
+}
+
+// HomebrewLanguage returns the language provider a formula belongs to, or "" for other formulae
+func HomebrewLanguage(formula string) string {
+	for language, formulae := range homebrewFormulae {
+		if matchesFormula(formula, formulae) {
+			return language
+		}
+	}
+	return ""
+}
+
+// StaleFormula is a formula with older versions still kept in the Homebrew Cellar
+type StaleFormula struct {
+	Name     string
+	Language string         // Language provider the formula belongs to, "" if none
+	Current  string         // Version the opt/ link points to
+	Stale    []StaleVersion // Other installed versions, removed by brew cleanup
+	Size     int64          // Disk space used by the stale versions
+}
+
+// StaleVersion is an old version of a formula kept in the Cellar
+type StaleVersion struct {
+	Version string
+	Path    string // The version's Cellar directory
+	Size    int64
+}
+
+// StaleVersions returns the stale version numbers, e.g. for listing them
+func (f StaleFormula) StaleVersions() []string {
+	versions := make([]string, len(f.Stale))
+	for i, stale := range f.Stale {
+		versions[i] = stale.Version
+	}
+	return versions
+}
+
+var (
+	homebrewVersionsOnce sync.Once
+	homebrewVersions     map[string][]string
+	homebrewVersionsErr  error
+)
+
+// HomebrewVersions returns the installed versions of every formula, as listed by a single
+// brew list --versions run. The result is cached for the rest of the process.
+func HomebrewVersions(ctx context.Context) (map[string][]string, error) {
+	homebrewVersionsOnce.Do(func() {
+		output, err := scanner.CommandOutput(ctx, "brew", "list", "--formula", "--versions")
+		if err != nil {
+			homebrewVersionsErr = err
+			return
+		}
+		homebrewVersions = parseBrewVersions(string(output))
+	})
+	return homebrewVersions, homebrewVersionsErr
+}
+
+// parseBrewVersions parses brew list --versions output, one "<formula> <version>..." line per formula
+func parseBrewVersions(output string) map[string][]string {
+	versions := make(map[string][]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		versions[fields[0]] = fields[1:]
+	}
+	return versions
+}
+
+// StaleHomebrewFormulae returns the formulae with more than one version in the Cellar,
+// sorted by name, with the space the old versions use. Sizes go through the size cache in
+// ctx, so scanning and cleaning in one command walk each old version once.
+func StaleHomebrewFormulae(ctx context.Context) ([]StaleFormula, error) {
+	versions, err := HomebrewVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := homebrewPrefix(ctx)
+	var stale []StaleFormula
+	for name, installed := range versions {
+		if len(installed) < 2 {
+			continue
+		}
+
+		// The opt/ link points at the version in use; without one, brew cleanup keeps the newest
+		current := installed[len(installed)-1]
+		if target, err := filepath.EvalSymlinks(filepath.Join(prefix, "opt", name)); err == nil {
+			current = filepath.Base(target)
+		}
+
+		formula := StaleFormula{Name: name, Language: HomebrewLanguage(name), Current: current}
+		for _, version := range installed {
+			if version == current {
+				continue
+			}
+			path := filepath.Join(prefix, "Cellar", name, version)
+			size, _ := scanner.CalculateDirSize(ctx, path)
+			formula.Stale = append(formula.Stale, StaleVersion{Version: version, Path: path, Size: size})
+			formula.Size += size
+		}
+		stale = append(stale, formula)
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].Name < stale[j].Name
+	})
+	return stale, nil
+}
+
+// staleHomebrewSize returns the space brew cleanup would free by removing old formula versions
+func staleHomebrewSize(ctx context.Context) int64 {
+	stale, err := StaleHomebrewFormulae(ctx)
+	if err != nil {
+		return 0
+	}
+
+	var total int64
+	for _, formula := range stale {
+		total += formula.Size
+	}
+	return total
+}
+
+var (
+	homebrewPrefixOnce sync.Once
+	homebrewPrefixDir  string
+)
+
+// homebrewPrefix returns the Homebrew prefix as reported by brew --prefix,
+// falling back to HOMEBREW_PREFIX
+func homebrewPrefix(ctx context.Context) string {
+	homebrewPrefixOnce.Do(func() {
+		homebrewPrefixDir = os.Getenv("HOMEBREW_PREFIX")
+		if output, err := scanner.CommandOutput(ctx, "brew", "--prefix"); err == nil {
+			homebrewPrefixDir = strings.TrimSpace(string(output))
+		}
+	})
+	return homebrewPrefixDir
+}
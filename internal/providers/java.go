@@ -1,8 +1,11 @@
 package providers
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	"dependency-hell-cli/internal/core"
@@ -23,11 +26,11 @@ func (p *JavaProvider) Name() string {
 }
 
 // DetectInstalled detects installed Java versions
-func (p *JavaProvider) DetectInstalled() ([]core.Installation, error) {
+func (p *JavaProvider) DetectInstalled(ctx context.Context) ([]core.Installation, error) {
 	// Check if java is installed
 	javaPath, err := scanner.FindExecutable("java")
 	if err != nil {
-		return nil, fmt.Errorf("java not found in PATH")
+		return nil, notInstalledError("java not found in PATH")
 	}
 
 	// Resolve symlinks
@@ -37,24 +40,28 @@ func (p *JavaProvider) DetectInstalled() ([]core.Installation, error) {
 	}
 
 	// Get version
-	version, err := scanner.GetExecutableVersion("java", "-version")
+	version, err := scanner.GetExecutableVersion(ctx, "java", "-version")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get java version: %w", err)
+		return nil, versionError("java", err)
 	}
 
 	// Parse version (java -version outputs to stderr and has complex format)
 	versionStr := p.parseVersion(version)
+	distribution := p.parseDistribution(version)
 
 	// Determine source
-	source := p.determineSource(realPath)
+	source, sourceReason := p.determineSource(realPath)
 	managerName := p.getManagerName(realPath, source)
 
 	installation := core.Installation{
-		Version:     versionStr,
-		Source:      source,
-		BinaryPath:  javaPath,
-		ManagerPath: p.getManagerPath(realPath, source),
-		ManagerName: managerName,
+		Version:      versionStr,
+		Source:       source,
+		BinaryPath:   javaPath,
+		ManagerPath:  p.getManagerPath(realPath, source),
+		ManagerName:  managerName,
+		Distribution: distribution,
+		Arch:         scanner.HomebrewArch(realPath),
+		SourceReason: sourceReason,
 	}
 
 	return []core.Installation{installation}, nil
@@ -89,21 +96,132 @@ func (p *JavaProvider) parseVersion(output string) string {
 	return "unknown"
 }
 
+// javaDistributions maps markers found in java -version output to distribution names.
+// Order matters: more specific markers must come before generic ones.
+var javaDistributions = []struct {
+	marker string
+	name   string
+}{
+	{"GraalVM", "GraalVM"},
+	{"Zulu", "Azul Zulu"},
+	{"Temurin", "Eclipse Temurin"},
+	{"AdoptOpenJDK", "AdoptOpenJDK"},
+	{"Corretto", "Amazon Corretto"},
+	{"Microsoft", "Microsoft"},
+	{"SapMachine", "SapMachine"},
+	{"Semeru", "IBM Semeru"},
+	{"BellSoft", "BellSoft Liberica"},
+	{"Liberica", "BellSoft Liberica"},
+	{"JBR", "JetBrains Runtime"},
+	{"Homebrew", "Homebrew OpenJDK"},
+	{"Java(TM)", "Oracle"},
+}
+
+// parseDistribution extracts the vendor/distribution from java -version output
+func (p *JavaProvider) parseDistribution(output string) string {
+	// Example output:
+	// openjdk version "17.0.9" 2023-10-17
+	// OpenJDK Runtime Environment Temurin-17.0.9+9 (build 17.0.9+9)
+	// OpenJDK 64-Bit Server VM Temurin-17.0.9+9 (build 17.0.9+9, mixed mode)
+
+	lines := strings.Split(output, "\n")
+	if len(lines) < 2 {
+		return ""
+	}
+
+	// The vendor appears on the runtime and VM lines, not the version line
+	details := strings.Join(lines[1:], "\n")
+	for _, distribution := range javaDistributions {
+		if strings.Contains(details, distribution.marker) {
+			return distribution.name
+		}
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(lines[0]), "openjdk") {
+		return "OpenJDK"
+	}
+	return ""
+}
+
+// BinaryName returns the executable used to detect the language
+func (p *JavaProvider) BinaryName() string {
+	return "java"
+}
+
+// ClassifySource classifies the install source of any resolved binary path
+func (p *JavaProvider) ClassifySource(realPath string) (core.InstallSource, string) {
+	return p.determineSource(realPath)
+}
+
 // determineSource determines the installation source based on path
-func (p *JavaProvider) determineSource(path string) core.InstallSource {
-	// Check JAVA_HOME first
+func (p *JavaProvider) determineSource(path string) (core.InstallSource, string) {
+	if strings.Contains(path, ".sdkman") {
+		return classifySource(path, []sourceRule{{".sdkman", core.SourceVersionManager}})
+	}
+
+	// Check JAVA_HOME before other locations
+	if javaHome := os.Getenv("JAVA_HOME"); strings.Contains(javaHome, ".sdkman") {
+		return core.SourceVersionManager, fmt.Sprintf("JAVA_HOME points under .sdkman (%s)", javaHome)
+	}
+
+	rules := append([]sourceRule{}, homebrewRules...)
+	rules = append(rules, sourceRule{"/Library/Java", core.SourceManual})
+	return classifySource(path, rules)
+}
+
+// JavaHomeMismatch describes a JAVA_HOME that points to a different JDK than java on PATH
+type JavaHomeMismatch struct {
+	PathHome        string // JDK home of the java binary on PATH
+	PathVersion     string
+	JavaHome        string
+	JavaHomeVersion string // "not found" when JAVA_HOME has no java binary
+}
+
+// CheckJavaHome compares JAVA_HOME with the JDK that java on PATH belongs to.
+// It returns nil when JAVA_HOME is unset or points to the same JDK.
+func (p *JavaProvider) CheckJavaHome(ctx context.Context, installation core.Installation) *JavaHomeMismatch {
 	javaHome := os.Getenv("JAVA_HOME")
+	if javaHome == "" {
+		return nil
+	}
 
-	if strings.Contains(path, ".sdkman") || strings.Contains(javaHome, ".sdkman") {
-		return core.SourceVersionManager
+	realPath, err := scanner.ResolveSymlink(installation.BinaryPath)
+	if err != nil {
+		realPath = installation.BinaryPath
 	}
-	if strings.Contains(path, "/opt/homebrew") || strings.Contains(path, "/usr/local/Cellar") {
-		return core.SourceHomebrew
+
+	// The macOS /usr/bin/java stub launches whatever JAVA_HOME points to
+	if runtime.GOOS == "darwin" && realPath == "/usr/bin/java" {
+		return nil
 	}
-	if strings.Contains(path, "/Library/Java") {
-		return core.SourceManual
+
+	pathHome := jdkHome(realPath)
+	if scanner.SamePath(pathHome, javaHome) {
+		return nil
+	}
+
+	javaHomeVersion := "not found"
+	if version, err := scanner.GetExecutableVersion(ctx, filepath.Join(javaHome, "bin", "java"), "-version"); err == nil {
+		javaHomeVersion = p.parseVersion(version)
+	}
+
+	return &JavaHomeMismatch{
+		PathHome:        pathHome,
+		PathVersion:     installation.Version,
+		JavaHome:        javaHome,
+		JavaHomeVersion: javaHomeVersion,
+	}
+}
+
+// jdkHome derives the JDK home directory from a resolved java binary path
+func jdkHome(realPath string) string {
+	home := filepath.Dir(filepath.Dir(realPath))
+
+	// Java 8 JDKs run java from <jdk>/jre/bin
+	if filepath.Base(home) == "jre" && scanner.PathExists(filepath.Join(filepath.Dir(home), "bin", "javac")) {
+		return filepath.Dir(home)
 	}
-	return core.SourceUnknown
+	return home
 }
 
 // getManagerPath extracts the manager path if applicable
@@ -117,13 +235,13 @@ func (p *JavaProvider) getManagerPath(path string, source core.InstallSource) st
 }
 
 // GetGlobalCacheUsage calculates disk usage for Java ecosystem
-func (p *JavaProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
+func (p *JavaProvider) GetGlobalCacheUsage(ctx context.Context) (*core.DiskUsage, error) {
 	var items []core.DiskUsageItem
 
 	// SDKMAN Java versions
 	sdkmanPath := "~/.sdkman/candidates/java"
 	if scanner.PathExists(sdkmanPath) {
-		size, _ := scanner.CalculateDirSize(sdkmanPath)
+		size, _ := scanner.CalculateDirSize(ctx, sdkmanPath)
 		items = append(items, core.DiskUsageItem{
 			Path:        sdkmanPath,
 			Description: "SDKMAN Java SDKs",
@@ -131,10 +249,10 @@ func (p *JavaProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Maven repository (the big one!)
-	mavenRepo := "~/.m2/repository"
+	// Maven repository (the big one!), wherever settings.xml points it
+	mavenRepo, _ := p.mavenRepository()
 	if scanner.PathExists(mavenRepo) {
-		size, _ := scanner.CalculateDirSize(mavenRepo)
+		size, _ := scanner.CalculateDirSize(ctx, mavenRepo)
 		items = append(items, core.DiskUsageItem{
 			Path:        mavenRepo,
 			Description: "Maven Repository",
@@ -142,10 +260,22 @@ func (p *JavaProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
+	// Local file:// mirrors declared in settings.xml
+	for _, mirror := range p.mavenMirrors() {
+		if path := localMirrorPath(mirror.URL); path != "" && scanner.PathExists(path) {
+			size, _ := scanner.CalculateDirSize(ctx, path)
+			items = append(items, core.DiskUsageItem{
+				Path:        path,
+				Description: fmt.Sprintf("Maven Mirror (%s)", mirror.ID),
+				Size:        size,
+			})
+		}
+	}
+
 	// Gradle cache
-	gradleCache := "~/.gradle/caches"
+	gradleCache := p.gradleCacheDir()
 	if scanner.PathExists(gradleCache) {
-		size, _ := scanner.CalculateDirSize(gradleCache)
+		size, _ := scanner.CalculateDirSize(ctx, gradleCache)
 		items = append(items, core.DiskUsageItem{
 			Path:        gradleCache,
 			Description: "Gradle Cache",
@@ -153,23 +283,92 @@ func (p *JavaProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Calculate total
-	var total int64
-	for _, item := range items {
-		total += item.Size
+	// Configuration cache and build scan data of newer Gradle versions
+	for _, dir := range p.gradleStateDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.DiskUsageItem{
+				Path:        dir.Path,
+				Description: dir.Description,
+				Size:        size,
+			})
+		}
+	}
+
+	// Build caches and file mirrors configured by Gradle init.d scripts
+	buildCaches, mirrors := p.gradleInitLocations()
+	for _, location := range buildCaches {
+		if scanner.PathExists(location.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, location.Path)
+			items = append(items, core.DiskUsageItem{
+				Path:        location.Path,
+				Description: "Gradle Build Cache (init.d)",
+				Size:        size,
+			})
+		}
+	}
+	for _, location := range mirrors {
+		if scanner.PathExists(location.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, location.Path)
+			items = append(items, core.DiskUsageItem{
+				Path:        location.Path,
+				Description: "Gradle Mirror (init.d)",
+				Size:        size,
+			})
+		}
+	}
+
+	return newDiskUsage(items), nil
+}
+
+// GetInfoSections returns the effective Maven/Gradle configuration for the info command
+func (p *JavaProvider) GetInfoSections(ctx context.Context) []core.InfoSection {
+	var sections []core.InfoSection
+
+	mavenRepo, origin := p.mavenRepository()
+	repoSize, _ := scanner.CalculateDirSize(ctx, mavenRepo)
+	maven := core.InfoSection{
+		Title: "Maven Settings",
+		Items: []core.DiskUsageItem{{
+			Path:        mavenRepo,
+			Description: fmt.Sprintf("Local Repository (from %s)", origin),
+			Size:        repoSize,
+		}},
+	}
+	for _, mirror := range p.mavenMirrors() {
+		maven.Items = append(maven.Items, core.DiskUsageItem{
+			Description: fmt.Sprintf("Mirror %s of %s", mirror.ID, mirror.MirrorOf),
+			Value:       mirror.URL,
+		})
+	}
+	sections = append(sections, maven)
+
+	if scripts := p.gradleInitScripts(); len(scripts) > 0 {
+		gradle := core.InfoSection{
+			Title: "Gradle Init Scripts",
+			Note:  "These run before every build and may move caches or add repositories, so sizes reported for the Gradle user home can be incomplete",
+		}
+		for _, script := range scripts {
+			description := filepath.Base(script)
+			if topics := gradleInitScriptCustomizes(script); len(topics) > 0 {
+				description += fmt.Sprintf(" (configures %s)", strings.Join(topics, ", "))
+			}
+			gradle.Items = append(gradle.Items, core.DiskUsageItem{
+				Path:        script,
+				Description: description,
+			})
+		}
+		sections = append(sections, gradle)
 	}
 
-	return &core.DiskUsage{
-		Items: items,
-		Total: total,
-	}, nil
+	return expandInfoSections(sections)
 }
 
 // GetEnvVars returns relevant environment variables
-func (p *JavaProvider) GetEnvVars() map[string]string {
+func (p *JavaProvider) GetEnvVars(ctx context.Context) map[string]string {
 	vars := make(map[string]string)
 
-	envVars := []string{"JAVA_HOME", "M2_HOME", "GRADLE_HOME"}
+	envVars := []string{"JAVA_HOME", "M2_HOME", "MAVEN_HOME", "MAVEN_OPTS", "GRADLE_HOME", "GRADLE_USER_HOME"}
 	for _, name := range envVars {
 		if value := scanner.GetEnvVar(name); value != "" {
 			vars[name] = value
@@ -180,30 +379,83 @@ func (p *JavaProvider) GetEnvVars() map[string]string {
 }
 
 // GetCleanableItems returns items that can be cleaned for Java
-func (p *JavaProvider) GetCleanableItems() ([]core.CleanableItem, error) {
+func (p *JavaProvider) GetCleanableItems(ctx context.Context) ([]core.CleanableItem, error) {
 	var items []core.CleanableItem
 
 	// Gradle cache (safe)
-	gradleCache := "~/.gradle/caches"
+	gradleCache := p.gradleCacheDir()
 	if scanner.PathExists(gradleCache) {
-		size, _ := scanner.CalculateDirSize(gradleCache)
+		size, _ := scanner.CalculateDirSize(ctx, gradleCache)
 		items = append(items, core.CleanableItem{
 			Path:        gradleCache,
 			Description: "Gradle Cache",
 			Size:        size,
 			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads dependencies over the network on the next Gradle build",
 		})
 	}
 
+	// Configuration cache and build scan data (safe - recomputed by the next build)
+	for _, dir := range p.gradleStateDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.CleanableItem{
+				Path:        dir.Path,
+				Description: dir.Description,
+				Size:        size,
+				Safe:        true,
+				Reclaim:     core.ReclaimRegenerable,
+				RebuildCost: "recomputed on the next Gradle build",
+			})
+		}
+	}
+
+	// Local build caches from init.d scripts (safe, rebuilt on demand); mirrors are left alone
+	buildCaches, _ := p.gradleInitLocations()
+	for _, location := range buildCaches {
+		if scanner.PathExists(location.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, location.Path)
+			items = append(items, core.CleanableItem{
+				Path:        location.Path,
+				Description: "Gradle Build Cache (init.d)",
+				Size:        size,
+				Safe:        true,
+				Reclaim:     core.ReclaimRegenerable,
+				RebuildCost: "rebuilds task outputs on the next Gradle build",
+			})
+		}
+	}
+
 	// Maven repository (NOT safe - requires careful consideration)
-	mavenRepo := "~/.m2/repository"
+	mavenRepo, _ := p.mavenRepository()
+
+	// Failed-download and origin markers (safe - artifacts stay, Maven re-resolves as needed).
+	// Only the marker files are deleted, so the repository itself is left in place.
 	if scanner.PathExists(mavenRepo) {
-		size, _ := scanner.CalculateDirSize(mavenRepo)
+		// A count cut short by --timeout would understate the item, so it is left out then
+		if count, size, err := mavenMarkers(ctx, mavenRepo); err == nil && count > 0 {
+			items = append(items, core.CleanableItem{
+				Path:        mavenRepo,
+				Patterns:    mavenMarkerNames,
+				Description: fmt.Sprintf("Maven Download Markers (%d *.lastUpdated/_remote.repositories files)", count),
+				Size:        size,
+				Safe:        true,
+				Reclaim:     core.ReclaimExact,
+				RebuildCost: "none; Maven re-checks remote repositories on the next build",
+			})
+		}
+	}
+
+	if scanner.PathExists(mavenRepo) {
+		size, _ := scanner.CalculateDirSize(ctx, mavenRepo)
 		items = append(items, core.CleanableItem{
 			Path:        mavenRepo,
 			Description: "Maven Repository",
 			Size:        size,
 			Safe:        false, // Requires extra confirmation
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads every dependency over the network on the next Maven build; artifacts installed locally are lost",
 		})
 	}
 
@@ -211,26 +463,6 @@ func (p *JavaProvider) GetCleanableItems() ([]core.CleanableItem, error) {
 }
 
 // Clean executes cleaning for Java
-func (p *JavaProvider) Clean(items []core.CleanableItem) (*core.CleanResult, error) {
-	result := &core.CleanResult{
-		ItemsCleaned:   0,
-		SpaceReclaimed: 0,
-		Errors:         []error{},
-	}
-
-	for _, item := range items {
-		if item.Path != "" {
-			// Remove directory
-			expandedPath := scanner.ExpandHome(item.Path)
-			if err := os.RemoveAll(expandedPath); err != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("failed to clean %s: %w", item.Description, err))
-				continue
-			}
-		}
-
-		result.ItemsCleaned++
-		result.SpaceReclaimed += item.Size
-	}
-
-	return result, nil
+func (p *JavaProvider) Clean(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	return cleanItems(ctx, items, dryRun, cleanItem), nil
 }
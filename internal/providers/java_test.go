@@ -0,0 +1,95 @@
+package providers
+
+import "testing"
+
+func TestJavaVersionOutputs(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		version      string
+		distribution string
+	}{
+		{
+			name: "Oracle 1.8",
+			output: `java version "1.8.0_381"
+Java(TM) SE Runtime Environment (build 1.8.0_381-b09)
+Java HotSpot(TM) 64-Bit Server VM (build 25.381-b09, mixed mode)`,
+			version:      "1.8.0_381",
+			distribution: "Oracle",
+		},
+		{
+			name: "Oracle 21",
+			output: `java version "21.0.1" 2023-10-17 LTS
+Java(TM) SE Runtime Environment (build 21.0.1+12-LTS-29)
+Java HotSpot(TM) 64-Bit Server VM (build 21.0.1+12-LTS-29, mixed mode, sharing)`,
+			version:      "21.0.1",
+			distribution: "Oracle",
+		},
+		{
+			name: "OpenJDK 1.8",
+			output: `openjdk version "1.8.0_392"
+OpenJDK Runtime Environment (build 1.8.0_392-8u392-ga-1~22.04-b08)
+OpenJDK 64-Bit Server VM (build 25.392-b08, mixed mode)`,
+			version:      "1.8.0_392",
+			distribution: "OpenJDK",
+		},
+		{
+			name: "OpenJDK 17",
+			output: `openjdk version "17.0.9" 2023-10-17
+OpenJDK Runtime Environment (build 17.0.9+9-Debian-1deb12u1)
+OpenJDK 64-Bit Server VM (build 17.0.9+9-Debian-1deb12u1, mixed mode, sharing)`,
+			version:      "17.0.9",
+			distribution: "OpenJDK",
+		},
+		{
+			name: "Temurin",
+			output: `openjdk version "17.0.9" 2023-10-17
+OpenJDK Runtime Environment Temurin-17.0.9+9 (build 17.0.9+9)
+OpenJDK 64-Bit Server VM Temurin-17.0.9+9 (build 17.0.9+9, mixed mode, sharing)`,
+			version:      "17.0.9",
+			distribution: "Eclipse Temurin",
+		},
+		{
+			name: "Temurin 1.8",
+			output: `openjdk version "1.8.0_392"
+OpenJDK Runtime Environment (Temurin)(build 1.8.0_392-b08)
+OpenJDK 64-Bit Server VM (Temurin)(build 25.392-b08, mixed mode)`,
+			version:      "1.8.0_392",
+			distribution: "Eclipse Temurin",
+		},
+		{
+			name: "Zulu",
+			output: `openjdk version "11.0.21" 2023-10-17 LTS
+OpenJDK Runtime Environment Zulu11.68+17-CA (build 11.0.21+9-LTS)
+OpenJDK 64-Bit Server VM Zulu11.68+17-CA (build 11.0.21+9-LTS, mixed mode)`,
+			version:      "11.0.21",
+			distribution: "Azul Zulu",
+		},
+		{
+			name: "GraalVM",
+			output: `java version "21.0.1" 2023-10-17
+Java(TM) SE Runtime Environment Oracle GraalVM 21.0.1+12.1 (build 21.0.1+12-jvmci-23.1-b19)
+Java HotSpot(TM) 64-Bit Server VM Oracle GraalVM 21.0.1+12.1 (build 21.0.1+12-jvmci-23.1-b19, mixed mode, sharing)`,
+			version:      "21.0.1",
+			distribution: "GraalVM",
+		},
+		{
+			name: "Corretto",
+			output: `openjdk version "17.0.9" 2023-10-17 LTS
+OpenJDK Runtime Environment Corretto-17.0.9.8.1 (build 17.0.9+8-LTS)
+OpenJDK 64-Bit Server VM Corretto-17.0.9.8.1 (build 17.0.9+8-LTS, mixed mode, sharing)`,
+			version:      "17.0.9",
+			distribution: "Amazon Corretto",
+		},
+	}
+
+	provider := NewJavaProvider()
+	for _, tt := range tests {
+		if got := provider.parseVersion(tt.output); got != tt.version {
+			t.Errorf("%s: parseVersion = %q, want %q", tt.name, got, tt.version)
+		}
+		if got := provider.parseDistribution(tt.output); got != tt.distribution {
+			t.Errorf("%s: parseDistribution = %q, want %q", tt.name, got, tt.distribution)
+		}
+	}
+}
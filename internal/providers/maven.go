@@ -0,0 +1,146 @@
+package providers
+
+import (
+	"context"
+	"encoding/xml"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"dependency-hell-cli/internal/scanner"
+)
+
+// defaultMavenRepository is used when no settings override the local repository
+const defaultMavenRepository = "~/.m2/repository"
+
+// mavenSettings holds the parts of a Maven settings.xml that affect where artifacts live
+type mavenSettings struct {
+	LocalRepository string        `xml:"localRepository"`
+	Mirrors         []mavenMirror `xml:"mirrors>mirror"`
+}
+
+// mavenMirror is a repository mirror declared in settings.xml
+type mavenMirror struct {
+	ID       string `xml:"id"`
+	URL      string `xml:"url"`
+	MirrorOf string `xml:"mirrorOf"`
+}
+
+// mavenRepoLocalPattern matches -Dmaven.repo.local=<path> in MAVEN_OPTS
+var mavenRepoLocalPattern = regexp.MustCompile(`-Dmaven\.repo\.local=("[^"]+"|\S+)`)
+
+// mavenPropertyPattern matches ${...} property references in settings values
+var mavenPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// mavenSettingsFiles returns the settings files Maven reads, user settings first
+func mavenSettingsFiles() []string {
+	files := []string{"~/.m2/settings.xml"}
+	for _, name := range []string{"M2_HOME", "MAVEN_HOME"} {
+		if home := scanner.GetEnvVar(name); home != "" {
+			files = append(files, filepath.Join(home, "conf", "settings.xml"))
+		}
+	}
+	return files
+}
+
+// readMavenSettings parses a settings.xml file. Unreadable or malformed files yield empty settings.
+func readMavenSettings(path string) mavenSettings {
+	var settings mavenSettings
+
+	data, err := os.ReadFile(scanner.ExpandHome(path))
+	if err != nil {
+		return settings
+	}
+	if err := xml.Unmarshal(data, &settings); err != nil {
+		return mavenSettings{}
+	}
+
+	settings.LocalRepository = expandMavenProperties(strings.TrimSpace(settings.LocalRepository))
+	for i := range settings.Mirrors {
+		settings.Mirrors[i].URL = expandMavenProperties(strings.TrimSpace(settings.Mirrors[i].URL))
+	}
+
+	return settings
+}
+
+// expandMavenProperties resolves ${user.home} and ${env.NAME} references.
+// Unknown properties are left untouched.
+func expandMavenProperties(value string) string {
+	return mavenPropertyPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := mavenPropertyPattern.FindStringSubmatch(match)[1]
+		switch {
+		case name == "user.home":
+			if home, err := scanner.HomeDir(); err == nil {
+				return home
+			}
+		case strings.HasPrefix(name, "env."):
+			if value := os.Getenv(strings.TrimPrefix(name, "env.")); value != "" {
+				return value
+			}
+		}
+		return match
+	})
+}
+
+// mavenRepository returns the local repository Maven actually uses and where that setting came from.
+// The dhell config takes precedence over MAVEN_OPTS, which takes precedence over user settings, then global settings.
+func (p *JavaProvider) mavenRepository() (string, string) {
+	if repo, ok := scanner.ConfiguredPath("java", "maven_repository"); ok {
+		return repo, "dhell config"
+	}
+	if match := mavenRepoLocalPattern.FindStringSubmatch(os.Getenv("MAVEN_OPTS")); match != nil {
+		return expandMavenProperties(strings.Trim(match[1], `"`)), "MAVEN_OPTS"
+	}
+
+	for _, file := range mavenSettingsFiles() {
+		if repo := readMavenSettings(file).LocalRepository; repo != "" {
+			return repo, file
+		}
+	}
+
+	return defaultMavenRepository, "default"
+}
+
+// mavenMirrors returns the mirrors declared in all settings files, user settings first
+func (p *JavaProvider) mavenMirrors() []mavenMirror {
+	var mirrors []mavenMirror
+	seen := make(map[string]bool)
+
+	for _, file := range mavenSettingsFiles() {
+		for _, mirror := range readMavenSettings(file).Mirrors {
+			if mirror.ID == "" || seen[mirror.ID] {
+				continue
+			}
+			seen[mirror.ID] = true
+			mirrors = append(mirrors, mirror)
+		}
+	}
+
+	return mirrors
+}
+
+// localMirrorPath returns the directory of a file:// mirror, or "" for remote mirrors
+func localMirrorPath(url string) string {
+	if !strings.HasPrefix(url, "file:") {
+		return ""
+	}
+	path := strings.TrimPrefix(url, "file:")
+	path = strings.TrimPrefix(path, "//")
+	return filepath.FromSlash(path)
+}
+
+// mavenMarkerNames are the bookkeeping files Maven leaves next to artifacts: *.lastUpdated
+// records a failed or cached-miss download, _remote.repositories the repository an artifact came from
+var mavenMarkerNames = []string{"*.lastUpdated", "_remote.repositories"}
+
+// mavenMarkers counts the marker files under a local repository and their total size
+func mavenMarkers(ctx context.Context, repo string) (int, int64, error) {
+	count, size := 0, int64(0)
+	err := walkMatchingFiles(ctx, repo, mavenMarkerNames, func(path string, info fs.FileInfo) {
+		count++
+		size += info.Size()
+	})
+	return count, size, err
+}
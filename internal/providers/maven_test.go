@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+func TestMavenMarkersClean(t *testing.T) {
+	env := newFakeEnv(t)
+	artifact := env.writeFile(".m2/repository/org/example/lib/1.0/lib-1.0.jar", 4096)
+	lastUpdated := env.writeFile(".m2/repository/org/example/lib/1.0/lib-1.0.jar.lastUpdated", 100)
+	remote := env.writeFile(".m2/repository/org/example/lib/1.0/_remote.repositories", 200)
+
+	provider := NewJavaProvider()
+	items, err := provider.GetCleanableItems(context.Background())
+	if err != nil {
+		t.Fatalf("GetCleanableItems() error = %v", err)
+	}
+
+	var markers []core.CleanableItem
+	for _, item := range items {
+		if len(item.Patterns) > 0 {
+			markers = append(markers, item)
+		}
+	}
+	if len(markers) != 1 {
+		t.Fatalf("got %d marker items, want 1: %+v", len(markers), items)
+	}
+	if got := markers[0]; got.Size != 300 || scanner.ExpandHome(got.Path) != env.path(".m2/repository") || got.Command != "" || !strings.HasPrefix(got.Description, "Maven Download Markers") {
+		t.Fatalf("marker item = %+v, want 300 bytes of markers under the repository", got)
+	}
+
+	result, err := provider.Clean(context.Background(), markers, false)
+	if err != nil || len(result.Errors) > 0 {
+		t.Fatalf("Clean() = %+v, %v", result, err)
+	}
+	for _, path := range []string{lastUpdated, remote} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("marker %s still exists", path)
+		}
+	}
+	if _, err := os.Stat(artifact); err != nil {
+		t.Errorf("artifact was removed: %v", err)
+	}
+}
+
+func TestMavenMarkersStopWhenCancelled(t *testing.T) {
+	env := newFakeEnv(t)
+	env.writeFile(".m2/repository/org/example/lib/1.0/lib-1.0.jar.lastUpdated", 100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := mavenMarkers(ctx, env.path(".m2/repository")); !errors.Is(err, context.Canceled) {
+		t.Errorf("mavenMarkers() with a cancelled context = %v, want context.Canceled", err)
+	}
+	items, _ := NewJavaProvider().GetCleanableItems(ctx)
+	for _, item := range items {
+		if len(item.Patterns) > 0 {
+			t.Errorf("cancelled count still reported %+v", item)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package providers
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"dependency-hell-cli/internal/scanner"
+)
+
+// NodeGlobalBin is the directory a Node package manager installs global CLIs into
+type NodeGlobalBin struct {
+	Manager string
+	Dir     string
+}
+
+// NodeBinConflict is a global CLI installed by more than one package manager
+type NodeBinConflict struct {
+	Name string
+	Bins []NodeGlobalBin // Ordered by PATH position, directories not on PATH last
+}
+
+// nodeToolchainBinaries are the package managers and runtimes themselves, which every
+// manager's bin dir may legitimately contain
+var nodeToolchainBinaries = map[string]bool{
+	"node": true, "npm": true, "npx": true, "corepack": true,
+	"yarn": true, "yarnpkg": true, "pnpm": true, "pnpx": true,
+	"volta": true, "volta-shim": true, "volta-migrate": true,
+}
+
+// GlobalBinDirs returns the existing global bin directories of npm, pnpm, yarn and volta
+func (p *NodeProvider) GlobalBinDirs(ctx context.Context) []NodeGlobalBin {
+	candidates := []NodeGlobalBin{
+		{Manager: "npm", Dir: p.npmGlobalBin(ctx)},
+		{Manager: "pnpm", Dir: p.pnpmHome()},
+		{Manager: "yarn", Dir: p.yarnGlobalBin()},
+		{Manager: "volta", Dir: filepath.Join(p.voltaHome(), "bin")},
+	}
+
+	var bins []NodeGlobalBin
+	seen := make(map[string]bool)
+	for _, bin := range candidates {
+		dir := scanner.ExpandHome(bin.Dir)
+		if bin.Dir == "" || !scanner.PathExists(dir) || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		bins = append(bins, NodeGlobalBin{Manager: bin.Manager, Dir: dir})
+	}
+
+	return bins
+}
+
+// GlobalBinConflicts finds CLIs installed globally by more than one package manager
+func (p *NodeProvider) GlobalBinConflicts(ctx context.Context) []NodeBinConflict {
+	owners := make(map[string][]NodeGlobalBin)
+	for _, bin := range p.GlobalBinDirs(ctx) {
+		for _, name := range listExecutables(bin.Dir) {
+			name = strings.TrimSuffix(name, filepath.Ext(name)) // npm.cmd, tsc.ps1 on Windows
+			if nodeToolchainBinaries[name] {
+				continue
+			}
+			if owned := owners[name]; len(owned) > 0 && owned[len(owned)-1] == bin {
+				continue
+			}
+			owners[name] = append(owners[name], bin)
+		}
+	}
+
+	var conflicts []NodeBinConflict
+	for name, bins := range owners {
+		if len(bins) < 2 {
+			continue
+		}
+		sort.SliceStable(bins, func(i, j int) bool {
+			return pathRank(bins[i].Dir) < pathRank(bins[j].Dir)
+		})
+		conflicts = append(conflicts, NodeBinConflict{Name: name, Bins: bins})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Name < conflicts[j].Name })
+
+	return conflicts
+}
+
+// pathRank orders directories by PATH position, putting those not on PATH last
+func pathRank(dir string) int {
+	if index := scanner.PathIndex(dir); index != -1 {
+		return index
+	}
+	return int(^uint(0) >> 1)
+}
+
+// npmGlobalBin returns where npm install -g links binaries, honoring the prefix override
+func (p *NodeProvider) npmGlobalBin(ctx context.Context) string {
+	prefix := ""
+	for _, name := range []string{"npm_config_prefix", "NPM_CONFIG_PREFIX"} {
+		if value := scanner.GetEnvVar(name); value != "" {
+			prefix = value
+			break
+		}
+	}
+	if prefix == "" {
+		if _, err := scanner.FindExecutable("npm"); err != nil {
+			return ""
+		}
+		output, err := scanner.CommandOutput(ctx, "npm", "prefix", "-g")
+		if err != nil {
+			return ""
+		}
+		prefix = strings.TrimSpace(string(output))
+	}
+
+	// Windows puts global binaries directly in the prefix
+	if runtime.GOOS == "windows" {
+		return prefix
+	}
+	return filepath.Join(prefix, "bin")
+}
+
+// pnpmHome returns the pnpm global bin directory, honoring PNPM_HOME
+func (p *NodeProvider) pnpmHome() string {
+	if home := scanner.GetEnvVar("PNPM_HOME"); home != "" {
+		return home
+	}
+	if runtime.GOOS == "darwin" {
+		return "~/Library/pnpm"
+	}
+	return filepath.Join(scanner.XDGDataHome(), "pnpm")
+}
+
+// yarnGlobalBin returns the Yarn classic global bin directory
+func (p *NodeProvider) yarnGlobalBin() string {
+	if prefix := scanner.GetEnvVar("YARN_PREFIX"); prefix != "" {
+		return filepath.Join(prefix, "bin")
+	}
+	return "~/.yarn/bin"
+}
+
+// voltaHome returns the Volta directory, honoring VOLTA_HOME
+func (p *NodeProvider) voltaHome() string {
+	if home := scanner.GetEnvVar("VOLTA_HOME"); home != "" {
+		return home
+	}
+	return "~/.volta"
+}
@@ -0,0 +1,61 @@
+package providers
+
+import (
+	"path/filepath"
+	"runtime"
+
+	"dependency-hell-cli/internal/scanner"
+)
+
+// nodeBinaryCache is a cache of large binaries (browsers, Electron) downloaded by a Node tool
+type nodeBinaryCache struct {
+	Description string
+	EnvVar      string // Overrides the location when set
+	Name        string // Directory under the platform cache base
+	WindowsName string // Directory under %LOCALAPPDATA% on Windows, when it differs from Name
+}
+
+// nodeBinaryCaches are the browser and Electron downloads of common Node tooling.
+// They routinely reach several GB and are downloaded again on the next install.
+var nodeBinaryCaches = []nodeBinaryCache{
+	{Description: "Playwright Browsers", EnvVar: "PLAYWRIGHT_BROWSERS_PATH", Name: "ms-playwright"},
+	{Description: "Cypress Binaries", EnvVar: "CYPRESS_CACHE_FOLDER", Name: "Cypress", WindowsName: filepath.Join("Cypress", "Cache")},
+	{Description: "Electron Downloads", EnvVar: "ELECTRON_CACHE", Name: "electron", WindowsName: filepath.Join("electron", "Cache")},
+	{Description: "electron-builder Cache", EnvVar: "ELECTRON_BUILDER_CACHE", Name: "electron-builder", WindowsName: filepath.Join("electron-builder", "Cache")},
+}
+
+// binaryCacheDirs returns the location of each browser/Electron cache on this platform.
+// Puppeteer keeps ~/.cache/puppeteer on every platform.
+func (p *NodeProvider) binaryCacheDirs() []toolDir {
+	var dirs []toolDir
+	for _, cache := range nodeBinaryCaches {
+		dir := scanner.GetEnvVar(cache.EnvVar)
+		// PLAYWRIGHT_BROWSERS_PATH=0 keeps browsers in each project's node_modules, not a shared path
+		if dir == "" || dir == "0" {
+			dir = platformCacheDir(runtime.GOOS, cache.Name, cache.WindowsName)
+		}
+		dirs = append(dirs, toolDir{Description: cache.Description, Path: dir})
+	}
+
+	puppeteer := scanner.GetEnvVar("PUPPETEER_CACHE_DIR")
+	if puppeteer == "" {
+		puppeteer = filepath.Join(scanner.XDGCacheHome(), "puppeteer")
+	}
+	return append(dirs, toolDir{Description: "Puppeteer Browsers", Path: puppeteer})
+}
+
+// platformCacheDir returns where a tool following the OS conventions keeps its cache named name
+func platformCacheDir(goos, name, windowsName string) string {
+	switch goos {
+	case "darwin":
+		return filepath.Join("~/Library/Caches", name)
+	case "windows":
+		if windowsName == "" {
+			windowsName = name
+		}
+		if localAppData := scanner.GetEnvVar("LOCALAPPDATA"); localAppData != "" {
+			return filepath.Join(localAppData, windowsName)
+		}
+	}
+	return filepath.Join(scanner.XDGCacheHome(), name)
+}
@@ -1,8 +1,12 @@
 package providers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os/exec"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	"dependency-hell-cli/internal/core"
@@ -23,11 +27,11 @@ func (p *NodeProvider) Name() string {
 }
 
 // DetectInstalled detects installed Node.js versions
-func (p *NodeProvider) DetectInstalled() ([]core.Installation, error) {
+func (p *NodeProvider) DetectInstalled(ctx context.Context) ([]core.Installation, error) {
 	// Check if node is installed
 	nodePath, err := scanner.FindExecutable("node")
 	if err != nil {
-		return nil, fmt.Errorf("node not found in PATH")
+		return nil, notInstalledError("node not found in PATH")
 	}
 
 	// Resolve symlinks
@@ -37,26 +41,64 @@ func (p *NodeProvider) DetectInstalled() ([]core.Installation, error) {
 	}
 
 	// Get version
-	versionStr, err := scanner.GetExecutableVersion("node", "--version")
+	versionStr, err := scanner.GetExecutableVersion(ctx, "node", "--version")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get node version: %w", err)
+		return nil, versionError("node", err)
 	}
 
 	versionStr = strings.TrimSpace(versionStr)
 
 	// Determine source
-	source := p.determineSource(realPath)
+	source, sourceReason := p.determineSource(realPath)
 	managerName := p.getManagerName(realPath, source)
 
 	installation := core.Installation{
-		Version:     versionStr,
-		Source:      source,
-		BinaryPath:  nodePath,
-		ManagerPath: p.getManagerPath(realPath, source),
-		ManagerName: managerName,
+		Version:      versionStr,
+		Source:       source,
+		BinaryPath:   nodePath,
+		ManagerPath:  p.getManagerPath(realPath, source),
+		ManagerName:  managerName,
+		Arch:         scanner.HomebrewArch(realPath),
+		SourceReason: sourceReason,
 	}
 
-	return []core.Installation{installation}, nil
+	installations := []core.Installation{installation}
+
+	// Other versions installed by nvm
+	versionsDir := scanner.ExpandHome(filepath.Join(p.nvmVersionsDir(), "node"))
+	for _, version := range p.listNvmVersions() {
+		if version == versionStr {
+			continue
+		}
+		installations = append(installations, core.Installation{
+			Version:      version,
+			Source:       core.SourceVersionManager,
+			BinaryPath:   filepath.Join(versionsDir, version, "bin", "node"),
+			ManagerName:  "nvm",
+			SourceReason: fmt.Sprintf("installed under %s", versionsDir),
+		})
+	}
+
+	return installations, nil
+}
+
+// listNvmVersions lists the Node.js versions installed by nvm (e.g., "v20.11.0"), newest first
+func (p *NodeProvider) listNvmVersions() []string {
+	var versions []string
+
+	entries, err := os.ReadDir(scanner.ExpandHome(filepath.Join(p.nvmVersionsDir(), "node")))
+	if err != nil {
+		return versions
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
+	}
+
+	core.SortVersionsNewestFirst(versions)
+	return versions
 }
 
 // getManagerName returns the specific version manager name
@@ -72,18 +114,24 @@ func (p *NodeProvider) getManagerName(path string, source core.InstallSource) st
 	return ""
 }
 
+// BinaryName returns the executable used to detect the language
+func (p *NodeProvider) BinaryName() string {
+	return "node"
+}
+
+// ClassifySource classifies the install source of any resolved binary path
+func (p *NodeProvider) ClassifySource(realPath string) (core.InstallSource, string) {
+	return p.determineSource(realPath)
+}
+
 // determineSource determines the installation source based on path
-func (p *NodeProvider) determineSource(path string) core.InstallSource {
-	if strings.Contains(path, ".nvm") {
-		return core.SourceVersionManager
-	}
-	if strings.Contains(path, ".volta") {
-		return core.SourceVersionManager
-	}
-	if strings.Contains(path, "/opt/homebrew") || strings.Contains(path, "/usr/local/Cellar") {
-		return core.SourceHomebrew
+func (p *NodeProvider) determineSource(path string) (core.InstallSource, string) {
+	rules := []sourceRule{
+		{".nvm", core.SourceVersionManager},
+		{".volta", core.SourceVersionManager},
 	}
-	return core.SourceUnknown
+	rules = append(rules, homebrewRules...)
+	return classifySource(path, rules)
 }
 
 // getManagerPath extracts the manager path if applicable
@@ -103,14 +151,151 @@ func (p *NodeProvider) getManagerPath(path string, source core.InstallSource) st
 	return ""
 }
 
+// npmCacheDir returns the npm content cache, the _cacache of npm's cache directory
+func (p *NodeProvider) npmCacheDir() string {
+	return filepath.Join(p.npmCacheRoot(), "_cacache")
+}
+
+// npmCacheRoot returns npm's cache directory (npm config get cache). A configured npm_cache
+// means the same as npm_config_cache, so both overrides hold the content cache and logs.
+func (p *NodeProvider) npmCacheRoot() string {
+	if cache, ok := scanner.ConfiguredPath("node", "npm_cache"); ok {
+		return cache
+	}
+	for _, name := range []string{"npm_config_cache", "NPM_CONFIG_CACHE"} {
+		if cache := scanner.GetEnvVar(name); cache != "" {
+			return cache
+		}
+	}
+	return "~/.npm"
+}
+
+// logDirs returns the log directories of npm and pnpm. Yarn writes yarn-error.log
+// into the project instead, so it has no global log directory.
+func (p *NodeProvider) logDirs() []toolDir {
+	return []toolDir{
+		{"NPM Logs", p.npmLogsDir()},
+		{"PNPM State & Logs", p.pnpmStateDir()},
+	}
+}
+
+// npmLogsDir returns where npm writes its debug logs, honoring npm_config_logs_dir.
+// By default they sit next to the content cache in _logs.
+func (p *NodeProvider) npmLogsDir() string {
+	for _, name := range []string{"npm_config_logs_dir", "NPM_CONFIG_LOGS_DIR"} {
+		if dir := scanner.GetEnvVar(name); dir != "" {
+			return dir
+		}
+	}
+	return filepath.Join(p.npmCacheRoot(), "_logs")
+}
+
+// pnpmStateDir returns pnpm's state directory, honoring XDG_STATE_HOME
+func (p *NodeProvider) pnpmStateDir() string {
+	return filepath.Join(scanner.XDGStateHome(), "pnpm")
+}
+
+// corepackCacheDir returns the corepack cache, honoring COREPACK_HOME
+func (p *NodeProvider) corepackCacheDir() string {
+	if cache, ok := scanner.ConfiguredPath("node", "corepack_cache"); ok {
+		return cache
+	}
+	if corepackHome := scanner.GetEnvVar("COREPACK_HOME"); corepackHome != "" {
+		return corepackHome
+	}
+	if runtime.GOOS == "windows" {
+		if localAppData := scanner.GetEnvVar("LOCALAPPDATA"); localAppData != "" {
+			return filepath.Join(localAppData, "node", "corepack")
+		}
+	}
+	return filepath.Join(scanner.XDGCacheHome(), "node", "corepack")
+}
+
+// yarnClassicCacheDir returns the Yarn 1.x global cache. When yarn is installed, `yarn cache dir`
+// is authoritative; otherwise YARN_CACHE_FOLDER or the platform default is used.
+func (p *NodeProvider) yarnClassicCacheDir(ctx context.Context) string {
+	if cache, ok := scanner.ConfiguredPath("node", "yarn_cache"); ok {
+		return cache
+	}
+	if !p.isYarnBerry() {
+		if _, err := scanner.FindExecutable("yarn"); err == nil {
+			if output, err := scanner.CommandOutput(ctx, "yarn", "cache", "dir"); err == nil {
+				if dir := strings.TrimSpace(string(output)); dir != "" && filepath.IsAbs(dir) {
+					return dir
+				}
+			}
+		}
+	}
+	if cache := scanner.GetEnvVar("YARN_CACHE_FOLDER"); cache != "" {
+		return cache
+	}
+	return defaultYarnCacheDir(runtime.GOOS)
+}
+
+// defaultYarnCacheDir returns where Yarn classic keeps its cache on the given platform
+func defaultYarnCacheDir(goos string) string {
+	switch goos {
+	case "darwin":
+		return "~/Library/Caches/Yarn"
+	case "windows":
+		if localAppData := scanner.GetEnvVar("LOCALAPPDATA"); localAppData != "" {
+			return filepath.Join(localAppData, "Yarn", "Cache")
+		}
+	}
+	return filepath.Join(scanner.XDGCacheHome(), "yarn")
+}
+
+// yarnBerryCache returns the global cache used by Yarn 2+ (Berry)
+func (p *NodeProvider) yarnBerryCache() string {
+	if cache, ok := scanner.ConfiguredPath("node", "yarn_berry_cache"); ok {
+		return cache
+	}
+	return "~/.yarn/berry/cache"
+}
+
+// nvmVersionsDir returns the directory holding the Node versions installed by nvm
+func (p *NodeProvider) nvmVersionsDir() string {
+	if dir, ok := scanner.ConfiguredPath("node", "nvm_versions"); ok {
+		return dir
+	}
+	return "~/.nvm/versions"
+}
+
+// pnpmStoreDir returns the pnpm content-addressable store
+func (p *NodeProvider) pnpmStoreDir() string {
+	if store, ok := scanner.ConfiguredPath("node", "pnpm_store"); ok {
+		return store
+	}
+	return defaultPnpmStoreDir(runtime.GOOS)
+}
+
+// defaultPnpmStoreDir returns where pnpm keeps its store on the given platform
+func defaultPnpmStoreDir(goos string) string {
+	switch goos {
+	case "darwin":
+		return "~/Library/pnpm/store"
+	case "windows":
+		if localAppData := scanner.GetEnvVar("LOCALAPPDATA"); localAppData != "" {
+			return filepath.Join(localAppData, "pnpm", "store")
+		}
+	}
+	return filepath.Join(scanner.XDGDataHome(), "pnpm", "store")
+}
+
+// isYarnBerry reports whether a Yarn Berry global cache exists.
+// Classic Yarn never creates the berry subdirectory, so its presence distinguishes the two.
+func (p *NodeProvider) isYarnBerry() bool {
+	return scanner.PathExists(p.yarnBerryCache())
+}
+
 // GetGlobalCacheUsage calculates disk usage for Node.js ecosystem caches
-func (p *NodeProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
+func (p *NodeProvider) GetGlobalCacheUsage(ctx context.Context) (*core.DiskUsage, error) {
 	var items []core.DiskUsageItem
 
 	// NVM versions
-	nvmPath := "~/.nvm/versions"
+	nvmPath := p.nvmVersionsDir()
 	if scanner.PathExists(nvmPath) {
-		size, _ := scanner.CalculateDirSize(nvmPath)
+		size, _ := scanner.CalculateDirSize(ctx, nvmPath)
 		items = append(items, core.DiskUsageItem{
 			Path:        nvmPath,
 			Description: "NVM Versions",
@@ -119,9 +304,9 @@ func (p *NodeProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 	}
 
 	// NPM cache
-	npmCache := "~/.npm/_cacache"
+	npmCache := p.npmCacheDir()
 	if scanner.PathExists(npmCache) {
-		size, _ := scanner.CalculateDirSize(npmCache)
+		size, _ := scanner.CalculateDirSize(ctx, npmCache)
 		items = append(items, core.DiskUsageItem{
 			Path:        npmCache,
 			Description: "NPM Cache",
@@ -129,10 +314,10 @@ func (p *NodeProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Yarn cache (macOS)
-	yarnCache := "~/Library/Caches/Yarn"
+	// Yarn classic cache
+	yarnCache := p.yarnClassicCacheDir(ctx)
 	if scanner.PathExists(yarnCache) {
-		size, _ := scanner.CalculateDirSize(yarnCache)
+		size, _ := scanner.CalculateDirSize(ctx, yarnCache)
 		items = append(items, core.DiskUsageItem{
 			Path:        yarnCache,
 			Description: "Yarn Cache",
@@ -140,21 +325,32 @@ func (p *NodeProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Yarn v2+ cache
-	yarnV2Cache := "~/.yarn"
-	if scanner.PathExists(yarnV2Cache) {
-		size, _ := scanner.CalculateDirSize(yarnV2Cache)
+	// Yarn Berry (v2+) global cache
+	if p.isYarnBerry() {
+		yarnBerryCache := p.yarnBerryCache()
+		size, _ := scanner.CalculateDirSize(ctx, yarnBerryCache)
+		items = append(items, core.DiskUsageItem{
+			Path:        yarnBerryCache,
+			Description: "Yarn Berry Cache",
+			Size:        size,
+		})
+	}
+
+	// Corepack cache (package manager downloads and shims)
+	corepackCache := p.corepackCacheDir()
+	if scanner.PathExists(corepackCache) {
+		size, _ := scanner.CalculateDirSize(ctx, corepackCache)
 		items = append(items, core.DiskUsageItem{
-			Path:        yarnV2Cache,
-			Description: "Yarn v2+ Cache",
+			Path:        corepackCache,
+			Description: "Corepack Cache",
 			Size:        size,
 		})
 	}
 
 	// PNPM store (the big one!)
-	pnpmStore := "~/.local/share/pnpm/store"
+	pnpmStore := p.pnpmStoreDir()
 	if scanner.PathExists(pnpmStore) {
-		size, _ := scanner.CalculateDirSize(pnpmStore)
+		size, _ := scanner.CalculateDirSize(ctx, pnpmStore)
 		items = append(items, core.DiskUsageItem{
 			Path:        pnpmStore,
 			Description: "PNPM Store",
@@ -162,24 +358,39 @@ func (p *NodeProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Calculate total
-	var total int64
-	for _, item := range items {
-		total += item.Size
+	// Browsers and Electron builds downloaded by test and desktop tooling
+	for _, cache := range p.binaryCacheDirs() {
+		if scanner.PathExists(cache.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, cache.Path)
+			items = append(items, core.DiskUsageItem{
+				Path:        cache.Path,
+				Description: cache.Description,
+				Size:        size,
+			})
+		}
+	}
+
+	// Package manager logs pile up with every failed or verbose run
+	for _, logs := range p.logDirs() {
+		if scanner.PathExists(logs.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, logs.Path)
+			items = append(items, core.DiskUsageItem{
+				Path:        logs.Path,
+				Description: logs.Description,
+				Size:        size,
+			})
+		}
 	}
 
-	return &core.DiskUsage{
-		Items: items,
-		Total: total,
-	}, nil
+	return newDiskUsage(items), nil
 }
 
 // GetEnvVars returns relevant environment variables
-func (p *NodeProvider) GetEnvVars() map[string]string {
+func (p *NodeProvider) GetEnvVars(ctx context.Context) map[string]string {
 	vars := make(map[string]string)
 
-	// Common Node.js environment variables
-	envVars := []string{"NODE_PATH", "NPM_CONFIG_PREFIX", "NVM_DIR"}
+	// Common Node.js environment variables, including those that change builds and caching
+	envVars := []string{"NODE_PATH", "NODE_OPTIONS", "NPM_CONFIG_PREFIX", "npm_config_cache", "NPM_CONFIG_CACHE", "NVM_DIR", "COREPACK_HOME"}
 	for _, name := range envVars {
 		if value := scanner.GetEnvVar(name); value != "" {
 			vars[name] = value
@@ -189,71 +400,197 @@ func (p *NodeProvider) GetEnvVars() map[string]string {
 	return vars
 }
 
+// GetInfoSections returns the package manager the current project pins and how much of the
+// pnpm store pnpm store prune could free for the info command
+func (p *NodeProvider) GetInfoSections(ctx context.Context) []core.InfoSection {
+	var sections []core.InfoSection
+
+	if manifest, packageManager := findPackageManager(); packageManager != "" {
+		sections = append(sections, core.InfoSection{
+			Title: "Package Manager",
+			Items: []core.DiskUsageItem{{
+				Description: fmt.Sprintf("packageManager in %s (used by corepack)", manifest),
+				Value:       packageManager,
+			}},
+		})
+	}
+
+	if pnpmStore := p.pnpmStoreDir(); scanner.PathExists(pnpmStore) {
+		total, _ := scanner.CalculateDirSize(ctx, pnpmStore)
+		sections = append(sections, core.InfoSection{
+			Title: "PNPM Store",
+			Items: []core.DiskUsageItem{
+				{Path: pnpmStore, Description: "Total", Size: total},
+				{Path: pnpmStore, Description: "Unreferenced (freed by pnpm store prune, estimate)", Size: p.pnpmPruneEstimate()},
+			},
+		})
+	}
+
+	return expandInfoSections(sections)
+}
+
+// findPackageManager returns the nearest package.json from the working directory up that sets
+// the packageManager field, and the value of that field (e.g., "pnpm@8.15.4")
+func findPackageManager() (string, string) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", ""
+	}
+
+	for {
+		manifest := filepath.Join(dir, "package.json")
+		if data, err := os.ReadFile(manifest); err == nil {
+			var pkg struct {
+				PackageManager string `json:"packageManager"`
+			}
+			if json.Unmarshal(data, &pkg) == nil && pkg.PackageManager != "" {
+				return manifest, pkg.PackageManager
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
 // GetCleanableItems returns items that can be cleaned for Node.js
-func (p *NodeProvider) GetCleanableItems() ([]core.CleanableItem, error) {
+func (p *NodeProvider) GetCleanableItems(ctx context.Context) ([]core.CleanableItem, error) {
 	var items []core.CleanableItem
 
 	// NPM cache (safe)
-	npmCache := "~/.npm/_cacache"
+	npmCache := p.npmCacheDir()
 	if scanner.PathExists(npmCache) {
-		size, _ := scanner.CalculateDirSize(npmCache)
+		size, _ := scanner.CalculateDirSize(ctx, npmCache)
 		items = append(items, core.CleanableItem{
 			Description: "NPM Cache",
 			Command:     "npm cache clean --force",
 			Size:        size,
 			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads packages on the next npm install",
 		})
 	}
 
-	// Yarn cache (safe)
-	yarnCache := "~/Library/Caches/Yarn"
+	// Yarn classic cache (safe)
+	yarnCache := p.yarnClassicCacheDir(ctx)
 	if scanner.PathExists(yarnCache) {
-		size, _ := scanner.CalculateDirSize(yarnCache)
+		size, _ := scanner.CalculateDirSize(ctx, yarnCache)
 		items = append(items, core.CleanableItem{
 			Description: "Yarn Cache",
 			Command:     "yarn cache clean",
 			Size:        size,
 			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads packages on the next yarn install",
 		})
 	}
 
-	// PNPM store (safe - pnpm store prune removes unreferenced packages)
-	pnpmStore := "~/.local/share/pnpm/store"
-	if scanner.PathExists(pnpmStore) {
-		size, _ := scanner.CalculateDirSize(pnpmStore)
+	// Yarn Berry global cache (safe - packages are re-fetched on install)
+	if p.isYarnBerry() {
+		yarnBerryCache := p.yarnBerryCache()
+		size, _ := scanner.CalculateDirSize(ctx, yarnBerryCache)
 		items = append(items, core.CleanableItem{
-			Description: "PNPM Store",
-			Command:     "pnpm store prune",
+			Path:        yarnBerryCache,
+			Description: "Yarn Berry Cache",
+			Command:     "yarn cache clean --mirror",
 			Size:        size,
 			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads packages on the next yarn install",
+		})
+	}
+
+	// Corepack cache (safe - package managers are re-downloaded on demand)
+	corepackCache := p.corepackCacheDir()
+	if scanner.PathExists(corepackCache) {
+		size, _ := scanner.CalculateDirSize(ctx, corepackCache)
+		items = append(items, core.CleanableItem{
+			Path:        corepackCache,
+			Description: "Corepack Cache",
+			Size:        size,
+			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads package managers the next time one runs",
+		})
+	}
+
+	// PNPM store (needs confirmation - a project whose node_modules was deleted but that is
+	// installed again must re-download what prune removes). Only unreferenced content is
+	// removed, so the estimate is the part no project links to.
+	pnpmStore := p.pnpmStoreDir()
+	if scanner.PathExists(pnpmStore) {
+		items = append(items, core.CleanableItem{
+			Path:        pnpmStore,
+			Description: "PNPM Store",
+			Command:     "pnpm store prune --force",
+			Size:        p.pnpmPruneEstimate(),
+			Safe:        false,
+			Reclaim:     core.ReclaimUpperBound,
+			RebuildCost: "re-downloads pruned packages when a project installs them again",
 		})
 	}
 
+	// Browser and Electron downloads (safe - fetched again on the next install)
+	for _, cache := range p.binaryCacheDirs() {
+		if scanner.PathExists(cache.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, cache.Path)
+			items = append(items, core.CleanableItem{
+				Path:        cache.Path,
+				Description: cache.Description,
+				Size:        size,
+				Safe:        true,
+				Reclaim:     core.ReclaimRegenerable,
+				RebuildCost: "re-downloads binaries on the next install that needs them",
+			})
+		}
+	}
+
+	// Package manager logs (safe - nothing reads them back)
+	for _, logs := range p.logDirs() {
+		if scanner.PathExists(logs.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, logs.Path)
+			items = append(items, core.CleanableItem{
+				Path:        logs.Path,
+				Description: logs.Description,
+				Size:        size,
+				Safe:        true,
+				Reclaim:     core.ReclaimExact,
+				RebuildCost: "none; nothing reads them back",
+			})
+		}
+	}
+
 	return items, nil
 }
 
 // Clean executes cleaning for Node.js
-func (p *NodeProvider) Clean(items []core.CleanableItem) (*core.CleanResult, error) {
-	result := &core.CleanResult{
-		ItemsCleaned:   0,
-		SpaceReclaimed: 0,
-		Errors:         []error{},
-	}
-
-	for _, item := range items {
-		if item.Command != "" {
-			// Execute clean command
-			parts := strings.Fields(item.Command)
-			cmd := exec.Command(parts[0], parts[1:]...)
-			if err := cmd.Run(); err != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("failed to clean %s: %w", item.Description, err))
-				continue
-			}
+func (p *NodeProvider) Clean(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	return cleanItems(ctx, items, dryRun, func(ctx context.Context, item core.CleanableItem) (int64, error) {
+		if item.Command == "" || item.Path == "" {
+			return cleanItem(ctx, item)
 		}
 
-		result.ItemsCleaned++
-		result.SpaceReclaimed += item.Size
-	}
+		// Commands that prune a directory free an unknown share of it; measure what they freed
+		before, _ := scanner.FreshDirSize(ctx, item.Path)
+		if err := runCleanCommand(ctx, item); err != nil {
+			return 0, err
+		}
+		if after, err := scanner.FreshDirSize(ctx, item.Path); err == nil && after <= before {
+			return before - after, nil
+		}
+		return item.Size, nil
+	}), nil
+}
 
-	return result, nil
+// pnpmPruneEstimate estimates what pnpm store prune frees: the store files not hard linked into
+// any project. Where link counts are unavailable, the whole store is the estimate.
+func (p *NodeProvider) pnpmPruneEstimate() int64 {
+	total, unlinked, ok := scanner.UnlinkedSize(p.pnpmStoreDir())
+	if !ok {
+		return total
+	}
+	return unlinked
 }
@@ -0,0 +1,142 @@
+package providers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+func TestNodeProviderFixture(t *testing.T) {
+	env := newFakeEnv(t)
+	nodeBinary := env.addBinary("node", ".nvm/versions/node/v20.11.0/bin/node")
+	env.setOutput("node --version", "v20.11.0")
+	env.writeFile(".npm/_cacache/content-v2/sha512/ab/cd", 2048)
+
+	provider := NewNodeProvider()
+
+	installations, err := provider.DetectInstalled(context.Background())
+	if err != nil {
+		t.Fatalf("DetectInstalled() error = %v", err)
+	}
+	active := installations[0]
+	if active.Version != "v20.11.0" || active.BinaryPath != nodeBinary {
+		t.Errorf("DetectInstalled() = version %q binary %q, want v20.11.0 %s", active.Version, active.BinaryPath, nodeBinary)
+	}
+	if active.Source != core.SourceVersionManager || active.ManagerName != "nvm" {
+		t.Errorf("DetectInstalled() = source %s manager %q, want Version Manager nvm", active.Source, active.ManagerName)
+	}
+
+	usage, err := provider.GetGlobalCacheUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetGlobalCacheUsage() error = %v", err)
+	}
+	if got := findItem(t, usage, "NPM Cache"); got.Size != 2048 || got.Path != env.path(".npm/_cacache") {
+		t.Errorf("NPM Cache = %s (%d bytes), want %s (2048 bytes)", got.Path, got.Size, env.path(".npm/_cacache"))
+	}
+}
+
+func TestNodePackageManagerIsAValueNotAPath(t *testing.T) {
+	env := newFakeEnv(t)
+	manifest := env.writeFile("project/package.json", 0)
+	if err := os.WriteFile(manifest, []byte(`{"packageManager": "pnpm@8.15.4"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(filepath.Dir(manifest))
+
+	for _, section := range NewNodeProvider().GetInfoSections(context.Background()) {
+		if section.Title != "Package Manager" {
+			continue
+		}
+		item := section.Items[0]
+		if item.Value != "pnpm@8.15.4" || item.Path != "" {
+			t.Fatalf("Package Manager item = path %q value %q, want no path and value pnpm@8.15.4", item.Path, item.Value)
+		}
+		return
+	}
+	t.Fatal("no Package Manager section")
+}
+
+func TestNodeNpmCacheOverrides(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		configure func(env *fakeEnv)
+	}{
+		{"npm_config_cache", func(env *fakeEnv) { t.Setenv("npm_config_cache", env.path("npm-root")) }},
+		{"cache_paths npm_cache", func(env *fakeEnv) {
+			scanner.SetPathTemplates(map[string]map[string]string{"node": {"npm_cache": env.path("npm-root")}})
+			t.Cleanup(func() { scanner.SetPathTemplates(nil) })
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newFakeEnv(t)
+			tt.configure(env)
+
+			provider := NewNodeProvider()
+			if got, want := scanner.ExpandHome(provider.npmCacheDir()), env.path("npm-root/_cacache"); got != want {
+				t.Errorf("npm cache = %s, want %s", got, want)
+			}
+			if got, want := scanner.ExpandHome(provider.npmLogsDir()), env.path("npm-root/_logs"); got != want {
+				t.Errorf("npm logs = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestPnpmStoreFollowsXDGDataHome(t *testing.T) {
+	data := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", data)
+	if got, want := defaultPnpmStoreDir("linux"), filepath.Join(data, "pnpm", "store"); got != want {
+		t.Errorf("defaultPnpmStoreDir(linux) = %q, want %q", got, want)
+	}
+
+	t.Setenv("XDG_DATA_HOME", "relative/data")
+	if got, want := defaultPnpmStoreDir("linux"), filepath.Join("~/.local/share", "pnpm", "store"); got != want {
+		t.Errorf("defaultPnpmStoreDir(linux) with a relative XDG_DATA_HOME = %q, want %q", got, want)
+	}
+}
+
+func TestYarnClassicCacheOnLinux(t *testing.T) {
+	newFakeEnv(t)
+	if got, want := defaultYarnCacheDir("linux"), filepath.Join("~/.cache", "yarn"); got != want {
+		t.Errorf("defaultYarnCacheDir(linux) = %q, want %q", got, want)
+	}
+	if got := defaultYarnCacheDir("darwin"); got != "~/Library/Caches/Yarn" {
+		t.Errorf("defaultYarnCacheDir(darwin) = %q, want ~/Library/Caches/Yarn", got)
+	}
+}
+
+func TestYarnCacheDirOverridesDefault(t *testing.T) {
+	env := newFakeEnv(t)
+	env.addBinary("yarn", "bin/yarn")
+	env.setOutput("yarn cache dir", env.path("yarn-cache/v6")+"\n")
+	env.writeFile("yarn-cache/v6/npm-lodash-4.17.21/package.json", 1500)
+	env.writeFile(".cache/yarn/v6/npm-left-pad-1.3.0/package.json", 99)
+
+	provider := NewNodeProvider()
+	usage, err := provider.GetGlobalCacheUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetGlobalCacheUsage() error = %v", err)
+	}
+	if got := findItem(t, usage, "Yarn Cache"); got.Path != env.path("yarn-cache/v6") || got.Size != 1500 {
+		t.Errorf("Yarn Cache = %s (%d bytes), want %s (1500 bytes)", got.Path, got.Size, env.path("yarn-cache/v6"))
+	}
+
+	items, err := provider.GetCleanableItems(context.Background())
+	if err != nil {
+		t.Fatalf("GetCleanableItems() error = %v", err)
+	}
+	// yarn cache clean empties the cache; the item is sized from the directory yarn reported
+	for _, item := range items {
+		if item.Description == "Yarn Cache" {
+			if item.Size != 1500 {
+				t.Errorf("cleanable Yarn Cache = %d bytes, want 1500", item.Size)
+			}
+			return
+		}
+	}
+	t.Error("no cleanable Yarn Cache")
+}
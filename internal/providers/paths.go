@@ -0,0 +1,13 @@
+package providers
+
+// CachePathKeys lists, per language, the paths the cache_paths config can override.
+// Each key replaces the provider's default (and any environment variable it honors).
+var CachePathKeys = map[string][]string{
+	"go":     {"goenv_root"},
+	"node":   {"npm_cache", "yarn_cache", "yarn_berry_cache", "corepack_cache", "pnpm_store", "nvm_versions"},
+	"java":   {"maven_repository", "gradle_home"},
+	"python": {"pip_cache", "pyenv_root", "virtualenvs"},
+	"php":    {"composer_home", "composer_cache"},
+	"rust":   {"cargo_home", "cargo_registry", "cargo_git", "rustup_toolchains"},
+	"deno":   {"deno_dir"},
+}
@@ -1,8 +1,13 @@
 package providers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 
 	"dependency-hell-cli/internal/core"
@@ -23,11 +28,11 @@ func (p *PHPProvider) Name() string {
 }
 
 // DetectInstalled detects installed PHP versions
-func (p *PHPProvider) DetectInstalled() ([]core.Installation, error) {
+func (p *PHPProvider) DetectInstalled(ctx context.Context) ([]core.Installation, error) {
 	// Check if php is installed
 	phpPath, err := scanner.FindExecutable("php")
 	if err != nil {
-		return nil, fmt.Errorf("php not found in PATH")
+		return nil, notInstalledError("php not found in PATH")
 	}
 
 	// Resolve symlinks
@@ -37,22 +42,24 @@ func (p *PHPProvider) DetectInstalled() ([]core.Installation, error) {
 	}
 
 	// Get version
-	version, err := scanner.GetExecutableVersion("php", "--version")
+	version, err := scanner.GetExecutableVersion(ctx, "php", "--version")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get php version: %w", err)
+		return nil, versionError("php", err)
 	}
 
 	// Parse version (e.g., "PHP 8.2.0 (cli) ...")
 	versionStr := p.parseVersion(version)
 
 	// Determine source
-	source := p.determineSource(realPath)
+	source, sourceReason := p.determineSource(realPath)
 
 	installation := core.Installation{
-		Version:     versionStr,
-		Source:      source,
-		BinaryPath:  phpPath,
-		ManagerPath: p.getManagerPath(realPath, source),
+		Version:      versionStr,
+		Source:       source,
+		BinaryPath:   phpPath,
+		ManagerPath:  p.getManagerPath(realPath, source),
+		Arch:         scanner.HomebrewArch(realPath),
+		SourceReason: sourceReason,
 	}
 
 	return []core.Installation{installation}, nil
@@ -74,18 +81,22 @@ func (p *PHPProvider) parseVersion(output string) string {
 	return "unknown"
 }
 
+// BinaryName returns the executable used to detect the language
+func (p *PHPProvider) BinaryName() string {
+	return "php"
+}
+
+// ClassifySource classifies the install source of any resolved binary path
+func (p *PHPProvider) ClassifySource(realPath string) (core.InstallSource, string) {
+	return p.determineSource(realPath)
+}
+
 // determineSource determines the installation source based on path
-func (p *PHPProvider) determineSource(path string) core.InstallSource {
-	if strings.Contains(path, ".phpenv") {
-		return core.SourceVersionManager
-	}
-	if strings.Contains(path, "/opt/homebrew") || strings.Contains(path, "/usr/local/Cellar") {
-		return core.SourceHomebrew
-	}
-	if strings.Contains(path, "/usr/bin/php") {
-		return core.SourceSystem
-	}
-	return core.SourceUnknown
+func (p *PHPProvider) determineSource(path string) (core.InstallSource, string) {
+	rules := []sourceRule{{".phpenv", core.SourceVersionManager}}
+	rules = append(rules, homebrewRules...)
+	rules = append(rules, sourceRule{"/usr/bin/php", core.SourceSystem})
+	return classifySource(path, rules)
 }
 
 // getManagerPath extracts the manager path if applicable
@@ -99,7 +110,7 @@ func (p *PHPProvider) getManagerPath(path string, source core.InstallSource) str
 }
 
 // GetGlobalCacheUsage calculates disk usage for PHP ecosystem
-func (p *PHPProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
+func (p *PHPProvider) GetGlobalCacheUsage(ctx context.Context) (*core.DiskUsage, error) {
 	var items []core.DiskUsageItem
 
 	// PHP installation (if via Homebrew)
@@ -111,7 +122,7 @@ func (p *PHPProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 			if idx := strings.Index(realPath, "/Cellar/php"); idx != -1 {
 				phpDir := realPath[:strings.Index(realPath[idx:], "/bin")+idx]
 				if scanner.PathExists(phpDir) {
-					size, _ := scanner.CalculateDirSize(phpDir)
+					size, _ := scanner.CalculateDirSize(ctx, phpDir)
 					items = append(items, core.DiskUsageItem{
 						Path:        phpDir,
 						Description: "PHP Installation",
@@ -122,21 +133,22 @@ func (p *PHPProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		}
 	}
 
-	// Composer cache
-	composerCache := "~/.composer/cache"
-	if scanner.PathExists(composerCache) {
-		size, _ := scanner.CalculateDirSize(composerCache)
-		items = append(items, core.DiskUsageItem{
-			Path:        composerCache,
-			Description: "Composer Cache",
-			Size:        size,
-		})
+	// Composer cache, split into repository metadata, package downloads and VCS clones
+	for _, dir := range p.composerCacheDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.DiskUsageItem{
+				Path:        dir.Path,
+				Description: dir.Description,
+				Size:        size,
+			})
+		}
 	}
 
 	// Composer vendor (global packages)
-	composerVendor := "~/.composer/vendor"
+	composerVendor := filepath.Join(p.composerHome(), "vendor")
 	if scanner.PathExists(composerVendor) {
-		size, _ := scanner.CalculateDirSize(composerVendor)
+		size, _ := scanner.CalculateDirSize(ctx, composerVendor)
 		items = append(items, core.DiskUsageItem{
 			Path:        composerVendor,
 			Description: "Composer Global Packages",
@@ -144,23 +156,152 @@ func (p *PHPProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Calculate total
-	var total int64
-	for _, item := range items {
-		total += item.Size
+	return newDiskUsage(items), nil
+}
+
+// composerGlobalConfig is the part of the global composer.json that dhell reads
+type composerGlobalConfig struct {
+	Require map[string]string `json:"require"`
+	Config  struct {
+		BinDir string `json:"bin-dir"`
+	} `json:"config"`
+}
+
+// composerHome returns the Composer home directory, honoring COMPOSER_HOME.
+// Without it, Composer uses ~/.composer if present and the XDG location otherwise.
+func (p *PHPProvider) composerHome() string {
+	if home, ok := scanner.ConfiguredPath("php", "composer_home"); ok {
+		return home
+	}
+	if home := scanner.GetEnvVar("COMPOSER_HOME"); home != "" {
+		return home
+	}
+	if !scanner.PathExists("~/.composer") && scanner.PathExists("~/.config/composer") {
+		return "~/.config/composer"
 	}
+	return "~/.composer"
+}
+
+// composerCacheDir returns Composer's cache directory, honoring COMPOSER_CACHE_DIR.
+// Without it, Composer uses the platform cache directory when its home follows XDG or on macOS,
+// and the cache directory inside its home otherwise.
+func (p *PHPProvider) composerCacheDir() string {
+	if cache, ok := scanner.ConfiguredPath("php", "composer_cache"); ok {
+		return cache
+	}
+	if cache := scanner.GetEnvVar("COMPOSER_CACHE_DIR"); cache != "" {
+		return cache
+	}
+
+	home := p.composerHome()
+	switch {
+	case runtime.GOOS == "darwin" && home == "~/.composer":
+		return "~/Library/Caches/composer"
+	case home == "~/.config/composer":
+		return filepath.Join(scanner.XDGCacheHome(), "composer")
+	}
+	return filepath.Join(home, "cache")
+}
+
+// composerCacheSubdir describes a subdirectory of the Composer cache
+type composerCacheSubdir struct {
+	toolDir
+	RebuildCost string
+}
 
-	return &core.DiskUsage{
-		Items: items,
-		Total: total,
-	}, nil
+// composerCacheDirs returns the parts of the Composer cache: repo holds package metadata,
+// files the downloaded zips and vcs the clones of VCS repositories
+func (p *PHPProvider) composerCacheDirs() []composerCacheSubdir {
+	cache := p.composerCacheDir()
+	return []composerCacheSubdir{
+		{toolDir{"Composer Metadata Cache", filepath.Join(cache, "repo")}, "re-fetches package metadata on the next composer update or require"},
+		{toolDir{"Composer Package Cache", filepath.Join(cache, "files")}, "re-downloads package zips on the next composer install, which is slower on large projects"},
+		{toolDir{"Composer VCS Cache", filepath.Join(cache, "vcs")}, "re-clones VCS repositories on the next composer install"},
+	}
+}
+
+// readComposerGlobalConfig parses the global composer.json, returning an empty config on failure
+func (p *PHPProvider) readComposerGlobalConfig() composerGlobalConfig {
+	var config composerGlobalConfig
+
+	data, err := os.ReadFile(scanner.ExpandHome(filepath.Join(p.composerHome(), "composer.json")))
+	if err != nil {
+		return config
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return composerGlobalConfig{}
+	}
+
+	return config
+}
+
+// ComposerGlobalBin returns the directory global Composer packages install binaries into
+func (p *PHPProvider) ComposerGlobalBin() string {
+	if binDir := scanner.GetEnvVar("COMPOSER_BIN_DIR"); binDir != "" {
+		return binDir
+	}
+	if binDir := p.readComposerGlobalConfig().Config.BinDir; binDir != "" {
+		if filepath.IsAbs(binDir) {
+			return binDir
+		}
+		return filepath.Join(scanner.ExpandHome(p.composerHome()), binDir)
+	}
+	return filepath.Join(scanner.ExpandHome(p.composerHome()), "vendor", "bin")
+}
+
+// ComposerGlobalBinaries lists the executables in the global Composer bin directory
+func (p *PHPProvider) ComposerGlobalBinaries() []string {
+	return listExecutables(p.ComposerGlobalBin())
+}
+
+// composerGlobalPackages lists the global packages required in composer.json as "name constraint"
+func (p *PHPProvider) composerGlobalPackages() []string {
+	var packages []string
+	for name, constraint := range p.readComposerGlobalConfig().Require {
+		packages = append(packages, fmt.Sprintf("%s %s", name, constraint))
+	}
+	sort.Strings(packages)
+	return packages
+}
+
+// GetInfoSections returns global Composer details for the info command
+func (p *PHPProvider) GetInfoSections(ctx context.Context) []core.InfoSection {
+	binDir := p.ComposerGlobalBin()
+	if !scanner.PathExists(binDir) {
+		return nil
+	}
+
+	onPath := "not on PATH"
+	if scanner.PathIndex(binDir) != -1 {
+		onPath = "on PATH"
+	}
+
+	size, _ := scanner.CalculateDirSize(ctx, binDir)
+	section := core.InfoSection{
+		Title: "Composer Global",
+		Items: []core.DiskUsageItem{{
+			Path:        binDir,
+			Description: fmt.Sprintf("Bin Directory (%s, %d binaries)", onPath, len(p.ComposerGlobalBinaries())),
+			Size:        size,
+		}},
+	}
+
+	for _, pkg := range p.composerGlobalPackages() {
+		name, constraint, _ := strings.Cut(pkg, " ")
+		section.Items = append(section.Items, core.DiskUsageItem{
+			Description: name,
+			Value:       constraint,
+		})
+	}
+
+	return expandInfoSections([]core.InfoSection{section})
 }
 
 // GetEnvVars returns relevant environment variables
-func (p *PHPProvider) GetEnvVars() map[string]string {
+func (p *PHPProvider) GetEnvVars(ctx context.Context) map[string]string {
 	vars := make(map[string]string)
 
-	envVars := []string{"COMPOSER_HOME", "PHP_INI_SCAN_DIR"}
+	envVars := []string{"COMPOSER_HOME", "COMPOSER_CACHE_DIR", "COMPOSER_BIN_DIR", "PHP_INI_SCAN_DIR"}
 	for _, name := range envVars {
 		if value := scanner.GetEnvVar(name); value != "" {
 			vars[name] = value
@@ -171,46 +312,32 @@ func (p *PHPProvider) GetEnvVars() map[string]string {
 }
 
 // GetCleanableItems returns items that can be cleaned for PHP
-func (p *PHPProvider) GetCleanableItems() ([]core.CleanableItem, error) {
+func (p *PHPProvider) GetCleanableItems(ctx context.Context) ([]core.CleanableItem, error) {
 	var items []core.CleanableItem
 
-	// Composer cache (safe)
-	composerCache := "~/.composer/cache"
-	if scanner.PathExists(composerCache) {
-		size, _ := scanner.CalculateDirSize(composerCache)
-		items = append(items, core.CleanableItem{
-			Description: "Composer Cache",
-			Command:     "composer clear-cache",
-			Size:        size,
-			Safe:        true,
-		})
+	// Composer cache parts (safe - each can be cleared on its own and is fetched again on demand;
+	// together they are what composer clear-cache removes)
+	for _, dir := range p.composerCacheDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.CleanableItem{
+				Path:        dir.Path,
+				Description: dir.Description,
+				Size:        size,
+				Safe:        true,
+				Reclaim:     core.ReclaimRegenerable,
+				RebuildCost: dir.RebuildCost,
+			})
+		}
 	}
 
 	return items, nil
 }
 
 // Clean executes cleaning for PHP
-func (p *PHPProvider) Clean(items []core.CleanableItem) (*core.CleanResult, error) {
-	result := &core.CleanResult{
-		ItemsCleaned:   0,
-		SpaceReclaimed: 0,
-		Errors:         []error{},
-	}
-
-	for _, item := range items {
-		if item.Command != "" {
-			// For composer clear-cache, just remove the directory
-			if item.Path != "" {
-				if err := os.RemoveAll(scanner.ExpandHome(item.Path)); err != nil {
-					result.Errors = append(result.Errors, fmt.Errorf("failed to clean %s: %w", item.Description, err))
-					continue
-				}
-			}
-		}
-
-		result.ItemsCleaned++
-		result.SpaceReclaimed += item.Size
-	}
-
-	return result, nil
+func (p *PHPProvider) Clean(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	// Every Composer item is a directory; there is no clean command to run
+	return cleanItems(ctx, items, dryRun, func(ctx context.Context, item core.CleanableItem) (int64, error) {
+		return item.Size, scanner.RemoveDir(item.Path)
+	}), nil
 }
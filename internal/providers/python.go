@@ -1,8 +1,11 @@
 package providers
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	"dependency-hell-cli/internal/core"
@@ -22,12 +25,18 @@ func (p *PythonProvider) Name() string {
 	return "Python"
 }
 
+// pythonBinaries are the interpreter names tried in order; some conda and Windows layouts only have python
+var pythonBinaries = []string{"python3", "python"}
+
+// pipBinaries are the pip names tried in order
+var pipBinaries = []string{"pip3", "pip"}
+
 // DetectInstalled detects installed Python versions
-func (p *PythonProvider) DetectInstalled() ([]core.Installation, error) {
-	// Check if python3 is installed
-	pythonPath, err := scanner.FindExecutable("python3")
+func (p *PythonProvider) DetectInstalled(ctx context.Context) ([]core.Installation, error) {
+	// Check if python3 (or python) is installed
+	pythonPath, binary, err := scanner.FindFirstExecutable(pythonBinaries...)
 	if err != nil {
-		return nil, fmt.Errorf("python3 not found in PATH")
+		return nil, notInstalledError("neither python3 nor python found in PATH")
 	}
 
 	// Resolve symlinks
@@ -37,9 +46,9 @@ func (p *PythonProvider) DetectInstalled() ([]core.Installation, error) {
 	}
 
 	// Get version
-	version, err := scanner.GetExecutableVersion("python3", "--version")
+	version, err := scanner.GetExecutableVersion(ctx, binary, "--version")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get python version: %w", err)
+		return nil, versionError("python", err)
 	}
 
 	// Parse version (e.g., "Python 3.11.0")
@@ -49,18 +58,66 @@ func (p *PythonProvider) DetectInstalled() ([]core.Installation, error) {
 	}
 
 	// Determine source
-	source := p.determineSource(realPath)
+	source, sourceReason := p.determineSource(realPath)
 	managerName := p.getManagerName(realPath, source)
+	if binary != pythonBinaries[0] {
+		sourceReason += fmt.Sprintf("; found as %s, there is no %s on PATH", binary, pythonBinaries[0])
+	}
 
 	installation := core.Installation{
-		Version:     versionStr,
-		Source:      source,
-		BinaryPath:  pythonPath,
-		ManagerPath: p.getManagerPath(realPath, source),
-		ManagerName: managerName,
+		Version:      versionStr,
+		Source:       source,
+		BinaryPath:   pythonPath,
+		ManagerPath:  p.getManagerPath(realPath, source),
+		ManagerName:  managerName,
+		Arch:         scanner.HomebrewArch(realPath),
+		SourceReason: sourceReason,
+	}
+
+	installations := []core.Installation{installation}
+
+	// Other versions installed by pyenv
+	pyenvRoot := scanner.ExpandHome(p.pyenvRoot())
+	versionsDir := filepath.Join(pyenvRoot, "versions")
+	for _, version := range p.listPyenvVersions() {
+		if version == versionStr {
+			continue
+		}
+		// Python 2 versions only have bin/python
+		binaryPath := filepath.Join(versionsDir, version, "bin", "python3")
+		if !scanner.PathExists(binaryPath) {
+			binaryPath = filepath.Join(versionsDir, version, "bin", "python")
+		}
+		installations = append(installations, core.Installation{
+			Version:      version,
+			Source:       core.SourceVersionManager,
+			BinaryPath:   binaryPath,
+			ManagerPath:  pyenvRoot,
+			ManagerName:  "pyenv",
+			SourceReason: fmt.Sprintf("installed under %s", versionsDir),
+		})
+	}
+
+	return installations, nil
+}
+
+// listPyenvVersions lists the Python versions installed by pyenv, newest first
+func (p *PythonProvider) listPyenvVersions() []string {
+	var versions []string
+
+	entries, err := os.ReadDir(scanner.ExpandHome(filepath.Join(p.pyenvRoot(), "versions")))
+	if err != nil {
+		return versions
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
 	}
 
-	return []core.Installation{installation}, nil
+	core.SortVersionsNewestFirst(versions)
+	return versions
 }
 
 // getManagerName returns the specific version manager name
@@ -76,21 +133,38 @@ func (p *PythonProvider) getManagerName(path string, source core.InstallSource)
 	return ""
 }
 
-// determineSource determines the installation source based on path
-func (p *PythonProvider) determineSource(path string) core.InstallSource {
-	if strings.Contains(path, ".pyenv") {
-		return core.SourceVersionManager
-	}
-	if strings.Contains(path, "anaconda") || strings.Contains(path, "miniconda") {
-		return core.SourceVersionManager
+// BinaryName returns the executable used to detect the language: python3, or python when
+// only that exists
+func (p *PythonProvider) BinaryName() string {
+	if _, binary, err := scanner.FindFirstExecutable(pythonBinaries...); err == nil {
+		return binary
 	}
-	if strings.Contains(path, "/opt/homebrew") || strings.Contains(path, "/usr/local/Cellar") {
-		return core.SourceHomebrew
+	return pythonBinaries[0]
+}
+
+// pipBinary returns the pip executable on PATH, pip3 or pip
+func (p *PythonProvider) pipBinary() string {
+	if _, binary, err := scanner.FindFirstExecutable(pipBinaries...); err == nil {
+		return binary
 	}
-	if strings.Contains(path, "/usr/bin/python") {
-		return core.SourceSystem
+	return "pip"
+}
+
+// ClassifySource classifies the install source of any resolved binary path
+func (p *PythonProvider) ClassifySource(realPath string) (core.InstallSource, string) {
+	return p.determineSource(realPath)
+}
+
+// determineSource determines the installation source based on path
+func (p *PythonProvider) determineSource(path string) (core.InstallSource, string) {
+	rules := []sourceRule{
+		{".pyenv", core.SourceVersionManager},
+		{"anaconda", core.SourceVersionManager},
+		{"miniconda", core.SourceVersionManager},
 	}
-	return core.SourceUnknown
+	rules = append(rules, homebrewRules...)
+	rules = append(rules, sourceRule{"/usr/bin/python", core.SourceSystem})
+	return classifySource(path, rules)
 }
 
 // getManagerPath extracts the manager path if applicable
@@ -106,13 +180,13 @@ func (p *PythonProvider) getManagerPath(path string, source core.InstallSource)
 }
 
 // GetGlobalCacheUsage calculates disk usage for Python ecosystem
-func (p *PythonProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
+func (p *PythonProvider) GetGlobalCacheUsage(ctx context.Context) (*core.DiskUsage, error) {
 	var items []core.DiskUsageItem
 
 	// Pyenv versions
-	pyenvPath := "~/.pyenv/versions"
+	pyenvPath := filepath.Join(p.pyenvRoot(), "versions")
 	if scanner.PathExists(pyenvPath) {
-		size, _ := scanner.CalculateDirSize(pyenvPath)
+		size, _ := scanner.CalculateDirSize(ctx, pyenvPath)
 		items = append(items, core.DiskUsageItem{
 			Path:        pyenvPath,
 			Description: "Pyenv Versions",
@@ -120,45 +194,82 @@ func (p *PythonProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Pip cache
-	pipCache := "~/Library/Caches/pip"
-	if scanner.PathExists(pipCache) {
-		size, _ := scanner.CalculateDirSize(pipCache)
+	// Source tarballs and kept build trees of pyenv installs
+	for _, dir := range p.pyenvBuildDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.DiskUsageItem{
+				Path:        dir.Path,
+				Description: dir.Description,
+				Size:        size,
+			})
+		}
+	}
+
+	// Logs and build directories python-build leaves in the temp directory, one item each
+	// so that nothing ever points at the temp directory itself
+	for _, leftover := range scanner.ScanTempLeftovers() {
+		if leftover.Tool == "pyenv python-build" {
+			items = append(items, core.DiskUsageItem{
+				Path:        leftover.Path,
+				Description: "Pyenv Build Log",
+				Size:        leftover.Size,
+			})
+		}
+	}
+
+	// User site-packages (pip install --user), not part of any interpreter
+	items = append(items, p.findUserSitePackages(ctx)...)
+
+	// Pip cache, split into downloaded HTTP responses and locally built wheels
+	for _, dir := range p.pipHTTPCacheDirs() {
+		size, _ := scanner.CalculateDirSize(ctx, dir)
 		items = append(items, core.DiskUsageItem{
-			Path:        pipCache,
-			Description: "Pip Cache",
+			Path:        dir,
+			Description: "Pip HTTP Cache",
+			Size:        size,
+		})
+	}
+	if wheels := p.pipWheelCacheDir(); scanner.PathExists(wheels) {
+		size, _ := scanner.CalculateDirSize(ctx, wheels)
+		items = append(items, core.DiskUsageItem{
+			Path:        wheels,
+			Description: "Pip Wheel Cache",
 			Size:        size,
 		})
 	}
 
-	// Virtualenvs (if using virtualenvwrapper)
-	virtualenvs := "~/.virtualenvs"
+	// Virtualenvs (if using virtualenvwrapper), with each orphaned one reported under its own path
+	virtualenvs := p.virtualenvsDir()
 	if scanner.PathExists(virtualenvs) {
-		size, _ := scanner.CalculateDirSize(virtualenvs)
+		size, _ := scanner.CalculateDirSize(ctx, virtualenvs)
+
+		var orphaned []core.DiskUsageItem
+		for _, venv := range p.findOrphanedVirtualenvs(ctx) {
+			size -= venv.Size
+			orphaned = append(orphaned, core.DiskUsageItem{
+				Path:        venv.Path,
+				Description: "Orphaned Virtualenv " + filepath.Base(venv.Path),
+				Size:        venv.Size,
+			})
+		}
+
 		items = append(items, core.DiskUsageItem{
 			Path:        virtualenvs,
 			Description: "Virtualenvs",
 			Size:        size,
 		})
+		items = append(items, orphaned...)
 	}
 
-	// Calculate total
-	var total int64
-	for _, item := range items {
-		total += item.Size
-	}
-
-	return &core.DiskUsage{
-		Items: items,
-		Total: total,
-	}, nil
+	return newDiskUsage(items), nil
 }
 
 // GetEnvVars returns relevant environment variables
-func (p *PythonProvider) GetEnvVars() map[string]string {
+func (p *PythonProvider) GetEnvVars(ctx context.Context) map[string]string {
 	vars := make(map[string]string)
 
-	envVars := []string{"PYTHONPATH", "VIRTUAL_ENV", "PYENV_ROOT"}
+	envVars := []string{"PYTHONPATH", "VIRTUAL_ENV", "PYENV_ROOT", "PIP_CACHE_DIR", "PYTHON_BUILD_CACHE_PATH", "PYTHON_BUILD_BUILD_PATH"}
 	for _, name := range envVars {
 		if value := scanner.GetEnvVar(name); value != "" {
 			vars[name] = value
@@ -169,48 +280,288 @@ func (p *PythonProvider) GetEnvVars() map[string]string {
 }
 
 // GetCleanableItems returns items that can be cleaned for Python
-func (p *PythonProvider) GetCleanableItems() ([]core.CleanableItem, error) {
+func (p *PythonProvider) GetCleanableItems(ctx context.Context) ([]core.CleanableItem, error) {
 	var items []core.CleanableItem
 
-	// Pip cache (safe)
-	pipCache := "~/Library/Caches/pip"
-	if scanner.PathExists(pipCache) {
-		size, _ := scanner.CalculateDirSize(pipCache)
+	// Pip HTTP cache (safe - packages are downloaded again)
+	for _, dir := range p.pipHTTPCacheDirs() {
+		size, _ := scanner.CalculateDirSize(ctx, dir)
 		items = append(items, core.CleanableItem{
-			Description: "Pip Cache",
-			Command:     "pip cache purge",
+			Path:        dir,
+			Description: "Pip HTTP Cache",
 			Size:        size,
 			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads packages on the next pip install",
 		})
 	}
 
+	// Pip wheel cache (needs confirmation - wheels built from sdists can be slow to rebuild).
+	// Together with the HTTP cache this is what pip cache purge removes.
+	if wheels := p.pipWheelCacheDir(); scanner.PathExists(wheels) {
+		size, _ := scanner.CalculateDirSize(ctx, wheels)
+		args := []string{p.pipBinary(), "cache", "remove", "*"}
+		items = append(items, core.CleanableItem{
+			Path:        wheels,
+			Description: "Pip Wheel Cache",
+			Command:     strings.Join(args, " "),
+			Args:        args,
+			Size:        size,
+			Safe:        false,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads and may recompile source packages on the next pip install",
+		})
+	}
+
+	// Pyenv download cache and kept sources (safe - tarballs are downloaded again, installed
+	// versions don't need their sources)
+	for _, dir := range p.pyenvBuildDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.CleanableItem{
+				Path:        dir.Path,
+				Description: dir.Description,
+				Size:        size,
+				Safe:        true,
+				Reclaim:     core.ReclaimRegenerable,
+				RebuildCost: "re-downloads sources on the next pyenv install",
+			})
+		}
+	}
+
+	// Orphaned virtualenvs (safe - their base interpreter no longer exists)
+	items = append(items, p.findOrphanedVirtualenvs(ctx)...)
+
 	return items, nil
 }
 
-// Clean executes cleaning for Python
-func (p *PythonProvider) Clean(items []core.CleanableItem) (*core.CleanResult, error) {
-	result := &core.CleanResult{
-		ItemsCleaned:   0,
-		SpaceReclaimed: 0,
-		Errors:         []error{},
-	}
-
-	for _, item := range items {
-		if item.Command != "" {
-			// Execute clean command
-			parts := strings.Fields(item.Command)
-			if len(parts) > 0 {
-				// For pip cache purge, we need to handle it specially
-				if err := os.RemoveAll(scanner.ExpandHome(item.Path)); err != nil {
-					result.Errors = append(result.Errors, fmt.Errorf("failed to clean %s: %w", item.Description, err))
-					continue
-				}
-			}
+// pyenvRoot returns the pyenv root directory, honoring PYENV_ROOT
+func (p *PythonProvider) pyenvRoot() string {
+	if root, ok := scanner.ConfiguredPath("python", "pyenv_root"); ok {
+		return root
+	}
+	if root := scanner.GetEnvVar("PYENV_ROOT"); root != "" {
+		return root
+	}
+	return "~/.pyenv"
+}
+
+// pyenvBuildDirs returns where python-build keeps downloaded source tarballs (PYTHON_BUILD_CACHE_PATH)
+// and the build trees pyenv install --keep leaves behind (PYTHON_BUILD_BUILD_PATH)
+func (p *PythonProvider) pyenvBuildDirs() []toolDir {
+	cache := filepath.Join(p.pyenvRoot(), "cache")
+	if dir := scanner.GetEnvVar("PYTHON_BUILD_CACHE_PATH"); dir != "" {
+		cache = dir
+	}
+	sources := filepath.Join(p.pyenvRoot(), "sources")
+	if dir := scanner.GetEnvVar("PYTHON_BUILD_BUILD_PATH"); dir != "" {
+		sources = dir
+	}
+
+	return []toolDir{
+		{Description: "Pyenv Download Cache", Path: cache},
+		{Description: "Pyenv Sources (--keep)", Path: sources},
+	}
+}
+
+// userSitePatterns match the pip --user site-packages of every Python version
+var userSitePatterns = []string{
+	"~/.local/lib/python*/site-packages",          // Linux
+	"~/Library/Python/*/lib/python/site-packages", // macOS
+}
+
+// findUserSitePackages finds the user site-packages of each Python version
+func (p *PythonProvider) findUserSitePackages(ctx context.Context) []core.DiskUsageItem {
+	var items []core.DiskUsageItem
+
+	for _, pattern := range userSitePatterns {
+		matches, _ := filepath.Glob(scanner.ExpandHome(pattern))
+		for _, match := range matches {
+			size, _ := scanner.CalculateDirSize(ctx, match)
+			items = append(items, core.DiskUsageItem{
+				Path:        match,
+				Description: fmt.Sprintf("User Site-Packages (%s)", p.sitePackagesVersion(match)),
+				Size:        size,
+			})
+		}
+	}
+
+	return items
+}
+
+// findVersionSitePackages finds the site-packages of pyenv versions and the active interpreter
+func (p *PythonProvider) findVersionSitePackages(ctx context.Context) []core.DiskUsageItem {
+	var items []core.DiskUsageItem
+	seen := make(map[string]bool)
+
+	addSitePackages := func(path, description string) {
+		if seen[path] || !scanner.PathExists(path) {
+			return
+		}
+		seen[path] = true
+
+		size, _ := scanner.CalculateDirSize(ctx, path)
+		items = append(items, core.DiskUsageItem{
+			Path:        path,
+			Description: description,
+			Size:        size,
+		})
+	}
+
+	// Pyenv versions: <root>/versions/<version>/lib/pythonX.Y/site-packages
+	pattern := filepath.Join(scanner.ExpandHome(p.pyenvRoot()), "versions", "*", "lib", "python*", "site-packages")
+	matches, _ := filepath.Glob(pattern)
+	for _, match := range matches {
+		version := filepath.Base(filepath.Dir(filepath.Dir(filepath.Dir(match))))
+		addSitePackages(match, fmt.Sprintf("pyenv %s", version))
+	}
+
+	// Active interpreter (System, Homebrew, ...)
+	binary := p.BinaryName()
+	output, err := scanner.CommandOutput(ctx, binary, "-c", "import site; print('\\n'.join(site.getsitepackages()))")
+	if err == nil {
+		for _, path := range strings.Fields(string(output)) {
+			addSitePackages(path, fmt.Sprintf("%s (%s)", binary, p.sitePackagesVersion(path)))
+		}
+	}
+
+	return items
+}
+
+// sitePackagesVersion extracts the X.Y Python version from a site-packages path
+func (p *PythonProvider) sitePackagesVersion(path string) string {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if version, found := strings.CutPrefix(part, "python"); found && version != "" {
+			return version
+		}
+	}
+
+	// macOS user site: ~/Library/Python/X.Y/lib/python/site-packages
+	return filepath.Base(filepath.Dir(filepath.Dir(filepath.Dir(path))))
+}
+
+// GetInfoSections returns site-packages details for the info command
+func (p *PythonProvider) GetInfoSections(ctx context.Context) []core.InfoSection {
+	sitePackages := append(p.findUserSitePackages(ctx), p.findVersionSitePackages(ctx)...)
+	if len(sitePackages) == 0 {
+		return nil
+	}
+
+	return expandInfoSections([]core.InfoSection{{
+		Title: "Site-Packages",
+		Items: sitePackages,
+	}})
+}
+
+// virtualenvsDir returns the virtualenvwrapper directory, honoring WORKON_HOME
+func (p *PythonProvider) virtualenvsDir() string {
+	if dir, ok := scanner.ConfiguredPath("python", "virtualenvs"); ok {
+		return dir
+	}
+	if workonHome := scanner.GetEnvVar("WORKON_HOME"); workonHome != "" {
+		return workonHome
+	}
+	return "~/.virtualenvs"
+}
+
+// pipCacheDir returns pip's cache directory, honoring PIP_CACHE_DIR
+func (p *PythonProvider) pipCacheDir() string {
+	if cache, ok := scanner.ConfiguredPath("python", "pip_cache"); ok {
+		return cache
+	}
+	if cache := scanner.GetEnvVar("PIP_CACHE_DIR"); cache != "" {
+		return cache
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return "~/Library/Caches/pip"
+	case "windows":
+		if localAppData := scanner.GetEnvVar("LOCALAPPDATA"); localAppData != "" {
+			return filepath.Join(localAppData, "pip", "Cache")
+		}
+	}
+	return filepath.Join(scanner.XDGCacheHome(), "pip")
+}
+
+// pipHTTPCacheDirs returns the existing HTTP cache directories; pip 23.3 moved it from http to http-v2
+func (p *PythonProvider) pipHTTPCacheDirs() []string {
+	var dirs []string
+	for _, name := range []string{"http", "http-v2"} {
+		if dir := filepath.Join(p.pipCacheDir(), name); scanner.PathExists(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// pipWheelCacheDir returns the directory of wheels pip built locally
+func (p *PythonProvider) pipWheelCacheDir() string {
+	return filepath.Join(p.pipCacheDir(), "wheels")
+}
+
+// findOrphanedVirtualenvs finds virtualenvs whose base interpreter has been removed
+func (p *PythonProvider) findOrphanedVirtualenvs(ctx context.Context) []core.CleanableItem {
+	var orphaned []core.CleanableItem
+
+	virtualenvs := scanner.ExpandHome(p.virtualenvsDir())
+	entries, err := os.ReadDir(virtualenvs)
+	if err != nil {
+		return orphaned
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		venvPath := filepath.Join(virtualenvs, entry.Name())
+		interpreter := p.parseVirtualenvInterpreter(filepath.Join(venvPath, "pyvenv.cfg"))
+		if interpreter == "" || scanner.PathExists(interpreter) {
+			continue
 		}
 
-		result.ItemsCleaned++
-		result.SpaceReclaimed += item.Size
+		size, _ := scanner.CalculateDirSize(ctx, venvPath)
+		orphaned = append(orphaned, core.CleanableItem{
+			Path:        venvPath,
+			Description: fmt.Sprintf("Orphaned Virtualenv %s (missing %s)", entry.Name(), interpreter),
+			Size:        size,
+			Safe:        true,
+			Reclaim:     core.ReclaimExact,
+			RebuildCost: "none; its interpreter is gone, recreate it to use it again",
+		})
 	}
 
-	return result, nil
+	return orphaned
+}
+
+// parseVirtualenvInterpreter returns the base interpreter path referenced by a pyvenv.cfg
+func (p *PythonProvider) parseVirtualenvInterpreter(cfgPath string) string {
+	// Example pyvenv.cfg:
+	// home = /Users/me/.pyenv/versions/3.11.0/bin
+	// executable = /Users/me/.pyenv/versions/3.11.0/bin/python3.11
+
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return ""
+	}
+
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if found {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	// executable is more precise but only written by newer Pythons
+	if executable := values["executable"]; executable != "" {
+		return executable
+	}
+	return values["home"]
+}
+
+// Clean executes cleaning for Python
+func (p *PythonProvider) Clean(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	// Pip items with a command only use Path to say where the data lives
+	return cleanItems(ctx, items, dryRun, cleanItem), nil
 }
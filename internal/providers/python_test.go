@@ -0,0 +1,106 @@
+package providers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPythonBuildLogsAreItemsOfTheirOwn(t *testing.T) {
+	env := newFakeEnv(t)
+	tmp := env.path("tmp")
+	t.Setenv("TMPDIR", tmp)
+	t.Setenv("GOTMPDIR", "")
+	env.writeFile("tmp/python-build.20240101120000.1234.log", 300)
+	env.writeFile("tmp/python-build.20240101120000.1234/Python-3.12.1/configure", 700)
+	env.writeFile("tmp/unrelated.log", 5000)
+
+	usage, err := NewPythonProvider().GetGlobalCacheUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetGlobalCacheUsage() error = %v", err)
+	}
+
+	sizes := make(map[string]int64)
+	for _, item := range usage.Items {
+		if item.Description != "Pyenv Build Log" {
+			continue
+		}
+		if filepath.Clean(item.Path) == filepath.Clean(tmp) || filepath.Clean(item.Path) == filepath.Clean(os.TempDir()) {
+			t.Fatalf("build log item points at the temp directory %s", item.Path)
+		}
+		sizes[item.Path] = item.Size
+	}
+
+	want := map[string]int64{
+		filepath.Join(tmp, "python-build.20240101120000.1234.log"): 300,
+		filepath.Join(tmp, "python-build.20240101120000.1234"):     700,
+	}
+	for path, size := range want {
+		if sizes[path] != size {
+			t.Errorf("build log %s = %d bytes, want %d (items %v)", path, sizes[path], size, sizes)
+		}
+	}
+}
+
+func TestParseVirtualenvInterpreter(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  string
+		want string
+	}{
+		{"executable", "home = /opt/py/bin\nexecutable = /opt/py/bin/python3.11\nversion = 3.11.0\n", "/opt/py/bin/python3.11"},
+		{"home", "home=/opt/py/bin\ninclude-system-site-packages = false\n", "/opt/py/bin"},
+		{"neither", "version = 3.11.0\n", ""},
+	}
+
+	env := newFakeEnv(t)
+	p := NewPythonProvider()
+	for _, tt := range tests {
+		cfg := env.writeFile("cfg/"+tt.name+"/pyvenv.cfg", 0)
+		if err := os.WriteFile(cfg, []byte(tt.cfg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if got := p.parseVirtualenvInterpreter(cfg); got != tt.want {
+			t.Errorf("%s: parseVirtualenvInterpreter() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := p.parseVirtualenvInterpreter(env.path("missing/pyvenv.cfg")); got != "" {
+		t.Errorf("parseVirtualenvInterpreter() of a missing file = %q, want empty", got)
+	}
+}
+
+func TestOrphanedVirtualenvsAreItemsOfTheirOwn(t *testing.T) {
+	env := newFakeEnv(t)
+	interpreter := env.writeFile(".pyenv/versions/3.12.1/bin/python3.12", 0)
+	venv := func(name, executable string, size int) {
+		cfg := env.writeFile(".virtualenvs/"+name+"/pyvenv.cfg", 0)
+		if err := os.WriteFile(cfg, []byte("executable = "+executable+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		env.writeFile(".virtualenvs/"+name+"/lib/site.py", size)
+	}
+	venv("live", interpreter, 300)
+	venv("gone", env.path(".pyenv/versions/3.9.0/bin/python3.9"), 700)
+
+	usage, err := NewPythonProvider().GetGlobalCacheUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetGlobalCacheUsage() error = %v", err)
+	}
+
+	paths := make(map[string]bool)
+	for _, item := range usage.Items {
+		if paths[item.Path] {
+			t.Errorf("two items share the path %s", item.Path)
+		}
+		paths[item.Path] = true
+	}
+
+	orphan := findItem(t, usage, "Orphaned Virtualenv gone")
+	if orphan.Path != env.path(".virtualenvs/gone") {
+		t.Errorf("orphaned virtualenv path = %s, want %s", orphan.Path, env.path(".virtualenvs/gone"))
+	}
+	if live := findItem(t, usage, "Virtualenvs"); live.Size < 300 || live.Size >= 700 || orphan.Size < 700 {
+		t.Errorf("virtualenvs = %d bytes and orphaned = %d bytes, want the 300 live bytes apart from the 700 orphaned", live.Size, orphan.Size)
+	}
+}
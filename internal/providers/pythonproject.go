@@ -0,0 +1,61 @@
+package providers
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// pythonProjectFiles mark a directory as the root of a Python package
+var pythonProjectFiles = []string{"setup.py", "pyproject.toml", "setup.cfg"}
+
+// pythonBuildDirs maps directory names left by setuptools and build frontends to a description
+var pythonBuildDirs = map[string]string{
+	"build": "Build Directory",
+	"dist":  "Distributions",
+	".eggs": "Setup Requirements (.eggs)",
+}
+
+// ScanProject finds build/, dist/, .eggs/ and *.egg-info directories of Python packages under root.
+// Directories only count when they sit next to a setup.py, setup.cfg or pyproject.toml.
+func (p *PythonProvider) ScanProject(ctx context.Context, root string) []core.CleanableItem {
+	var items []core.CleanableItem
+
+	scanner.WalkProject(ctx, root, func(path string, d fs.DirEntry) error {
+		if !d.IsDir() {
+			return nil
+		}
+
+		// Virtualenvs have their own lifecycle and are full of package metadata
+		if scanner.DirContains(path, "pyvenv.cfg") {
+			return filepath.SkipDir
+		}
+
+		description, ok := pythonBuildDirs[d.Name()]
+		if !ok && strings.HasSuffix(d.Name(), ".egg-info") {
+			description, ok = "Package Metadata (.egg-info)", true
+		}
+		if !ok || !scanner.DirContains(filepath.Dir(path), pythonProjectFiles...) {
+			return nil
+		}
+
+		size, _ := scanner.CalculateDirSize(ctx, path)
+		items = append(items, core.CleanableItem{
+			Path:        path,
+			Description: description,
+			Size:        size,
+			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "rebuilt by the next package build",
+		})
+
+		// Everything inside is covered by this item
+		return filepath.SkipDir
+	})
+
+	return items
+}
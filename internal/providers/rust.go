@@ -1,9 +1,10 @@
 package providers
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"dependency-hell-cli/internal/core"
@@ -24,11 +25,11 @@ func (p *RustProvider) Name() string {
 }
 
 // DetectInstalled detects installed Rust versions
-func (p *RustProvider) DetectInstalled() ([]core.Installation, error) {
+func (p *RustProvider) DetectInstalled(ctx context.Context) ([]core.Installation, error) {
 	// Check if rustc is installed
 	rustcPath, err := scanner.FindExecutable("rustc")
 	if err != nil {
-		return nil, fmt.Errorf("rustc not found in PATH")
+		return nil, notInstalledError("rustc not found in PATH")
 	}
 
 	// Resolve symlinks
@@ -38,22 +39,24 @@ func (p *RustProvider) DetectInstalled() ([]core.Installation, error) {
 	}
 
 	// Get version
-	version, err := scanner.GetExecutableVersion("rustc", "--version")
+	version, err := scanner.GetExecutableVersion(ctx, "rustc", "--version")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get rust version: %w", err)
+		return nil, versionError("rust", err)
 	}
 
 	// Parse version (e.g., "rustc 1.74.0 (79e9716c9 2023-11-13)")
 	versionStr := p.parseVersion(version)
 
 	// Determine source
-	source := p.determineSource(realPath)
+	source, sourceReason := p.determineSource(realPath)
 
 	installation := core.Installation{
-		Version:     versionStr,
-		Source:      source,
-		BinaryPath:  rustcPath,
-		ManagerPath: p.getManagerPath(realPath, source),
+		Version:      versionStr,
+		Source:       source,
+		BinaryPath:   rustcPath,
+		ManagerPath:  p.getManagerPath(realPath, source),
+		Arch:         scanner.HomebrewArch(realPath),
+		SourceReason: sourceReason,
 	}
 
 	return []core.Installation{installation}, nil
@@ -69,15 +72,21 @@ func (p *RustProvider) parseVersion(output string) string {
 	return "unknown"
 }
 
+// BinaryName returns the executable used to detect the language
+func (p *RustProvider) BinaryName() string {
+	return "rustc"
+}
+
+// ClassifySource classifies the install source of any resolved binary path
+func (p *RustProvider) ClassifySource(realPath string) (core.InstallSource, string) {
+	return p.determineSource(realPath)
+}
+
 // determineSource determines the installation source based on path
-func (p *RustProvider) determineSource(path string) core.InstallSource {
-	if strings.Contains(path, ".cargo/bin") {
-		return core.SourceVersionManager // Rustup is the standard
-	}
-	if strings.Contains(path, "/opt/homebrew") || strings.Contains(path, "/usr/local/Cellar") {
-		return core.SourceHomebrew
-	}
-	return core.SourceUnknown
+func (p *RustProvider) determineSource(path string) (core.InstallSource, string) {
+	rules := []sourceRule{{".cargo/bin", core.SourceVersionManager}} // Rustup is the standard
+	rules = append(rules, homebrewRules...)
+	return classifySource(path, rules)
 }
 
 // getManagerPath extracts the manager path if applicable
@@ -91,13 +100,13 @@ func (p *RustProvider) getManagerPath(path string, source core.InstallSource) st
 }
 
 // GetGlobalCacheUsage calculates disk usage for Rust ecosystem
-func (p *RustProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
+func (p *RustProvider) GetGlobalCacheUsage(ctx context.Context) (*core.DiskUsage, error) {
 	var items []core.DiskUsageItem
 
 	// Rustup toolchains
-	rustupPath := "~/.rustup/toolchains"
+	rustupPath := p.rustupToolchainsDir()
 	if scanner.PathExists(rustupPath) {
-		size, _ := scanner.CalculateDirSize(rustupPath)
+		size, _ := scanner.CalculateDirSize(ctx, rustupPath)
 		items = append(items, core.DiskUsageItem{
 			Path:        rustupPath,
 			Description: "Rustup Toolchains",
@@ -106,9 +115,9 @@ func (p *RustProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 	}
 
 	// Cargo registry (the big one!)
-	cargoRegistry := "~/.cargo/registry"
+	cargoRegistry := p.cargoRegistryDir()
 	if scanner.PathExists(cargoRegistry) {
-		size, _ := scanner.CalculateDirSize(cargoRegistry)
+		size, _ := scanner.CalculateDirSize(ctx, cargoRegistry)
 		items = append(items, core.DiskUsageItem{
 			Path:        cargoRegistry,
 			Description: "Cargo Registry",
@@ -117,9 +126,9 @@ func (p *RustProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 	}
 
 	// Cargo git checkouts
-	cargoGit := "~/.cargo/git"
+	cargoGit := p.cargoGitDir()
 	if scanner.PathExists(cargoGit) {
-		size, _ := scanner.CalculateDirSize(cargoGit)
+		size, _ := scanner.CalculateDirSize(ctx, cargoGit)
 		items = append(items, core.DiskUsageItem{
 			Path:        cargoGit,
 			Description: "Cargo Git Checkouts",
@@ -127,20 +136,141 @@ func (p *RustProvider) GetGlobalCacheUsage() (*core.DiskUsage, error) {
 		})
 	}
 
-	// Calculate total
-	var total int64
-	for _, item := range items {
-		total += item.Size
+	// Archives left behind by rustup installs and updates
+	for _, dir := range p.rustupScratchDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.DiskUsageItem{
+				Path:        dir.Path,
+				Description: dir.Description,
+				Size:        size,
+			})
+		}
+	}
+
+	return newDiskUsage(items), nil
+}
+
+// rustupToolchainsDir returns the directory holding rustup's toolchains
+func (p *RustProvider) rustupToolchainsDir() string {
+	if dir, ok := scanner.ConfiguredPath("rust", "rustup_toolchains"); ok {
+		return dir
+	}
+	return filepath.Join(p.rustupHome(), "toolchains")
+}
+
+// rustupHome returns the rustup home directory, honoring RUSTUP_HOME
+func (p *RustProvider) rustupHome() string {
+	if home := scanner.GetEnvVar("RUSTUP_HOME"); home != "" {
+		return home
 	}
+	return "~/.rustup"
+}
 
-	return &core.DiskUsage{
-		Items: items,
-		Total: total,
-	}, nil
+// rustupScratchDirs returns rustup's download cache and temporary directory, which keep
+// toolchain archives from interrupted or superseded installs
+func (p *RustProvider) rustupScratchDirs() []toolDir {
+	return []toolDir{
+		{Description: "Rustup Downloads", Path: filepath.Join(p.rustupHome(), "downloads")},
+		{Description: "Rustup Temp", Path: filepath.Join(p.rustupHome(), "tmp")},
+	}
+}
+
+// rustupSettings is what dhell reads from rustup's settings.toml
+type rustupSettings struct {
+	defaultToolchain string
+	overrides        []string // Toolchains pinned to directories with rustup override
+}
+
+// readRustupSettings parses the default toolchain and directory overrides from settings.toml
+func (p *RustProvider) readRustupSettings() rustupSettings {
+	var settings rustupSettings
+
+	data, err := os.ReadFile(filepath.Join(scanner.ExpandHome(p.rustupHome()), "settings.toml"))
+	if err != nil {
+		return settings
+	}
+
+	inOverrides := false
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			inOverrides = line == "[overrides]"
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		switch {
+		case inOverrides:
+			settings.overrides = append(settings.overrides, value)
+		case key == "default_toolchain":
+			settings.defaultToolchain = value
+		}
+	}
+
+	return settings
+}
+
+// orphanedToolchains returns the installed toolchains that are neither the default nor
+// pinned by a rustup override. rust-toolchain files in projects are not known to dhell,
+// so these may still be used.
+func (p *RustProvider) orphanedToolchains() []string {
+	settings := p.readRustupSettings()
+	if settings.defaultToolchain == "" {
+		// Without a known default every toolchain would look orphaned
+		return nil
+	}
+	referenced := append([]string{settings.defaultToolchain}, settings.overrides...)
+
+	entries, err := os.ReadDir(scanner.ExpandHome(p.rustupToolchainsDir()))
+	if err != nil {
+		return nil
+	}
+
+	var orphaned []string
+	for _, entry := range entries {
+		if entry.IsDir() && !toolchainReferenced(entry.Name(), referenced) {
+			orphaned = append(orphaned, entry.Name())
+		}
+	}
+	return orphaned
+}
+
+// toolchainReferenced reports whether an installed toolchain directory (e.g.
+// stable-x86_64-unknown-linux-gnu) matches a full or short (e.g. stable) toolchain name
+func toolchainReferenced(dir string, names []string) bool {
+	for _, name := range names {
+		if dir == name || strings.HasPrefix(dir, name+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// cargoRegistryDir returns Cargo's registry index and crate cache
+func (p *RustProvider) cargoRegistryDir() string {
+	if dir, ok := scanner.ConfiguredPath("rust", "cargo_registry"); ok {
+		return dir
+	}
+	return "~/.cargo/registry"
+}
+
+// cargoGitDir returns Cargo's git dependency checkouts
+func (p *RustProvider) cargoGitDir() string {
+	if dir, ok := scanner.ConfiguredPath("rust", "cargo_git"); ok {
+		return dir
+	}
+	return "~/.cargo/git"
 }
 
 // GetEnvVars returns relevant environment variables
-func (p *RustProvider) GetEnvVars() map[string]string {
+func (p *RustProvider) GetEnvVars(ctx context.Context) map[string]string {
 	vars := make(map[string]string)
 
 	envVars := []string{"CARGO_HOME", "RUSTUP_HOME"}
@@ -154,64 +284,70 @@ func (p *RustProvider) GetEnvVars() map[string]string {
 }
 
 // GetCleanableItems returns items that can be cleaned for Rust
-func (p *RustProvider) GetCleanableItems() ([]core.CleanableItem, error) {
+func (p *RustProvider) GetCleanableItems(ctx context.Context) ([]core.CleanableItem, error) {
 	var items []core.CleanableItem
 
 	// Cargo registry (safe - can be re-downloaded)
-	cargoRegistry := "~/.cargo/registry"
+	cargoRegistry := p.cargoRegistryDir()
 	if scanner.PathExists(cargoRegistry) {
-		size, _ := scanner.CalculateDirSize(cargoRegistry)
+		size, _ := scanner.CalculateDirSize(ctx, cargoRegistry)
 		items = append(items, core.CleanableItem{
 			Path:        cargoRegistry,
 			Description: "Cargo Registry",
 			Size:        size,
 			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-downloads the crate index and crates on the next cargo build",
 		})
 	}
 
 	// Cargo git checkouts (safe)
-	cargoGit := "~/.cargo/git"
+	cargoGit := p.cargoGitDir()
 	if scanner.PathExists(cargoGit) {
-		size, _ := scanner.CalculateDirSize(cargoGit)
+		size, _ := scanner.CalculateDirSize(ctx, cargoGit)
 		items = append(items, core.CleanableItem{
 			Path:        cargoGit,
 			Description: "Cargo Git Checkouts",
 			Size:        size,
 			Safe:        true,
+			Reclaim:     core.ReclaimRegenerable,
+			RebuildCost: "re-clones git dependencies on the next cargo build",
 		})
 	}
 
-	return items, nil
-}
-
-// Clean executes cleaning for Rust
-func (p *RustProvider) Clean(items []core.CleanableItem) (*core.CleanResult, error) {
-	result := &core.CleanResult{
-		ItemsCleaned:   0,
-		SpaceReclaimed: 0,
-		Errors:         []error{},
-	}
-
-	for _, item := range items {
-		if item.Path != "" {
-			// Remove directory
-			expandedPath := scanner.ExpandHome(item.Path)
-			if err := os.RemoveAll(expandedPath); err != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("failed to clean %s: %w", item.Description, err))
-				continue
-			}
-		} else if item.Command != "" {
-			// Execute command
-			cmd := exec.Command("sh", "-c", item.Command)
-			if err := cmd.Run(); err != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("failed to clean %s: %w", item.Description, err))
-				continue
-			}
+	// Rustup downloads and temp files (safe - only used during an install)
+	for _, dir := range p.rustupScratchDirs() {
+		if scanner.PathExists(dir.Path) {
+			size, _ := scanner.CalculateDirSize(ctx, dir.Path)
+			items = append(items, core.CleanableItem{
+				Path:        dir.Path,
+				Description: dir.Description,
+				Size:        size,
+				Safe:        true,
+				Reclaim:     core.ReclaimExact,
+				RebuildCost: "none; only used during a rustup install",
+			})
 		}
+	}
 
-		result.ItemsCleaned++
-		result.SpaceReclaimed += item.Size
+	// Toolchains not referenced by the default or an override (unsafe - a project's
+	// rust-toolchain file may still need them)
+	for _, toolchain := range p.orphanedToolchains() {
+		size, _ := scanner.CalculateDirSize(ctx, filepath.Join(p.rustupToolchainsDir(), toolchain))
+		items = append(items, core.CleanableItem{
+			Description: fmt.Sprintf("Unreferenced Toolchain %s", toolchain),
+			Command:     fmt.Sprintf("rustup toolchain uninstall %s", toolchain),
+			Size:        size,
+			Safe:        false,
+			Reclaim:     core.ReclaimExact,
+			RebuildCost: "reinstall with rustup toolchain install if a project needs it again",
+		})
 	}
 
-	return result, nil
+	return items, nil
+}
+
+// Clean executes cleaning for Rust
+func (p *RustProvider) Clean(ctx context.Context, items []core.CleanableItem, dryRun bool) (*core.CleanResult, error) {
+	return cleanItems(ctx, items, dryRun, cleanItem), nil
 }
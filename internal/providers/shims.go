@@ -0,0 +1,93 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dependency-hell-cli/internal/scanner"
+)
+
+// ShimSelection is the version a shim-based version manager (pyenv, goenv) currently selects
+type ShimSelection struct {
+	Manager  string // e.g. "pyenv"
+	Version  string // As written, e.g. "3.11.4" or the prefix "3.11"
+	Origin   string // Where the selection came from: the environment variable or version file
+	Dir      string // Where the selected version is installed, if it still is
+	ShimsDir string // The manager's shims directory, which must be on PATH for the selection to matter
+}
+
+// Installed reports whether the selected version is still installed. A prefix such as 3.11
+// counts as installed when any 3.11.x is, since managers resolve it to the latest one.
+func (s ShimSelection) Installed() bool {
+	if scanner.PathExists(s.Dir) {
+		return true
+	}
+	matches, _ := filepath.Glob(scanner.ExpandHome(s.Dir) + ".*")
+	return len(matches) > 0
+}
+
+// shimSelection resolves the version a shim manager selects the way the manager does:
+// the environment variable, then the nearest local version file, then the global version file.
+// "system" and an empty selection select no managed version.
+func shimSelection(manager, root, versionEnv, localFile string) (ShimSelection, bool) {
+	selection := ShimSelection{
+		Manager:  manager,
+		ShimsDir: filepath.Join(root, "shims"),
+	}
+
+	switch {
+	case os.Getenv(versionEnv) != "":
+		selection.Version, selection.Origin = os.Getenv(versionEnv), versionEnv
+	default:
+		file := findUpwards(localFile)
+		if file == "" {
+			file = filepath.Join(scanner.ExpandHome(root), "version")
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return ShimSelection{}, false
+		}
+		selection.Version, selection.Origin = string(data), file
+	}
+
+	// Several versions may be listed; the first is the one that provides the binary
+	fields := strings.Fields(selection.Version)
+	if len(fields) == 0 || fields[0] == "system" {
+		return ShimSelection{}, false
+	}
+	selection.Version = fields[0]
+	selection.Dir = filepath.Join(root, "versions", selection.Version)
+	return selection, true
+}
+
+// findUpwards returns the nearest file with the given name in the working directory or its parents
+func findUpwards(name string) string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	for {
+		candidate := filepath.Join(dir, name)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ShimSelection returns the Python version pyenv selects, if any
+func (p *PythonProvider) ShimSelection() (ShimSelection, bool) {
+	return shimSelection("pyenv", p.pyenvRoot(), "PYENV_VERSION", ".python-version")
+}
+
+// ShimSelection returns the Go version goenv selects, if any
+func (p *GoProvider) ShimSelection() (ShimSelection, bool) {
+	return shimSelection("goenv", p.goenvRoot(), "GOENV_VERSION", ".go-version")
+}
@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"fmt"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// sourceRule classifies a binary path containing marker as source
+type sourceRule struct {
+	marker string
+	source core.InstallSource
+}
+
+// homebrewRules match binaries installed by either Homebrew prefix
+var homebrewRules = []sourceRule{
+	{"/opt/homebrew", core.SourceHomebrew},
+	{"/usr/local/Cellar", core.SourceHomebrew},
+}
+
+// classifySource returns the source of the first matching rule and the reason it matched
+// Binaries on a Windows drive are native Windows installs, whatever rules say.
+func classifySource(path string, rules []sourceRule) (core.InstallSource, string) {
+	if scanner.IsWindowsMount(path) {
+		return core.SourceWindows, fmt.Sprintf("binary lives on the Windows filesystem (%s)", path)
+	}
+	for _, rule := range rules {
+		if strings.Contains(path, rule.marker) {
+			return rule.source, fmt.Sprintf("binary resolves under %s (%s)", rule.marker, path)
+		}
+	}
+	return core.SourceUnknown, fmt.Sprintf("binary resolves to %s, which matches no known install location", path)
+}
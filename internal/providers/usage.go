@@ -0,0 +1,38 @@
+package providers
+
+import (
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/scanner"
+)
+
+// toolDir is a described directory written by a language's tooling, reported as a
+// disk usage item and offered as a cleanable one
+type toolDir struct {
+	Description string
+	Path        string
+}
+
+// newDiskUsage totals the items and expands ~ in their paths,
+// so every provider reports absolute paths whatever form it looked them up in
+func newDiskUsage(items []core.DiskUsageItem) *core.DiskUsage {
+	var total int64
+	for i := range items {
+		items[i].Path = scanner.ExpandHome(items[i].Path)
+		total += items[i].Size
+	}
+
+	return &core.DiskUsage{
+		Items: items,
+		Total: total,
+	}
+}
+
+// expandInfoSections expands ~ in the item paths of info sections
+func expandInfoSections(sections []core.InfoSection) []core.InfoSection {
+	for _, section := range sections {
+		for i := range section.Items {
+			section.Items[i].Path = scanner.ExpandHome(section.Items[i].Path)
+		}
+	}
+	return sections
+}
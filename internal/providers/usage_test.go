@@ -0,0 +1,66 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+)
+
+// The JSON export and info render these items as they are, so a ~ here would reach the output
+func TestNoTildePathsInOutput(t *testing.T) {
+	env := newFakeEnv(t)
+	t.Setenv("TMPDIR", env.path("tmp"))
+	t.Setenv("GOTMPDIR", "")
+	for _, rel := range []string{
+		"go/pkg/mod/cache/download/x", ".goenv/versions/1.22.0/bin/go", ".cache/golangci-lint/x",
+		".npm/_cacache/index-v5/x", ".npm/_logs/debug.log", ".cache/yarn/v6/x", ".local/share/pnpm/store/v3/x",
+		".m2/repository/junit/junit.jar", ".gradle/caches/modules-2/x", ".gradle/wrapper/dists/x",
+		".cache/pip/http-v2/x", ".pyenv/versions/3.12.1/bin/python",
+		".cache/composer/files/x", ".cargo/registry/cache/x", ".rustup/toolchains/stable/bin/rustc",
+		".cache/deno/deps/x",
+	} {
+		env.writeFile(rel, 100)
+	}
+
+	all := []core.LanguageProvider{
+		NewGoProvider(), NewNodeProvider(), NewJavaProvider(), NewPythonProvider(),
+		NewPHPProvider(), NewRustProvider(), NewDenoProvider(), NewHomebrewProvider(),
+	}
+	ctx := context.Background()
+	var usages []*core.DiskUsage
+	var sections []core.InfoSection
+	for _, provider := range all {
+		usage, err := provider.GetGlobalCacheUsage(ctx)
+		if err != nil {
+			t.Fatalf("%s GetGlobalCacheUsage() error = %v", provider.Name(), err)
+		}
+		usages = append(usages, usage)
+		if info, ok := provider.(core.InfoSectionProvider); ok {
+			sections = append(sections, info.GetInfoSections(ctx)...)
+		}
+		if breakdown, ok := provider.(core.BreakdownProvider); ok {
+			sections = append(sections, breakdown.GetBreakdown(ctx)...)
+		}
+	}
+
+	encoded, err := json.Marshal(usages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(encoded), env.path(".npm/_cacache")) {
+		t.Fatalf("fixture caches missing from the disk usage: %s", encoded)
+	}
+	if strings.Contains(string(encoded), `"~`) {
+		t.Errorf("disk usage has a ~ path: %s", encoded)
+	}
+
+	if encoded, err = json.Marshal(sections); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(encoded), `"~`) {
+		t.Errorf("info sections have a ~ path: %s", encoded)
+	}
+}
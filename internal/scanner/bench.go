@@ -0,0 +1,83 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// WalkTiming is how long one size calculation method took on a directory
+type WalkTiming struct {
+	Method   string
+	Size     int64
+	Files    int64 // 0 for du, which doesn't count files
+	Duration time.Duration
+}
+
+// walkMethod is one way of measuring a directory compared by BenchmarkDirSize
+type walkMethod struct {
+	name    string
+	measure func() (dirStats, error)
+}
+
+// BenchmarkDirSize measures a directory with the sequential walk, the parallel walk at the
+// configured split depth and, when available, du. Each method runs runs times and the
+// fastest run is kept; the size cache is bypassed so every run hits the filesystem.
+func BenchmarkDirSize(ctx context.Context, path string, runs int) ([]WalkTiming, error) {
+	expandedPath := ExpandHome(path)
+	if info, err := os.Stat(expandedPath); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", path)
+	}
+	if runs < 1 {
+		runs = 1
+	}
+
+	// Warm the filesystem cache so the first method isn't penalised for cold reads
+	if _, err := walkTree(ctx, expandedPath); err != nil {
+		return nil, err
+	}
+
+	methods := []walkMethod{
+		{"sequential", func() (dirStats, error) { return walkTree(ctx, expandedPath) }},
+	}
+
+	workers := int(walkWorkers.Load())
+	if splitDepth := int(walkSplitDepth.Load()); splitDepth > 0 {
+		methods = append(methods, walkMethod{
+			fmt.Sprintf("parallel (%d workers, depth %d)", workers, splitDepth),
+			func() (dirStats, error) { return walkDirParallel(ctx, expandedPath, workers, splitDepth) },
+		})
+	}
+
+	if _, err := exec.LookPath("du"); err == nil {
+		methods = append(methods, walkMethod{
+			"du -sk",
+			func() (dirStats, error) {
+				size, err := duDirSize(ctx, expandedPath)
+				return dirStats{size: size}, err
+			},
+		})
+	}
+
+	var timings []WalkTiming
+	for _, method := range methods {
+		var best WalkTiming
+		for i := 0; i < runs; i++ {
+			start := time.Now()
+			stats, err := method.measure()
+			elapsed := time.Since(start)
+			if err != nil {
+				return timings, fmt.Errorf("%s: %w", method.name, err)
+			}
+
+			if i == 0 || elapsed < best.Duration {
+				best = WalkTiming{Method: method.name, Size: stats.size, Files: stats.files, Duration: elapsed}
+			}
+		}
+		timings = append(timings, best)
+	}
+
+	return timings, nil
+}
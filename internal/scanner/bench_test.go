@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestBenchmarkDirSizeMethodsAgree(t *testing.T) {
+	root := writeModuleCache(t)
+
+	var timings []WalkTiming
+	withWalkSettings(4, DefaultParallelWalkDepth, func() {
+		var err error
+		if timings, err = BenchmarkDirSize(context.Background(), root, 2); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if len(timings) < 2 {
+		t.Fatalf("got %d timings, want the sequential and parallel walks at least", len(timings))
+	}
+	sequential := timings[0]
+	if sequential.Method != "sequential" || sequential.Files != 30*6*8 || sequential.Size != 30*6*8*512 {
+		t.Fatalf("sequential walk = %+v, want 1440 files of 512 bytes", sequential)
+	}
+	for _, timing := range timings[1:] {
+		// du reports allocated blocks, so only the walks must match exactly
+		if timing.Method != "du -sk" && (timing.Size != sequential.Size || timing.Files != sequential.Files) {
+			t.Errorf("%s = %+v, want the sequential walk's %+v", timing.Method, timing, sequential)
+		}
+	}
+
+	if _, err := BenchmarkDirSize(context.Background(), filepath.Join(root, "missing"), 1); err == nil {
+		t.Error("BenchmarkDirSize of a missing directory succeeded, want an error")
+	}
+}
+
+// BenchmarkWalkMethods times the walks _bench compares, without the size cache or
+// CalculateDirSize's bookkeeping, at several worker counts
+func BenchmarkWalkMethods(b *testing.B) {
+	root := writeModuleCache(b)
+	ctx := context.Background()
+
+	b.Run("sequential", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := walkTree(ctx, root); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("parallel/workers=%d", workers), func(b *testing.B) {
+			withWalkSettings(workers, DefaultParallelWalkDepth, func() {
+				for b.Loop() {
+					if _, err := walkDirParallel(ctx, root, workers, DefaultParallelWalkDepth); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
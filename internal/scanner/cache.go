@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SizeCache memoizes directory sizes by expanded path for the duration of a command
+type SizeCache struct {
+	mu    sync.Mutex
+	sizes map[string]dirStats
+}
+
+// dirStats holds the measured size of a directory and, when counted, its number of files
+type dirStats struct {
+	size       int64
+	files      int64
+	counted    bool  // files is only valid when the directory was walked
+	unreadable int64 // Entries the walk could not read, so size is underreported
+}
+
+// NewSizeCache creates an empty size cache
+func NewSizeCache() *SizeCache {
+	return &SizeCache{sizes: make(map[string]dirStats)}
+}
+
+// sizeCacheKey is the context key of the size cache
+type sizeCacheKey struct{}
+
+// WithSizeCache returns a copy of ctx in which CalculateDirSize memoizes results in cache
+func WithSizeCache(ctx context.Context, cache *SizeCache) context.Context {
+	return context.WithValue(ctx, sizeCacheKey{}, cache)
+}
+
+// sizeCacheFrom returns the size cache carried by ctx, or nil when sizes aren't memoized
+func sizeCacheFrom(ctx context.Context) *SizeCache {
+	cache, _ := ctx.Value(sizeCacheKey{}).(*SizeCache)
+	return cache
+}
+
+// get returns the cached stats for an expanded path
+func (c *SizeCache) get(path string) (dirStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, ok := c.sizes[path]
+	return stats, ok
+}
+
+// set stores the stats for an expanded path
+func (c *SizeCache) set(path string, stats dirStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sizes[path] = stats
+}
+
+// Invalidate drops cached sizes for a path, its ancestors and its descendants,
+// since removing anything under a directory changes all of their sizes
+func (c *SizeCache) Invalidate(path string) {
+	expandedPath := filepath.Clean(ExpandHome(path))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for cached := range c.sizes {
+		if isSameOrNested(cached, expandedPath) || isSameOrNested(expandedPath, cached) {
+			delete(c.sizes, cached)
+		}
+	}
+}
+
+// Reset drops all cached sizes
+func (c *SizeCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sizes = make(map[string]dirStats)
+}
+
+// isSameOrNested reports whether path equals parent or lives under it
+func isSameOrNested(path, parent string) bool {
+	return path == parent || strings.HasPrefix(path, parent+string(filepath.Separator))
+}
@@ -0,0 +1,104 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// crashLogPattern is a file name pattern a tool uses for crash logs and diagnostic reports
+type crashLogPattern struct {
+	Glob     string
+	Tool     string
+	Language string // Name of the language provider the tool belongs to
+	Unsafe   bool   // Worth a look before removing, like a heap dump kept for a leak investigation
+}
+
+// crashLogsWhereRun are written to the working directory of the crashed process, which is
+// most often the home directory, or the temp directory for processes started by IDEs and services.
+// The patterns are strict enough not to match the user's own files.
+var crashLogsWhereRun = []crashLogPattern{
+	{"hs_err_pid*.log", "JVM Crash Log", "Java", false},
+	{"replay_pid*.log", "JVM Compiler Replay Log", "Java", false},
+	{"java_pid*.hprof", "JVM Heap Dump", "Java", true},
+	{"report.[0-9]*.[0-9]*.[0-9]*.[0-9]*.json", "Node.js Diagnostic Report", "Node.js", false},
+	{"npm-debug.log*", "npm Debug Log", "Node.js", false},
+	{"yarn-error.log", "yarn Error Log", "Node.js", false},
+	{"rustc-ice-*.txt", "rustc Crash Report", "Rust", false},
+}
+
+// crashLogDirs are fixed directories where tools keep their own crash and debug logs
+var crashLogDirs = map[string][]crashLogPattern{
+	"~/.npm/_logs": {
+		{"*-debug-*.log", "npm Debug Log", "Node.js", false},
+	},
+	"~/Library/Logs/DiagnosticReports": {
+		{"node*.ips", "macOS Crash Report", "Node.js", false},
+		{"node*.crash", "macOS Crash Report", "Node.js", false},
+		{"[Pp]ython*.ips", "macOS Crash Report", "Python", false},
+		{"[Pp]ython*.crash", "macOS Crash Report", "Python", false},
+		{"java*.ips", "macOS Crash Report", "Java", false},
+		{"java*.crash", "macOS Crash Report", "Java", false},
+	},
+}
+
+// ScanCrashLogs finds the crash logs and diagnostic reports tools left in the home directory,
+// the temp directories and their own log directories, sorted by language, tool and path.
+// Only regular files matching a known pattern are reported; symlinks are never followed.
+func ScanCrashLogs() []TempLeftover {
+	var logs []TempLeftover
+	seen := make(map[string]bool)
+
+	add := func(dir string, patterns []crashLogPattern) {
+		for _, pattern := range patterns {
+			matches, _ := filepath.Glob(filepath.Join(dir, pattern.Glob))
+			for _, path := range matches {
+				info, err := os.Lstat(path)
+				if err != nil || !info.Mode().IsRegular() || seen[path] {
+					continue
+				}
+				seen[path] = true
+				logs = append(logs, TempLeftover{
+					Path:     path,
+					Tool:     pattern.Tool,
+					Language: pattern.Language,
+					Kind:     KindCrashLog,
+					Size:     info.Size(),
+					Modified: info.ModTime(),
+					Unsafe:   pattern.Unsafe,
+				})
+			}
+		}
+	}
+
+	if home, err := HomeDir(); err == nil {
+		add(home, crashLogsWhereRun)
+	}
+	for _, dir := range TempDirs() {
+		add(dir, crashLogsWhereRun)
+	}
+	for dir, patterns := range crashLogDirs {
+		add(ExpandHome(dir), patterns)
+	}
+
+	sortTempLeftovers(logs)
+	return logs
+}
+
+// isCrashLogName reports whether a file name in a temp directory is a crash log, which
+// ScanCrashLogs reports even when its name also starts with a temp prefix (npm-debug.log)
+func isCrashLogName(name string) bool {
+	for _, pattern := range crashLogsWhereRun {
+		if matched, _ := filepath.Match(pattern.Glob, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanTempLeftoversAndCrashLogs returns the temp leftovers and crash logs together,
+// sorted by language, tool and path
+func ScanTempLeftoversAndCrashLogs() []TempLeftover {
+	all := append(ScanTempLeftovers(), ScanCrashLogs()...)
+	sortTempLeftovers(all)
+	return all
+}
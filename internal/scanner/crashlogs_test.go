@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCrashLogsAreNotTempLeftovers(t *testing.T) {
+	t.Setenv("DHELL_HOME", t.TempDir())
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	t.Setenv("GOTMPDIR", "")
+
+	for _, name := range []string{"npm-debug.log", "npm-debug.log.1", "rustc-ice-2024-01-01T00_00_00-123.txt", "java_pid42.hprof", "hs_err_pid42.log"} {
+		if err := os.WriteFile(filepath.Join(tmp, name), []byte("crash"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(tmp, "npm-1234-abcd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// /tmp may hold the machine's own leftovers; only look at the fixture
+	inFixture := func(leftovers []TempLeftover) map[string]TempLeftover {
+		found := make(map[string]TempLeftover)
+		for _, leftover := range leftovers {
+			if strings.HasPrefix(leftover.Path, tmp) {
+				found[filepath.Base(leftover.Path)] = leftover
+			}
+		}
+		return found
+	}
+
+	leftovers := inFixture(ScanTempLeftovers())
+	if len(leftovers) != 1 || leftovers["npm-1234-abcd"].Tool != "npm" {
+		t.Errorf("ScanTempLeftovers() = %v, want only npm-1234-abcd", leftovers)
+	}
+
+	logs := inFixture(ScanCrashLogs())
+	if len(logs) != 5 {
+		t.Errorf("ScanCrashLogs() found %d crash logs, want 5: %v", len(logs), logs)
+	}
+	for name, log := range logs {
+		if wantUnsafe := name == "java_pid42.hprof"; log.Unsafe != wantUnsafe {
+			t.Errorf("%s Unsafe = %v, want %v", name, log.Unsafe, wantUnsafe)
+		}
+	}
+
+	var count int
+	for _, leftover := range ScanTempLeftoversAndCrashLogs() {
+		if strings.HasPrefix(leftover.Path, tmp) {
+			count++
+		}
+	}
+	if count != 6 {
+		t.Errorf("ScanTempLeftoversAndCrashLogs() found %d entries, want each of the 6 once", count)
+	}
+}
@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// expandCandidatePattern replaces ~ and the supported variables in a cache candidate pattern
+func expandCandidatePattern(pattern string) string {
+	expanded := os.Expand(pattern, func(name string) string {
+		switch name {
+		case "TMPDIR":
+			return os.TempDir()
+		case "XDG_CACHE_HOME":
+			return ExpandHome(XDGCacheHome())
+		case "XDG_DATA_HOME":
+			return ExpandHome(XDGDataHome())
+		}
+		return os.Getenv(name)
+	})
+	return ExpandHome(expanded)
+}
+
+// DiscoverCandidatePaths returns the existing directories matching a cache candidate pattern
+func DiscoverCandidatePaths(pattern string) []string {
+	matches, err := filepath.Glob(expandCandidatePattern(pattern))
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && info.IsDir() {
+			dirs = append(dirs, match)
+		}
+	}
+	sort.Strings(dirs)
+
+	return dirs
+}
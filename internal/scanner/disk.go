@@ -1,48 +1,166 @@
 package scanner
 
 import (
+	"context"
+	"errors"
 	"io/fs"
 	"path/filepath"
+	"sync"
+	"time"
 )
 
-// CalculateDirSize calculates the total size of a directory
-func CalculateDirSize(path string) (int64, error) {
+// unreadableEntries records, by expanded path, how many entries the last walk of a directory could not read
+var unreadableEntries sync.Map
+
+// UnreadableEntries returns how many entries could not be read (e.g., permission denied) when
+// path was last measured. A non-zero count means its size is underreported.
+func UnreadableEntries(path string) int64 {
+	if count, ok := unreadableEntries.Load(filepath.Clean(ExpandHome(path))); ok {
+		return count.(int64)
+	}
+	return 0
+}
+
+// walkTimes records, by expanded path, how long the last measurement of a directory took
+var walkTimes sync.Map
+
+// WalkTime returns how long measuring path took the last time it was measured rather than
+// served from the size cache, so scan --profile can point at the slow directories
+func WalkTime(path string) (time.Duration, bool) {
+	if elapsed, ok := walkTimes.Load(filepath.Clean(ExpandHome(path))); ok {
+		return elapsed.(time.Duration), true
+	}
+	return 0, false
+}
+
+// CalculateDirSize calculates the total size of a directory, stopping once ctx is done.
+// Results are memoized when ctx carries a size cache (see WithSizeCache). Large trees such
+// as the module cache are walked in parallel (see SetWalkWorkers and SetParallelWalkDepth),
+// so there is no separate parallel variant; unreadable entries are skipped either way.
+func CalculateDirSize(ctx context.Context, path string) (int64, error) {
+	stats, err := calculateDirStats(ctx, path, false)
+	return stats.size, err
+}
+
+// CalculateDirSizeWithCount calculates the total size of a directory and the number of files in it.
+// Counting always walks the tree, even when the du backend is enabled.
+func CalculateDirSizeWithCount(ctx context.Context, path string) (int64, int64, error) {
+	stats, err := calculateDirStats(ctx, path, true)
+	return stats.size, stats.files, err
+}
+
+// calculateDirStats measures a directory, consulting the size cache carried by ctx
+func calculateDirStats(ctx context.Context, path string, count bool) (dirStats, error) {
 	expandedPath := ExpandHome(path)
 
 	if !PathExists(expandedPath) {
-		return 0, nil
+		return dirStats{counted: true}, nil
+	}
+
+	key := filepath.Clean(expandedPath)
+	cache := sizeCacheFrom(ctx)
+	if cache != nil {
+		if stats, ok := cache.get(key); ok && (stats.counted || !count) {
+			return stats, nil
+		}
+	}
+
+	start := time.Now()
+	stats, err := measureDir(ctx, expandedPath, count)
+	if err != nil {
+		return dirStats{}, err
 	}
+	walkTimes.Store(key, time.Since(start))
 
-	var size int64
+	if stats.unreadable > 0 {
+		unreadableEntries.Store(key, stats.unreadable)
+	} else {
+		unreadableEntries.Delete(key)
+	}
+
+	if cache != nil {
+		cache.set(key, stats)
+	}
+
+	return stats, nil
+}
+
+// measureDir measures a directory with du when enabled and no file count is needed,
+// falling back to the Go walk
+func measureDir(ctx context.Context, expandedPath string, count bool) (dirStats, error) {
+	if useDu.Load() && !count {
+		if size, err := duDirSize(ctx, expandedPath); err == nil {
+			return dirStats{size: size}, nil
+		}
+	}
+	return walkDir(ctx, expandedPath)
+}
+
+// walkDir sums the sizes of all files under a directory and counts them,
+// splitting the walk across workers when more than one is available
+func walkDir(ctx context.Context, expandedPath string) (dirStats, error) {
+	workers := walkWorkersFor(expandedPath)
+	splitDepth := int(walkSplitDepth.Load())
+
+	// Deep trees exceed MAX_PATH on Windows; unreadable entries (access denied) are counted, not fatal.
+	// The extended-length prefix stays inside the walk: errors name the path without it.
+	root := longPath(expandedPath)
+	var stats dirStats
+	var err error
+	if workers > 1 && splitDepth > 0 {
+		stats, err = walkDirParallel(ctx, root, workers, splitDepth)
+	} else {
+		stats, err = walkTree(ctx, root)
+	}
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		pathErr.Path = shortPath(pathErr.Path)
+	}
+	return stats, err
+}
+
+// walkTree sums the sizes of all files under a directory and counts them on the calling goroutine
+func walkTree(ctx context.Context, expandedPath string) (dirStats, error) {
+	stats := dirStats{counted: true}
 	err := filepath.WalkDir(expandedPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
-			// Skip directories we can't access
+			// Skip directories we can't access, remembering the size is incomplete
+			stats.unreadable++
 			return nil
 		}
 
+		// Stop between directories once the scan is cancelled
+		if d.IsDir() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
 		if !d.IsDir() {
 			info, err := d.Info()
 			if err != nil {
+				stats.unreadable++
 				return nil
 			}
-			size += info.Size()
+			stats.size += info.Size()
+			stats.files++
 		}
 		return nil
 	})
 
 	if err != nil {
-		return 0, err
+		return dirStats{}, err
 	}
 
-	return size, nil
+	return stats, nil
 }
 
 // ScanMultiplePaths scans multiple paths and returns total size
-func ScanMultiplePaths(paths []string) (int64, error) {
+func ScanMultiplePaths(ctx context.Context, paths []string) (int64, error) {
 	var total int64
 
 	for _, path := range paths {
-		size, err := CalculateDirSize(path)
+		size, err := CalculateDirSize(ctx, path)
 		if err != nil {
 			// Continue on error, just skip this path
 			continue
@@ -54,11 +172,11 @@ func ScanMultiplePaths(paths []string) (int64, error) {
 }
 
 // CalculatePathSizes calculates sizes for multiple paths individually
-func CalculatePathSizes(paths map[string]string) map[string]int64 {
+func CalculatePathSizes(ctx context.Context, paths map[string]string) map[string]int64 {
 	sizes := make(map[string]int64)
 
 	for description, path := range paths {
-		size, err := CalculateDirSize(path)
+		size, err := CalculateDirSize(ctx, path)
 		if err != nil {
 			sizes[description] = 0
 			continue
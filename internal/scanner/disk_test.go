@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTree creates files of the given sizes under a new temp directory
+func writeTree(t *testing.T, sizes map[string]int) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for rel, size := range sizes {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestCalculateDirSizeUsesContextCache(t *testing.T) {
+	root := writeTree(t, map[string]int{"a": 100, "sub/b": 50})
+	ctx := WithSizeCache(context.Background(), NewSizeCache())
+
+	if size, err := CalculateDirSize(ctx, root); err != nil || size != 150 {
+		t.Fatalf("CalculateDirSize = %d, %v, want 150", size, err)
+	}
+
+	// The cached size is reused within ctx, but not by a walk without the cache
+	if err := os.WriteFile(filepath.Join(root, "c"), make([]byte, 25), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if size, _ := CalculateDirSize(ctx, root); size != 150 {
+		t.Errorf("CalculateDirSize with the cache = %d, want the cached 150", size)
+	}
+	if size, _ := CalculateDirSize(context.Background(), root); size != 175 {
+		t.Errorf("CalculateDirSize without the cache = %d, want 175", size)
+	}
+}
+
+func TestCalculateDirSizeStopsWhenCancelled(t *testing.T) {
+	root := writeTree(t, map[string]int{"a": 100, "sub/b": 50})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := CalculateDirSize(ctx, root); !errors.Is(err, context.Canceled) {
+		t.Fatalf("CalculateDirSize after cancel = %v, want context.Canceled", err)
+	}
+}
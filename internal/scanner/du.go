@@ -0,0 +1,51 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// useDu makes CalculateDirSize shell out to du when it is available
+var useDu atomic.Bool
+
+// SetUseDu enables or disables the du fast path for directory sizes.
+// du reports allocated disk blocks and counts hard links once, so its sizes
+// can differ from the apparent sizes summed by the Go walk.
+func SetUseDu(enabled bool) {
+	useDu.Store(enabled)
+}
+
+// duDirSize returns the disk usage of a directory as reported by du -sk
+func duDirSize(ctx context.Context, expandedPath string) (int64, error) {
+	if _, err := exec.LookPath("du"); err != nil {
+		return 0, err
+	}
+
+	// du exits non-zero when some entries are unreadable but still prints a total
+	out, err := CommandOutput(ctx, "du", "-sk", expandedPath)
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return 0, err
+	}
+
+	return parseDuOutput(string(out))
+}
+
+// parseDuOutput parses the kilobyte count from "<kb>\t<path>" du output
+func parseDuOutput(out string) (int64, error) {
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return 0, errors.New("empty du output")
+	}
+
+	kilobytes, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return kilobytes * 1024, nil
+}
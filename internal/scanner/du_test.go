@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+)
+
+func TestParseDuOutput(t *testing.T) {
+	if size, err := parseDuOutput("2048\t/home/dev/go/pkg/mod\n"); err != nil || size != 2048*1024 {
+		t.Errorf("parseDuOutput = %d, %v; want %d", size, err, 2048*1024)
+	}
+	for _, out := range []string{"", "du: cannot read directory\n"} {
+		if _, err := parseDuOutput(out); err == nil {
+			t.Errorf("parseDuOutput(%q) succeeded, want an error", out)
+		}
+	}
+}
+
+// BenchmarkCalculateDirSizeDu compares the Go walk with du -sk on the same tree. du is usually
+// faster, but reports allocated blocks rather than the apparent sizes the walk sums.
+func BenchmarkCalculateDirSizeDu(b *testing.B) {
+	if _, err := exec.LookPath("du"); err != nil {
+		b.Skip("du is not installed")
+	}
+	root := writeModuleCache(b)
+	ctx := context.Background()
+	defer SetUseDu(false)
+
+	for _, c := range []struct {
+		name  string
+		useDu bool
+	}{
+		{"walk", false},
+		{"du", true},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			SetUseDu(c.useDu)
+			for b.Loop() {
+				if _, err := CalculateDirSize(ctx, root); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+var (
+	execMu  sync.Mutex
+	execSem = make(chan struct{}, runtime.NumCPU())
+)
+
+// SetExecConcurrency limits how many subprocesses may run at the same time
+func SetExecConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+
+	execMu.Lock()
+	defer execMu.Unlock()
+	execSem = make(chan struct{}, n)
+}
+
+// acquireExec blocks until a subprocess slot is available and returns its release func
+func acquireExec() func() {
+	execMu.Lock()
+	sem := execSem
+	execMu.Unlock()
+
+	sem <- struct{}{}
+	return func() { <-sem }
+}
+
+// CommandOutput runs a command within the subprocess limit and returns its stdout.
+// It is a variable so tests can return canned output instead of running binaries.
+var CommandOutput = runCommandOutput
+
+// runCommandOutput runs a command within the subprocess limit and returns its stdout
+func runCommandOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	release := acquireExec()
+	defer release()
+
+	return applyOffline(exec.CommandContext(ctx, name, args...)).Output()
+}
+
+// CommandOutputWithInput runs a command within the subprocess limit, feeding stdin, and returns its stdout
+func CommandOutputWithInput(ctx context.Context, stdin []byte, name string, args ...string) ([]byte, error) {
+	release := acquireExec()
+	defer release()
+
+	cmd := applyOffline(exec.CommandContext(ctx, name, args...))
+	cmd.Stdin = bytes.NewReader(stdin)
+	return cmd.Output()
+}
+
+// RunCommand runs a command that changes something, such as a clean command, within the
+// subprocess limit and the offline environment. The command's output is added to its error.
+// It is a variable so tests can record commands instead of running them.
+var RunCommand = runCommand
+
+// runCommand runs a command within the subprocess limit, reporting its output on failure
+func runCommand(ctx context.Context, name string, args ...string) error {
+	release := acquireExec()
+	defer release()
+
+	output, err := applyOffline(exec.CommandContext(ctx, name, args...)).CombinedOutput()
+	if err != nil {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("%w (output: %s)", err, message)
+		}
+		return err
+	}
+	return nil
+}
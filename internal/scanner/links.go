@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+)
+
+// UnlinkedSize walks root and returns its total size and the size of the files whose only link
+// is the one under root. Content-addressable stores such as pnpm's hard link their files into
+// projects, so a file with a single link is used by no project on this filesystem.
+// ok is false where link counts aren't available (e.g., Windows).
+func UnlinkedSize(root string) (total, unlinked int64, ok bool) {
+	ok = true
+	filepath.WalkDir(ExpandHome(root), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		total += info.Size()
+		links, known := linkCount(info)
+		if !known {
+			ok = false
+		} else if links == 1 {
+			unlinked += info.Size()
+		}
+		return nil
+	})
+	return total, unlinked, ok
+}
+
+// FreshDirSize measures a directory without consulting the size cache, e.g. to see what a
+// command actually freed
+func FreshDirSize(ctx context.Context, path string) (int64, error) {
+	expandedPath := ExpandHome(path)
+	if !PathExists(expandedPath) {
+		return 0, nil
+	}
+
+	stats, err := measureDir(ctx, expandedPath, false)
+	return stats.size, err
+}
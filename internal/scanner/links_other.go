@@ -0,0 +1,12 @@
+//go:build !unix
+
+package scanner
+
+import (
+	"io/fs"
+)
+
+// linkCount reports link counts as unknown; FileInfo doesn't expose them on this platform
+func linkCount(info fs.FileInfo) (uint64, bool) {
+	return 0, false
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package scanner
+
+// longPath returns path unchanged; only Windows limits path length this way
+func longPath(path string) string {
+	return path
+}
+
+// shortPath returns path unchanged; see longPath
+func shortPath(path string) string {
+	return path
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// longPath gives an absolute path the extended-length prefix, so walks below it aren't cut
+// off at MAX_PATH (260 characters) in deep trees such as nested node_modules
+func longPath(path string) string {
+	if strings.HasPrefix(path, `\\?\`) || !filepath.IsAbs(path) {
+		return path
+	}
+	if strings.HasPrefix(path, `\\`) {
+		// \\server\share becomes \\?\UNC\server\share
+		return `\\?\UNC\` + filepath.Clean(path)[2:]
+	}
+	return `\\?\` + filepath.Clean(path)
+}
+
+// shortPath undoes longPath, so paths the walker hands back read the way the user wrote them
+func shortPath(path string) string {
+	if rest, ok := strings.CutPrefix(path, `\\?\UNC\`); ok {
+		return `\\` + rest
+	}
+	return strings.TrimPrefix(path, `\\?\`)
+}
@@ -0,0 +1,40 @@
+//go:build windows
+
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWalkDeepTreePastMaxPath(t *testing.T) {
+	// 12 levels of 30-character directories put the file well past MAX_PATH
+	deep := strings.Repeat(strings.Repeat("d", 30)+"/", 12) + "package.json"
+	root := writeTree(t, map[string]int{deep: 300, "top.json": 20})
+	if len(filepath.Join(root, filepath.FromSlash(deep))) <= 260 {
+		t.Fatal("fixture is not deeper than MAX_PATH")
+	}
+
+	for _, workers := range []int{1, 4} {
+		withWalkSettings(workers, DefaultParallelWalkDepth, func() {
+			stats, err := walkDir(context.Background(), root)
+			if err != nil || stats.size != 320 || stats.files != 2 || stats.unreadable != 0 {
+				t.Errorf("walkDir with %d workers = %+v, %v; want 320 bytes in 2 files", workers, stats, err)
+			}
+		})
+	}
+}
+
+func TestShortPathUndoesLongPath(t *testing.T) {
+	for _, path := range []string{`C:\Users\dev\node_modules`, `\\server\share\cache`} {
+		long := longPath(path)
+		if !strings.HasPrefix(long, `\\?\`) {
+			t.Errorf("longPath(%q) = %q, want the extended-length prefix", path, long)
+		}
+		if got := shortPath(long); got != path {
+			t.Errorf("shortPath(longPath(%q)) = %q", path, got)
+		}
+	}
+}
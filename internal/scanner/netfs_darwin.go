@@ -0,0 +1,33 @@
+//go:build darwin
+
+package scanner
+
+import (
+	"strings"
+	"syscall"
+)
+
+// networkFSTypes are the names macOS reports for network and FUSE filesystems
+var networkFSTypes = []string{"nfs", "smbfs", "afpfs", "webdav", "macfuse", "osxfuse"}
+
+// isNetworkFS reports whether path lives on a network or FUSE filesystem
+func isNetworkFS(path string) bool {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return false
+	}
+
+	var name strings.Builder
+	for _, c := range stat.Fstypename {
+		if c == 0 {
+			break
+		}
+		name.WriteByte(byte(c))
+	}
+	for _, fsType := range networkFSTypes {
+		if strings.HasPrefix(name.String(), fsType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+//go:build linux
+
+package scanner
+
+import "syscall"
+
+// networkFSMagic are the statfs magic numbers of network and FUSE filesystems
+var networkFSMagic = map[int64]bool{
+	0x6969:     true, // NFS
+	0x517B:     true, // SMB
+	0xFF534D42: true, // CIFS
+	0xFE534D42: true, // SMB2
+	0x65735546: true, // FUSE (sshfs, rclone, ...)
+	0x01021997: true, // 9P (WSL 2 Windows drives)
+}
+
+// isNetworkFS reports whether path lives on a network or FUSE filesystem
+func isNetworkFS(path string) bool {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return false
+	}
+	return networkFSMagic[int64(stat.Type)]
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package scanner
+
+// isNetworkFS reports whether path lives on a network filesystem; unknown on this platform
+func isNetworkFS(path string) bool {
+	return false
+}
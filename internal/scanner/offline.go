@@ -0,0 +1,34 @@
+package scanner
+
+import (
+	"os"
+	"os/exec"
+	"sync/atomic"
+)
+
+// offline disables every operation that may reach the network
+var offline atomic.Bool
+
+// offlineEnv keeps the tools dhell runs from downloading anything behind its back
+var offlineEnv = []string{
+	"GOTOOLCHAIN=local",         // go would otherwise fetch the toolchain a go.mod asks for
+	"COREPACK_ENABLE_NETWORK=0", // corepack shims download yarn/pnpm on first use
+	"HOMEBREW_NO_AUTO_UPDATE=1",
+	"DHELL_OFFLINE=1", // external providers should not reach the network either
+}
+
+// SetOffline enables or disables offline mode
+func SetOffline(enabled bool) {
+	offline.Store(enabled)
+}
+
+// applyOffline adds the offline environment to a command when offline mode is enabled
+func applyOffline(cmd *exec.Cmd) *exec.Cmd {
+	if offline.Load() {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, offlineEnv...)
+	}
+	return cmd
+}
@@ -0,0 +1,26 @@
+package scanner
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+)
+
+func TestRunCommandOffline(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	t.Setenv("GOTOOLCHAIN", "auto")
+	check := `test "$GOTOOLCHAIN" = local && test "$DHELL_OFFLINE" = 1`
+
+	SetOffline(true)
+	t.Cleanup(func() { SetOffline(false) })
+	if err := RunCommand(context.Background(), "sh", "-c", check); err != nil {
+		t.Errorf("RunCommand with --offline did not get the offline environment: %v", err)
+	}
+
+	SetOffline(false)
+	if err := RunCommand(context.Background(), "sh", "-c", check); err == nil {
+		t.Error("RunCommand without --offline got the offline environment")
+	}
+}
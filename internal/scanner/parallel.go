@@ -0,0 +1,173 @@
+package scanner
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultParallelWalkDepth is the directory depth at which walks are split across workers.
+// Two levels spreads caches like ~/go/pkg/mod (host/owner) and ~/.m2/repository (group parts)
+// over many similarly sized subtrees; one level often leaves a single worker with most of the tree.
+const DefaultParallelWalkDepth = 2
+
+// maxDefaultWalkWorkers caps the default worker count: past this, more concurrent
+// stats mostly make a spinning disk seek instead of making the walk faster
+const maxDefaultWalkWorkers = 8
+
+// networkWalkWorkers is the most workers used by default on network and FUSE filesystems,
+// where every stat is a round trip and parallel walks mostly queue up behind each other
+const networkWalkWorkers = 2
+
+// networkFS reports whether a path lives on a network filesystem; tests replace it
+var networkFS = isNetworkFS
+
+var (
+	walkWorkers    atomic.Int32
+	walkWorkersSet atomic.Bool // Set by the user, so network filesystems don't reduce it
+	walkSplitDepth atomic.Int32
+
+	// walkSlots bounds the subtrees measured at once across all walks. Providers are scanned
+	// concurrently, so each walk's workers share these slots instead of adding up per provider.
+	walkSlots atomic.Pointer[chan struct{}]
+)
+
+func init() {
+	setWalkWorkers(DefaultWalkWorkers(), false)
+	walkSplitDepth.Store(DefaultParallelWalkDepth)
+}
+
+// DefaultWalkWorkers returns the default size-walk worker count: one per CPU, capped
+func DefaultWalkWorkers() int {
+	return min(runtime.NumCPU(), maxDefaultWalkWorkers)
+}
+
+// SetWalkWorkers sets how many workers measure subtrees in parallel, in total across the
+// walks of concurrently scanned providers. 1 walks sequentially. Unlike the default, an
+// explicit count is used on network filesystems too.
+func SetWalkWorkers(workers int) {
+	setWalkWorkers(workers, true)
+}
+
+// setWalkWorkers sets the worker count, remembering whether the user chose it
+func setWalkWorkers(workers int, explicit bool) {
+	if workers < 1 {
+		workers = 1
+	}
+	slots := make(chan struct{}, workers)
+	walkSlots.Store(&slots)
+	walkWorkers.Store(int32(workers))
+	walkWorkersSet.Store(explicit)
+}
+
+// walkWorkersFor returns the worker count to use for root. The default is reduced on
+// network filesystems; a count the user set is not.
+func walkWorkersFor(root string) int {
+	workers := int(walkWorkers.Load())
+	if !walkWorkersSet.Load() && workers > networkWalkWorkers && networkFS(root) {
+		return networkWalkWorkers
+	}
+	return workers
+}
+
+// SetParallelWalkDepth sets how many directory levels the walker descends before
+// handing subtrees to workers. 0 disables the parallel walk.
+func SetParallelWalkDepth(depth int) {
+	if depth < 0 {
+		depth = 0
+	}
+	walkSplitDepth.Store(int32(depth))
+}
+
+// walkDirParallel walks the first splitDepth levels of a directory itself and
+// measures every directory at that depth on a pool of workers
+func walkDirParallel(ctx context.Context, root string, workers, splitDepth int) (dirStats, error) {
+	stats := dirStats{counted: true}
+	var subtrees []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Skip directories we can't access, remembering the size is incomplete
+			stats.unreadable++
+			return nil
+		}
+
+		if d.IsDir() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if path != root && walkDepth(root, path) >= splitDepth {
+				subtrees = append(subtrees, path)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			stats.unreadable++
+			return nil
+		}
+		stats.size += info.Size()
+		stats.files++
+		return nil
+	})
+	if err != nil {
+		return dirStats{}, err
+	}
+
+	if workers > len(subtrees) {
+		workers = len(subtrees)
+	}
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		jobs  = make(chan string)
+		slots = *walkSlots.Load()
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				slots <- struct{}{}
+				sub, err := walkTree(ctx, path)
+				<-slots
+				if err != nil {
+					continue
+				}
+				mu.Lock()
+				stats.size += sub.size
+				stats.files += sub.files
+				stats.unreadable += sub.unreadable
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, path := range subtrees {
+		jobs <- path
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Subtrees stopped early are incomplete, so the total is too
+	if err := ctx.Err(); err != nil {
+		return dirStats{}, err
+	}
+
+	return stats, nil
+}
+
+// walkDepth returns how many levels below root path is
+func walkDepth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
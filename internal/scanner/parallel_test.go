@@ -0,0 +1,169 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// writeModuleCache creates a tree shaped like ~/go/pkg/mod: a few hosts, one of which
+// (like github.com) holds most owners, each with a handful of modules of small files
+func writeModuleCache(tb testing.TB) string {
+	tb.Helper()
+
+	root := tb.TempDir()
+	for host, owners := range map[string]int{"github.com": 24, "golang.org": 2, "gopkg.in": 4} {
+		for owner := 0; owner < owners; owner++ {
+			for module := 0; module < 6; module++ {
+				dir := filepath.Join(root, host, fmt.Sprintf("owner%d", owner), fmt.Sprintf("module%d@v1.0.0", module))
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					tb.Fatal(err)
+				}
+				for file := 0; file < 8; file++ {
+					if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d.go", file)), make([]byte, 512), 0644); err != nil {
+						tb.Fatal(err)
+					}
+				}
+			}
+		}
+	}
+	return root
+}
+
+// withWalkSettings runs f with the given workers and split depth, restoring the defaults after
+func withWalkSettings(workers, depth int, f func()) {
+	defer setWalkWorkers(DefaultWalkWorkers(), false)
+	defer SetParallelWalkDepth(DefaultParallelWalkDepth)
+
+	SetWalkWorkers(workers)
+	SetParallelWalkDepth(depth)
+	f()
+}
+
+// BenchmarkCalculateDirSize compares the sequential walk with the parallel walk at several split
+// depths, and several providers walking at once. Depth 1 leaves one worker with github.com; depth 2
+// spreads the owners over the workers, which is why it is the default. Concurrent walks share
+// the workers, so scanning providers at once doesn't start workers per provider.
+func BenchmarkCalculateDirSize(b *testing.B) {
+	root := writeModuleCache(b)
+	ctx := context.Background()
+	workers := DefaultWalkWorkers()
+
+	cases := []struct {
+		name    string
+		workers int
+		depth   int
+	}{
+		{"sequential", 1, 0},
+		{"parallel/depth=1", workers, 1},
+		{"parallel/depth=2", workers, 2},
+		{"parallel/depth=3", workers, 3},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			withWalkSettings(c.workers, c.depth, func() {
+				for b.Loop() {
+					if _, err := CalculateDirSize(ctx, root); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+
+	b.Run("parallel/4 providers", func(b *testing.B) {
+		withWalkSettings(workers, DefaultParallelWalkDepth, func() {
+			for b.Loop() {
+				var wg sync.WaitGroup
+				for range 4 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if _, err := CalculateDirSize(ctx, root); err != nil {
+							b.Error(err)
+						}
+					}()
+				}
+				wg.Wait()
+			}
+		})
+	})
+}
+
+// BenchmarkCalculateDirSizeWorkers measures the parallel walk with growing worker counts
+// at the default split depth; 1 worker is the sequential walk
+func BenchmarkCalculateDirSizeWorkers(b *testing.B) {
+	root := writeModuleCache(b)
+	ctx := context.Background()
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			withWalkSettings(workers, DefaultParallelWalkDepth, func() {
+				for b.Loop() {
+					if _, err := CalculateDirSize(ctx, root); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestParallelWalkMatchesSequential(t *testing.T) {
+	root := writeModuleCache(t)
+
+	var want dirStats
+	withWalkSettings(1, 0, func() {
+		var err error
+		if want, err = walkDir(context.Background(), root); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	for _, depth := range []int{1, 2, 3, 5} {
+		withWalkSettings(4, depth, func() {
+			got, err := walkDir(context.Background(), root)
+			if err != nil || got.size != want.size || got.files != want.files {
+				t.Errorf("depth %d: walk = %+v, %v; want %+v", depth, got, err, want)
+			}
+		})
+	}
+}
+
+func TestWalkWorkersOnNetworkFS(t *testing.T) {
+	root := writeModuleCache(t)
+	t.Cleanup(func() { networkFS = isNetworkFS })
+	networkFS = func(string) bool { return true }
+
+	want, err := walkTree(context.Background(), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		set  func()
+		want int
+	}{
+		{"default is capped", func() { setWalkWorkers(8, false) }, networkWalkWorkers},
+		{"--concurrency or config is respected", func() { SetWalkWorkers(8) }, 8},
+		{"explicit 1 stays sequential", func() { SetWalkWorkers(1) }, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer setWalkWorkers(DefaultWalkWorkers(), false)
+			tt.set()
+
+			if got := walkWorkersFor(root); got != tt.want {
+				t.Errorf("walkWorkersFor() = %d, want %d", got, tt.want)
+			}
+			got, err := walkDir(context.Background(), root)
+			if err != nil || got.size != want.size || got.files != want.files {
+				t.Errorf("walkDir() = %+v, %v; want %+v", got, err, want)
+			}
+		})
+	}
+}
@@ -1,16 +1,40 @@
 package scanner
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
-// ExpandHome expands the ~ in a path to the user's home directory
+// HomeDir returns the home directory providers look in: DHELL_HOME when set, so tests can
+// point dhell at a fixture tree, the user's home directory otherwise
+func HomeDir() (string, error) {
+	if home := os.Getenv("DHELL_HOME"); home != "" {
+		return home, nil
+	}
+	return os.UserHomeDir()
+}
+
+// ProtectedHomeDirs returns the directories a removal must never take out: the home directory
+// providers look in and, since DHELL_HOME may point anywhere, always the user's real home too
+func ProtectedHomeDirs() []string {
+	var dirs []string
+	for _, lookup := range []func() (string, error){HomeDir, os.UserHomeDir} {
+		if dir, err := lookup(); err == nil && dir != "" && !slices.Contains(dirs, filepath.Clean(dir)) {
+			dirs = append(dirs, filepath.Clean(dir))
+		}
+	}
+	return dirs
+}
+
+// ExpandHome expands the ~ in a path to the home directory
 func ExpandHome(path string) string {
 	if strings.HasPrefix(path, "~/") {
-		home, err := os.UserHomeDir()
+		home, err := HomeDir()
 		if err != nil {
 			return path
 		}
@@ -26,16 +50,99 @@ func PathExists(path string) bool {
 	return err == nil
 }
 
-// FindExecutable finds an executable in the system PATH
-func FindExecutable(name string) (string, error) {
-	return exec.LookPath(name)
+// FindExecutable finds an executable in the system PATH.
+// It is a variable so tests can stub out the lookup.
+var FindExecutable = exec.LookPath
+
+// FindFirstExecutable finds the first of several candidate names in the system PATH,
+// e.g. python3 then python, and returns its path and the name that was found
+func FindFirstExecutable(names ...string) (string, string, error) {
+	var firstErr error
+	for _, name := range names {
+		path, err := FindExecutable(name)
+		if err == nil {
+			return path, name, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		firstErr = fmt.Errorf("no executable names given")
+	}
+	return "", "", firstErr
 }
 
-// GetExecutableVersion runs a command to get version information
-func GetExecutableVersion(executable string, args ...string) (string, error) {
-	cmd := exec.Command(executable, args...)
+// FindAllExecutables finds every distinct copy of an executable across all PATH directories.
+// Entries resolving to the same real file are reported once, in PATH order.
+func FindAllExecutables(name string) []string {
+	var found []string
+	seen := make(map[string]bool)
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+
+		candidate := filepath.Join(dir, name)
+		info, err := os.Stat(candidate)
+		if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+			continue
+		}
+
+		realPath, err := ResolveSymlink(candidate)
+		if err != nil {
+			realPath = candidate
+		}
+		if seen[realPath] {
+			continue
+		}
+		seen[realPath] = true
+
+		found = append(found, candidate)
+	}
+
+	return found
+}
+
+// SamePath reports whether two paths refer to the same file or directory after resolving symlinks
+func SamePath(a, b string) bool {
+	a, b = ExpandHome(a), ExpandHome(b)
+	if resolved, err := filepath.EvalSymlinks(a); err == nil {
+		a = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(b); err == nil {
+		b = resolved
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// PathIndex returns the position of a directory in PATH, or -1 if it is not on PATH
+func PathIndex(dir string) int {
+	for i, entry := range filepath.SplitList(os.Getenv("PATH")) {
+		if entry != "" && SamePath(entry, dir) {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetExecutableVersion runs a command to get version information.
+// It is a variable so tests can return canned version output instead of running binaries.
+var GetExecutableVersion = runVersionCommand
+
+// runVersionCommand runs executable with args and returns its trimmed combined output
+func runVersionCommand(ctx context.Context, executable string, args ...string) (string, error) {
+	release := acquireExec()
+	defer release()
+
+	cmd := applyOffline(exec.CommandContext(ctx, executable, args...))
 	output, err := cmd.CombinedOutput()
 	if err != nil {
+		// A killed process only says "signal: killed"; report the cancellation instead
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("%w: %w", ctx.Err(), err)
+		}
 		return "", err
 	}
 	return strings.TrimSpace(string(output)), nil
@@ -46,7 +153,109 @@ func ResolveSymlink(path string) (string, error) {
 	return filepath.EvalSymlinks(path)
 }
 
+// maxSymlinkHops bounds SymlinkChain to avoid looping on cyclic links
+const maxSymlinkHops = 40
+
+// SymlinkChain follows a symlink hop by hop and returns every path visited,
+// starting with path itself and ending with the final non-symlink file
+func SymlinkChain(path string) ([]string, error) {
+	chain := []string{path}
+
+	current := path
+	for i := 0; i < maxSymlinkHops; i++ {
+		info, err := os.Lstat(current)
+		if err != nil {
+			return chain, err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return chain, nil
+		}
+
+		target, err := os.Readlink(current)
+		if err != nil {
+			return chain, err
+		}
+
+		// Relative targets are relative to the link's directory
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(current), target)
+		}
+
+		current = target
+		chain = append(chain, current)
+	}
+
+	return chain, fmt.Errorf("too many levels of symbolic links: %s", path)
+}
+
 // GetEnvVar gets an environment variable value
 func GetEnvVar(name string) string {
 	return os.Getenv(name)
 }
+
+// XDGCacheHome returns $XDG_CACHE_HOME, or ~/.cache when it is unset or not absolute.
+// Like the other defaults providers return, the fallback keeps its ~ for display.
+func XDGCacheHome() string {
+	return xdgHome("XDG_CACHE_HOME", "~/.cache")
+}
+
+// XDGDataHome returns $XDG_DATA_HOME, or ~/.local/share when it is unset or not absolute
+func XDGDataHome() string {
+	return xdgHome("XDG_DATA_HOME", "~/.local/share")
+}
+
+// XDGStateHome returns $XDG_STATE_HOME, or ~/.local/state when it is unset or not absolute
+func XDGStateHome() string {
+	return xdgHome("XDG_STATE_HOME", "~/.local/state")
+}
+
+// XDGConfigHome returns $XDG_CONFIG_HOME, or ~/.config when it is unset or not absolute
+func XDGConfigHome() string {
+	return xdgHome("XDG_CONFIG_HOME", "~/.config")
+}
+
+// xdgHome reads an XDG base directory variable. The spec says relative values are invalid
+// and must be ignored, so they fall back to the default like an unset variable.
+func xdgHome(name, fallback string) string {
+	if dir := GetEnvVar(name); filepath.IsAbs(dir) {
+		return dir
+	}
+	return fallback
+}
+
+// HomebrewPrefixes maps each Homebrew prefix to the architecture it serves
+var HomebrewPrefixes = map[string]string{
+	"/opt/homebrew": "arm64",
+	"/usr/local":    "x86_64",
+}
+
+// HomebrewArch returns the architecture of the Homebrew prefix a resolved path lives under
+func HomebrewArch(path string) string {
+	if strings.HasPrefix(path, "/opt/homebrew/") {
+		return "arm64"
+	}
+	if strings.HasPrefix(path, "/usr/local/Cellar/") || strings.HasPrefix(path, "/usr/local/Homebrew/") {
+		return "x86_64"
+	}
+	return ""
+}
+
+// FindHomebrewInstalls looks for a binary in every Homebrew prefix and returns
+// the resolved path keyed by architecture
+func FindHomebrewInstalls(binary string) map[string]string {
+	installs := make(map[string]string)
+
+	for prefix := range HomebrewPrefixes {
+		binPath := filepath.Join(prefix, "bin", binary)
+		realPath, err := ResolveSymlink(binPath)
+		if err != nil {
+			continue
+		}
+
+		if arch := HomebrewArch(realPath); arch != "" {
+			installs[arch] = realPath
+		}
+	}
+
+	return installs
+}
@@ -0,0 +1,26 @@
+package scanner
+
+import "testing"
+
+func TestXDGHomes(t *testing.T) {
+	abs := t.TempDir()
+	tests := []struct {
+		name   string
+		value  string
+		lookup func() string
+		want   string
+	}{
+		{"XDG_CACHE_HOME", abs, XDGCacheHome, abs},
+		{"XDG_CACHE_HOME", "", XDGCacheHome, "~/.cache"},
+		{"XDG_CACHE_HOME", "relative/cache", XDGCacheHome, "~/.cache"},
+		{"XDG_DATA_HOME", abs, XDGDataHome, abs},
+		{"XDG_DATA_HOME", "", XDGDataHome, "~/.local/share"},
+		{"XDG_DATA_HOME", "relative/data", XDGDataHome, "~/.local/share"},
+	}
+	for _, tt := range tests {
+		t.Setenv(tt.name, tt.value)
+		if got := tt.lookup(); got != tt.want {
+			t.Errorf("%s=%q: got %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package scanner
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// skippedProjectDirs are never descended into by project walks
+var skippedProjectDirs = map[string]bool{
+	".git":         true,
+	".hg":          true,
+	".svn":         true,
+	"node_modules": true,
+}
+
+// WalkProject calls visit for every entry under root, skipping VCS metadata and node_modules,
+// until ctx is done. visit may return filepath.SkipDir to skip a directory.
+func WalkProject(ctx context.Context, root string, visit func(path string, d fs.DirEntry) error) error {
+	return filepath.WalkDir(ExpandHome(root), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Skip directories we can't access
+			return nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if d.IsDir() && skippedProjectDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+
+		return visit(path, d)
+	})
+}
+
+// DirContains reports whether dir directly contains an entry matching any of the glob patterns
+func DirContains(dir string, patterns ...string) bool {
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err == nil && len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// FileSize returns the size of a file, or 0 if it can't be read
+func FileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
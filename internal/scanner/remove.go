@@ -0,0 +1,93 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrRefusedRemoval is returned by RemoveDir for paths it will never remove
+var ErrRefusedRemoval = errors.New("refusing to remove")
+
+// RemoveDir expands ~ in path and removes it with everything inside. It refuses paths that
+// can only come from a bug in an item, such as an empty path, a bare ~ (which expands to the
+// home directory), a relative path, a filesystem root, or the home directory or one of its parents.
+// Read-only entries, such as those in the Go module cache, are made writable and the removal retried.
+func RemoveDir(path string) error {
+	expandedPath, err := checkRemovablePath(path)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(expandedPath); err == nil {
+		return nil
+	}
+
+	// Entries can't be unlinked from a directory without write permission
+	makeWritable(expandedPath)
+	err = os.RemoveAll(expandedPath)
+	if err != nil && isGoModuleCache(expandedPath) {
+		return fmt.Errorf("%w (the Go module cache is read-only by design; run `go clean -modcache` instead)", err)
+	}
+	return err
+}
+
+// makeWritable adds owner write permission to everything under root, and search permission
+// to directories, so RemoveAll can unlink their entries (Windows also refuses read-only files)
+func makeWritable(root string) {
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		perm := info.Mode().Perm()
+		switch {
+		case d.IsDir() && perm&0700 != 0700:
+			os.Chmod(path, perm|0700)
+		case d.Type().IsRegular() && perm&0200 == 0:
+			os.Chmod(path, perm|0200)
+		}
+		return nil
+	})
+}
+
+// isGoModuleCache reports whether path is or lives in a Go module cache (GOMODCACHE, pkg/mod)
+func isGoModuleCache(path string) bool {
+	if modCache := GetEnvVar("GOMODCACHE"); modCache != "" {
+		if SamePath(path, modCache) || strings.HasPrefix(path, filepath.Clean(modCache)+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return strings.Contains(filepath.ToSlash(path)+"/", "/pkg/mod/") ||
+		PathExists(filepath.Join(path, "cache", "download"))
+}
+
+// checkRemovablePath expands path and rejects the targets RemoveDir must never remove
+func checkRemovablePath(path string) (string, error) {
+	if path == "" || path == "~" || path == "~/" {
+		return "", fmt.Errorf("%w %q: no cache directory given", ErrRefusedRemoval, path)
+	}
+
+	expandedPath := filepath.Clean(ExpandHome(path))
+	if !filepath.IsAbs(expandedPath) {
+		return "", fmt.Errorf("%w relative path %s", ErrRefusedRemoval, path)
+	}
+	if filepath.Dir(expandedPath) == expandedPath {
+		return "", fmt.Errorf("%w filesystem root %s", ErrRefusedRemoval, expandedPath)
+	}
+	for _, home := range ProtectedHomeDirs() {
+		if SamePath(expandedPath, home) {
+			return "", fmt.Errorf("%w your home directory %s", ErrRefusedRemoval, expandedPath)
+		}
+		if strings.HasPrefix(home, expandedPath+string(filepath.Separator)) {
+			return "", fmt.Errorf("%w %s: it contains your home directory", ErrRefusedRemoval, expandedPath)
+		}
+	}
+
+	return expandedPath, nil
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemoveDirReadOnlyTree(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "pkg", "mod")
+	module := filepath.Join(root, "example.com", "lib@v1.0.0")
+	if err := os.MkdirAll(module, 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(module, "lib.go")
+	if err := os.WriteFile(file, []byte("package lib\n"), 0444); err != nil {
+		t.Fatal(err)
+	}
+
+	// Like go mod download, leave the files and directories read-only
+	for _, dir := range []string{module, filepath.Dir(module)} {
+		if err := os.Chmod(dir, 0555); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() { makeWritable(root) })
+
+	if err := RemoveDir(root); err != nil {
+		t.Fatalf("RemoveDir(%s) = %v", root, err)
+	}
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Fatalf("%s still exists (stat error %v)", root, err)
+	}
+}
+
+func TestRemoveDirMissing(t *testing.T) {
+	if err := RemoveDir(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Fatalf("RemoveDir of a missing directory = %v, want nil", err)
+	}
+}
+
+func TestRemoveDirRefusesUnsafePaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("DHELL_HOME", home)
+
+	for _, path := range []string{"", "~", "~/", "~/.", "relative/cache", "/", filepath.Dir(home)} {
+		if err := RemoveDir(path); !errors.Is(err, ErrRefusedRemoval) {
+			t.Errorf("RemoveDir(%q) = %v, want ErrRefusedRemoval", path, err)
+		}
+	}
+	if _, err := os.Stat(home); err != nil {
+		t.Fatalf("home directory was touched: %v", err)
+	}
+}
+
+func TestRemoveDirProtectsRealHome(t *testing.T) {
+	realHome, err := os.UserHomeDir()
+	if err != nil || filepath.Dir(realHome) == realHome {
+		t.Skip("no real home directory to protect")
+	}
+	t.Setenv("DHELL_HOME", "/")
+
+	for _, path := range []string{realHome, filepath.Dir(realHome)} {
+		if err := RemoveDir(path); !errors.Is(err, ErrRefusedRemoval) {
+			t.Errorf("RemoveDir(%q) with DHELL_HOME=/ = %v, want ErrRefusedRemoval", path, err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package scanner
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// tempPrefix is a name prefix that tools use for their entries in the temp directory
+type tempPrefix struct {
+	Prefix   string
+	Tool     string
+	Language string // Name of the language provider the tool belongs to
+}
+
+// tempPrefixes lists the temp entries dhell recognizes. Only entries directly in a temp
+// directory and starting with one of these prefixes are ever reported or removed.
+var tempPrefixes = []tempPrefix{
+	{"go-build", "go build", "Golang"},
+	{"go-link-", "go link", "Golang"},
+	{"npm-", "npm", "Node.js"},
+	{"yarn--", "yarn", "Node.js"},
+	{"pip-", "pip", "Python"},
+	{"python-build.", "pyenv python-build", "Python"},
+	{"cargo-install", "cargo install", "Rust"},
+	{"rustc", "rustc", "Rust"},
+}
+
+// Kinds of TempLeftover
+const (
+	KindTempLeftover = "temp"
+	KindCrashLog     = "crash-log"
+)
+
+// TempLeftover is a recognizable entry a tool left in the temp directory, or a crash log
+// or diagnostic report it left in the home or temp directory
+type TempLeftover struct {
+	Path     string
+	Tool     string
+	Language string
+	Kind     string // KindTempLeftover or KindCrashLog
+	Size     int64
+	Modified time.Time // Newest modification time of the entry or anything inside it
+	Unsafe   bool      // Worth a look before removing (see crashLogPattern)
+}
+
+// Age returns how long ago the leftover was last modified
+func (l TempLeftover) Age() time.Duration {
+	return time.Since(l.Modified)
+}
+
+// TempDirs returns the temp directories to look in: $TMPDIR, /tmp and GOTMPDIR if set
+func TempDirs() []string {
+	candidates := []string{os.TempDir()}
+	if runtime.GOOS != "windows" {
+		candidates = append(candidates, "/tmp")
+	}
+	if dir := os.Getenv("GOTMPDIR"); dir != "" {
+		candidates = append(candidates, dir)
+	}
+
+	var dirs []string
+	for _, candidate := range candidates {
+		duplicate := false
+		for _, dir := range dirs {
+			if SamePath(candidate, dir) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate && PathExists(candidate) {
+			dirs = append(dirs, candidate)
+		}
+	}
+	return dirs
+}
+
+// ScanTempLeftovers finds the recognizable tool leftovers in the temp directories,
+// sorted by language, tool and path. Symlinks are never followed or reported.
+func ScanTempLeftovers() []TempLeftover {
+	var leftovers []TempLeftover
+	for _, dir := range TempDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			prefix, ok := matchTempPrefix(entry.Name())
+			if !ok || entry.Type()&fs.ModeSymlink != 0 || isCrashLogName(entry.Name()) {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			size, modified := tempEntryStats(path)
+			leftovers = append(leftovers, TempLeftover{
+				Path:     path,
+				Tool:     prefix.Tool,
+				Language: prefix.Language,
+				Kind:     KindTempLeftover,
+				Size:     size,
+				Modified: modified,
+			})
+		}
+	}
+
+	sortTempLeftovers(leftovers)
+	return leftovers
+}
+
+// sortTempLeftovers sorts leftovers by language, tool and path
+func sortTempLeftovers(leftovers []TempLeftover) {
+	sort.Slice(leftovers, func(i, j int) bool {
+		a, b := leftovers[i], leftovers[j]
+		if a.Language != b.Language {
+			return a.Language < b.Language
+		}
+		if a.Tool != b.Tool {
+			return a.Tool < b.Tool
+		}
+		return a.Path < b.Path
+	})
+}
+
+// matchTempPrefix returns the tool a temp entry name belongs to
+func matchTempPrefix(name string) (tempPrefix, bool) {
+	for _, prefix := range tempPrefixes {
+		if strings.HasPrefix(name, prefix.Prefix) {
+			return prefix, true
+		}
+	}
+	return tempPrefix{}, false
+}
+
+// tempEntryStats returns the size of a temp entry and the newest modification time within it.
+// A tool still writing into an old directory keeps it recent, so it isn't considered stale.
+func tempEntryStats(root string) (int64, time.Time) {
+	var size int64
+	var newest time.Time
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, newest
+}
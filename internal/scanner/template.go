@@ -0,0 +1,148 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// pathTemplates holds the configured cache path templates by provider and path key
+var pathTemplates atomic.Pointer[map[string]map[string]string]
+
+// SetPathTemplates sets the cache path templates providers consult before their defaults,
+// e.g. {"rust": {"cargo_registry": "${CARGO_HOME}/registry"}}
+func SetPathTemplates(templates map[string]map[string]string) {
+	pathTemplates.Store(&templates)
+}
+
+// ConfiguredPath returns the expanded template configured for a provider's path key.
+// Templates that fail to expand are ignored so the provider falls back to its default.
+func ConfiguredPath(provider, key string) (string, bool) {
+	templates := pathTemplates.Load()
+	if templates == nil {
+		return "", false
+	}
+
+	template, ok := (*templates)[provider][key]
+	if !ok {
+		return "", false
+	}
+
+	path, err := ExpandTemplate(template)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// templateVariables are the {name} placeholders supported in path templates
+var templateVariables = map[string]func() string{
+	"home":       func() string { return ExpandHome("~/") },
+	"xdg_cache":  func() string { return ExpandHome(XDGCacheHome()) },
+	"xdg_data":   func() string { return ExpandHome(XDGDataHome()) },
+	"xdg_config": func() string { return ExpandHome(XDGConfigHome()) },
+	"xdg_state":  func() string { return ExpandHome(XDGStateHome()) },
+	"tmp":        os.TempDir,
+}
+
+// ExpandTemplate expands a cache path template. It supports a leading ~, the placeholders
+// {home}, {xdg_cache}, {xdg_data}, {xdg_config}, {xdg_state} and {tmp}, and environment
+// references $VAR, ${VAR} and ${VAR:-default}. Unknown placeholders and unset variables
+// without a default are errors rather than silently empty path segments.
+func ExpandTemplate(template string) (string, error) {
+	var expanded strings.Builder
+
+	for i := 0; i < len(template); i++ {
+		switch template[i] {
+		case '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end == -1 {
+				return "", fmt.Errorf("unclosed { in %q", template)
+			}
+			name := template[i+1 : i+end]
+			variable, ok := templateVariables[name]
+			if !ok {
+				return "", fmt.Errorf("unknown placeholder {%s} in %q", name, template)
+			}
+			expanded.WriteString(variable())
+			i += end
+
+		case '$':
+			value, length, err := expandEnvRef(template[i:])
+			if err != nil {
+				return "", fmt.Errorf("%v in %q", err, template)
+			}
+			expanded.WriteString(value)
+			i += length - 1
+
+		default:
+			expanded.WriteByte(template[i])
+		}
+	}
+
+	path := ExpandHome(expanded.String())
+	if path == "~" {
+		path = ExpandHome("~/")
+	}
+	return filepath.Clean(path), nil
+}
+
+// expandEnvRef expands the $VAR, ${VAR} or ${VAR:-default} reference at the start of ref
+// and returns its value and how many bytes of ref it spans
+func expandEnvRef(ref string) (string, int, error) {
+	if strings.HasPrefix(ref, "${") {
+		end := closingBrace(ref, 1)
+		if end == -1 {
+			return "", 0, fmt.Errorf("unclosed ${")
+		}
+		name, fallback, hasFallback := strings.Cut(ref[2:end], ":-")
+		if value := os.Getenv(name); value != "" {
+			return value, end + 1, nil
+		}
+		if hasFallback {
+			expanded, err := ExpandTemplate(fallback)
+			return expanded, end + 1, err
+		}
+		return "", 0, fmt.Errorf("$%s is not set", name)
+	}
+
+	length := 1
+	for length < len(ref) && isEnvNameByte(ref[length]) {
+		length++
+	}
+	if length == 1 {
+		return "", 0, fmt.Errorf("$ without a variable name")
+	}
+
+	name := ref[1:length]
+	value := os.Getenv(name)
+	if value == "" {
+		return "", 0, fmt.Errorf("$%s is not set", name)
+	}
+	return value, length, nil
+}
+
+// closingBrace returns the index of the } matching the { at open, allowing nested
+// placeholders in defaults such as ${CARGO_HOME:-{home}/.cargo}
+func closingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isEnvNameByte reports whether c can appear in an environment variable name
+func isEnvNameByte(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
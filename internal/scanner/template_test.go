@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("DHELL_HOME", home)
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("CARGO_HOME", "/opt/cargo")
+	t.Setenv("DHELL_UNSET", "")
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"$CARGO_HOME/registry", "/opt/cargo/registry"},
+		{"${CARGO_HOME}/registry", "/opt/cargo/registry"},
+		{"${CARGO_HOME:-/unused}/registry", "/opt/cargo/registry"},
+		{"${DHELL_UNSET:-/srv/cache}/registry", "/srv/cache/registry"},
+		{"${DHELL_UNSET:-{home}/.cargo}/registry", filepath.Join(home, ".cargo", "registry")},
+		{"{home}/.m2", filepath.Join(home, ".m2")},
+		{"{xdg_cache}/maven", filepath.Join(ExpandHome(XDGCacheHome()), "maven")},
+		{"~/.npm", filepath.Join(home, ".npm")},
+		{"~", home},
+		{"/data/./pnpm-store/", "/data/pnpm-store"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandTemplate(tt.template)
+		if err != nil || got != filepath.Clean(filepath.FromSlash(tt.want)) {
+			t.Errorf("ExpandTemplate(%q) = %q, %v; want %q", tt.template, got, err, tt.want)
+		}
+	}
+}
+
+func TestExpandTemplateErrors(t *testing.T) {
+	t.Setenv("DHELL_HOME", t.TempDir())
+	t.Setenv("DHELL_UNSET", "")
+
+	for _, template := range []string{
+		"{nope}/cache",         // Unknown placeholder
+		"{home/cache",          // Unclosed placeholder
+		"$DHELL_UNSET/cache",   // Unset variable
+		"${DHELL_UNSET}/cache", // Unset variable without a default
+		"${DHELL_UNSET:-{nope}}",
+		"${CARGO_HOME",
+		"$/cache",
+	} {
+		if got, err := ExpandTemplate(template); err == nil {
+			t.Errorf("ExpandTemplate(%q) = %q, want an error", template, got)
+		}
+	}
+}
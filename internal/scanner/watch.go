@@ -0,0 +1,175 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// errNothingWatched is returned when no path could be registered for notifications
+var errNothingWatched = errors.New("no paths could be watched")
+
+// WatchEvent represents a significant size change of a watched directory
+type WatchEvent struct {
+	Path    string
+	OldSize int64
+	NewSize int64
+}
+
+// Delta returns the size change in bytes
+func (e WatchEvent) Delta() int64 {
+	return e.NewSize - e.OldSize
+}
+
+// Watcher reports size changes of cache directories
+type Watcher struct {
+	Paths     []string
+	Interval  time.Duration // Polling interval, also used when events are unavailable
+	Debounce  time.Duration // Quiet period after the last event before re-measuring
+	Threshold int64         // Minimum absolute size change to report
+	UseEvents bool          // Use filesystem notifications instead of polling
+}
+
+// Run watches the paths until ctx is done, calling onChange for significant changes.
+// In event mode it falls back to polling when filesystem notifications are unsupported.
+func (w *Watcher) Run(ctx context.Context, onChange func(WatchEvent)) error {
+	stop := ctx.Done()
+	sizes := make(map[string]int64)
+	for _, path := range w.Paths {
+		sizes[path], _ = CalculateDirSize(ctx, path)
+	}
+
+	measure := func(path string) {
+		newSize, err := CalculateDirSize(ctx, path)
+		if err != nil {
+			return
+		}
+
+		oldSize := sizes[path]
+		delta := newSize - oldSize
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta < w.Threshold || delta == 0 {
+			return
+		}
+
+		sizes[path] = newSize
+		onChange(WatchEvent{Path: path, OldSize: oldSize, NewSize: newSize})
+	}
+
+	if w.UseEvents {
+		if notifier, err := w.newNotifier(); err == nil {
+			defer notifier.Close()
+			return w.runEvents(stop, notifier, measure)
+		}
+	}
+
+	return w.runPolling(stop, measure)
+}
+
+// newNotifier creates a filesystem watcher on each path and its direct subdirectories
+func (w *Watcher) newNotifier() (*fsnotify.Watcher, error) {
+	notifier, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	watched := 0
+	for _, path := range w.Paths {
+		expandedPath := ExpandHome(path)
+		if err := notifier.Add(expandedPath); err != nil {
+			continue
+		}
+		watched++
+
+		entries, err := os.ReadDir(expandedPath)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				_ = notifier.Add(filepath.Join(expandedPath, entry.Name()))
+			}
+		}
+	}
+
+	if watched == 0 {
+		notifier.Close()
+		return nil, errNothingWatched
+	}
+
+	return notifier, nil
+}
+
+// runEvents re-measures a path once its events have been quiet for the debounce period
+func (w *Watcher) runEvents(stop <-chan struct{}, notifier *fsnotify.Watcher, measure func(string)) error {
+	dirty := make(map[string]bool)
+	timer := time.NewTimer(w.Debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case event, ok := <-notifier.Events:
+			if !ok {
+				return nil
+			}
+			// New directories are watched too, so files created deep inside them are noticed
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = notifier.Add(event.Name)
+				}
+			}
+			if root := w.rootOf(event.Name); root != "" {
+				dirty[root] = true
+				timer.Reset(w.Debounce)
+			}
+		case err, ok := <-notifier.Errors:
+			if !ok {
+				return nil
+			}
+			return err
+		case <-timer.C:
+			for path := range dirty {
+				measure(path)
+			}
+			dirty = make(map[string]bool)
+		}
+	}
+}
+
+// runPolling re-measures every path on each interval
+func (w *Watcher) runPolling(stop <-chan struct{}, measure func(string)) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			for _, path := range w.Paths {
+				measure(path)
+			}
+		}
+	}
+}
+
+// rootOf returns the watched path that contains the given file, at any depth.
+// When watched paths are nested, the innermost one is returned.
+func (w *Watcher) rootOf(name string) string {
+	var root, rootDir string
+	for _, path := range w.Paths {
+		expandedPath := filepath.Clean(ExpandHome(path))
+		if len(expandedPath) > len(rootDir) && isSameOrNested(name, expandedPath) {
+			root, rootDir = path, expandedPath
+		}
+	}
+	return root
+}
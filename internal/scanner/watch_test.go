@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherRootOf(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "cache")
+	w := &Watcher{Paths: []string{root, nested}}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{root, root},
+		{filepath.Join(root, "a", "b", "c", "d", "file"), root},
+		{filepath.Join(nested, "v3", "files", "ab", "cd"), nested},
+		{root + "-other", ""},
+		{filepath.Dir(root), ""},
+	}
+	for _, tt := range tests {
+		if got := w.rootOf(tt.name); got != tt.want {
+			t.Errorf("rootOf(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWatcherEventsInNewDirectories(t *testing.T) {
+	root := t.TempDir()
+	w := &Watcher{Paths: []string{root}, Interval: time.Hour, Debounce: 20 * time.Millisecond, Threshold: 1, UseEvents: true}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan WatchEvent, 1)
+	go w.Run(ctx, func(event WatchEvent) {
+		select {
+		case events <- event:
+		default:
+		}
+	})
+
+	// Each directory is created after the watcher started, so only its Create event gets it watched
+	deep := root
+	for _, name := range []string{"new", "deeper", "deepest"} {
+		time.Sleep(100 * time.Millisecond)
+		deep = filepath.Join(deep, name)
+		if err := os.Mkdir(deep, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(deep, "file"), make([]byte, 4096), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Path != root || event.NewSize != 4096 {
+			t.Errorf("event = %+v, want %s growing to 4096 bytes", event, root)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event for a file written three new directories deep")
+	}
+}
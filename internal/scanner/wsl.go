@@ -0,0 +1,89 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	wslOnce     sync.Once
+	wslDetected bool
+)
+
+// IsWSL reports whether dhell is running inside the Windows Subsystem for Linux
+func IsWSL() bool {
+	wslOnce.Do(func() {
+		data, err := os.ReadFile("/proc/version")
+		wslDetected = err == nil && strings.Contains(strings.ToLower(string(data)), "microsoft")
+	})
+	return wslDetected
+}
+
+// IsWindowsMount reports whether path lives on a Windows drive mounted by WSL (e.g., /mnt/c/...)
+func IsWindowsMount(path string) bool {
+	rest, ok := strings.CutPrefix(path, "/mnt/")
+	if !ok || len(rest) == 0 {
+		return false
+	}
+	drive := rest[0]
+	if !('a' <= drive && drive <= 'z') {
+		return false
+	}
+	return len(rest) == 1 || rest[1] == '/'
+}
+
+// windowsExeNames maps Linux binary names to their Windows executable when it isn't name.exe
+var windowsExeNames = map[string]string{
+	"python3": "python.exe",
+}
+
+// windowsInstallPatterns are the default Windows install locations of each binary, as seen from WSL
+var windowsInstallPatterns = map[string][]string{
+	"go":      {"/mnt/c/Program Files/Go/bin/go.exe"},
+	"node":    {"/mnt/c/Program Files/nodejs/node.exe"},
+	"java":    {"/mnt/c/Program Files/Java/*/bin/java.exe", "/mnt/c/Program Files/Eclipse Adoptium/*/bin/java.exe"},
+	"python3": {"/mnt/c/Users/*/AppData/Local/Programs/Python/Python*/python.exe"},
+	"php":     {"/mnt/c/php/php.exe", "/mnt/c/tools/php*/php.exe"},
+	"rustc":   {"/mnt/c/Users/*/.cargo/bin/rustc.exe"},
+}
+
+// FindWindowsExecutables finds the Windows-side copies of a binary when running under WSL.
+// It checks the Windows directories WSL appends to PATH and the default install locations.
+func FindWindowsExecutables(name string) []string {
+	if !IsWSL() {
+		return nil
+	}
+
+	exe := windowsExeNames[name]
+	if exe == "" {
+		exe = name + ".exe"
+	}
+
+	var candidates []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if IsWindowsMount(dir) {
+			candidates = append(candidates, filepath.Join(dir, exe))
+		}
+	}
+	for _, pattern := range windowsInstallPatterns[name] {
+		matches, _ := filepath.Glob(pattern)
+		candidates = append(candidates, matches...)
+	}
+
+	var found []string
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			found = append(found, candidate)
+		}
+	}
+
+	return found
+}
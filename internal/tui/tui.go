@@ -0,0 +1,337 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/lock"
+	"dependency-hell-cli/internal/output"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Loader scans the environment and returns one result per provider
+type Loader func(ctx context.Context) []output.ScanResult
+
+// ItemAdjuster adjusts a provider's cleanable items before cleaning and returns warnings to show
+type ItemAdjuster func(providerName string, items []core.CleanableItem) []string
+
+var (
+	cursorStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#7D56F4"))
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888"))
+
+	messageStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFA500"))
+)
+
+// scanDoneMsg is sent when a scan has finished
+type scanDoneMsg struct {
+	results []output.ScanResult
+}
+
+// cleanPlanMsg is sent when the items a clean would remove have been gathered
+type cleanPlanMsg struct {
+	provider   core.LanguageProvider
+	items      []core.CleanableItem // Items safe by default
+	overridden []core.CleanableItem // Items unsafe by default but marked safe in the config
+	skipped    int                  // Unsafe items, left for the clean command
+	warnings   []string
+	err        error
+}
+
+// cleanDoneMsg is sent when cleaning a language has finished
+type cleanDoneMsg struct {
+	language string
+	result   *core.CleanResult
+	skipped  int
+	err      error
+}
+
+// model holds the TUI state
+type model struct {
+	ctx        context.Context // Bounds the scans and cleans started from the TUI
+	load       Loader
+	adjust     ItemAdjuster
+	results    []output.ScanResult
+	cursor     int
+	expanded   map[int]bool
+	loading    bool
+	confirming bool
+	cleaning   bool          // A clean is being planned, confirmed or run; no other may start
+	plan       *cleanPlanMsg // Clean waiting for the overridden items to be confirmed
+	message    string
+}
+
+// Run starts the interactive TUI. adjust may be nil.
+func Run(ctx context.Context, load Loader, adjust ItemAdjuster) error {
+	m := model{
+		ctx:      ctx,
+		load:     load,
+		adjust:   adjust,
+		expanded: make(map[int]bool),
+		loading:  true,
+	}
+
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+// Init starts the initial scan
+func (m model) Init() tea.Cmd {
+	return m.scan()
+}
+
+// scan runs the loader in the background
+func (m model) scan() tea.Cmd {
+	return func() tea.Msg {
+		var installed []output.ScanResult
+		for _, result := range m.load(m.ctx) {
+			if result.Error == nil {
+				installed = append(installed, result)
+			}
+		}
+		return scanDoneMsg{results: installed}
+	}
+}
+
+// planClean gathers the safe items of a provider in the background, keeping apart
+// those only safe because the config says so
+func planClean(ctx context.Context, provider core.LanguageProvider, adjust ItemAdjuster) tea.Cmd {
+	return func() tea.Msg {
+		items, err := provider.GetCleanableItems(ctx)
+		if err != nil {
+			return cleanPlanMsg{provider: provider, err: err}
+		}
+
+		safeByDefault := make([]bool, len(items))
+		for i, item := range items {
+			safeByDefault[i] = item.Safe
+		}
+
+		plan := cleanPlanMsg{provider: provider}
+		if adjust != nil {
+			plan.warnings = adjust(provider.Name(), items)
+		}
+
+		// Only safe items are cleaned inline; unsafe ones need the clean command
+		for i, item := range items {
+			switch {
+			case !item.Safe:
+				plan.skipped++
+			case !safeByDefault[i]:
+				plan.overridden = append(plan.overridden, item)
+			default:
+				plan.items = append(plan.items, item)
+			}
+		}
+		return plan
+	}
+}
+
+// clean cleans the items of a plan in the background
+func clean(ctx context.Context, plan cleanPlanMsg) tea.Cmd {
+	return func() tea.Msg {
+		language := plan.provider.Name()
+
+		// Refuse to run alongside a dhell clean in another terminal
+		cleanLock, err := lock.Acquire(lock.DefaultPath())
+		if errors.Is(err, lock.ErrLocked) {
+			return cleanDoneMsg{language: language, err: errors.New("another clean is already running")}
+		}
+		if err != nil {
+			return cleanDoneMsg{language: language, err: err}
+		}
+		defer cleanLock.Release()
+
+		result, err := plan.provider.Clean(ctx, plan.items, false)
+		return cleanDoneMsg{
+			language: language,
+			result:   result,
+			skipped:  plan.skipped,
+			err:      err,
+		}
+	}
+}
+
+// Update handles key presses and background results
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case scanDoneMsg:
+		m.loading = false
+		m.results = msg.results
+		if m.cursor >= len(m.results) {
+			m.cursor = 0
+		}
+		return m, nil
+
+	case cleanPlanMsg:
+		if msg.err != nil {
+			m.cleaning = false
+			m.message = fmt.Sprintf("Error cleaning %s: %v", msg.provider.Name(), msg.err)
+			return m, nil
+		}
+		// Items made safe by the config are only cleaned once confirmed here too
+		if len(msg.overridden) > 0 {
+			m.plan = &msg
+			m.message = "⚠️  " + strings.Join(msg.warnings, "\n⚠️  ") + fmt.Sprintf("\nAlso clean these %d item(s)? [y/N]", len(msg.overridden))
+			return m, nil
+		}
+		return m, clean(m.ctx, msg)
+
+	case cleanDoneMsg:
+		m.cleaning = false
+		m.message = renderCleanMessage(msg)
+		m.loading = true
+		return m, m.scan()
+
+	case tea.KeyMsg:
+		if m.plan != nil {
+			plan := *m.plan
+			m.plan = nil
+			if msg.String() == "y" {
+				plan.items = append(plan.items, plan.overridden...)
+			} else {
+				plan.skipped += len(plan.overridden)
+			}
+			m.message = fmt.Sprintf("Cleaning %s...", plan.provider.Name())
+			return m, clean(m.ctx, plan)
+		}
+
+		if m.confirming {
+			m.confirming = false
+			if msg.String() == "y" && len(m.results) > 0 {
+				provider := m.results[m.cursor].Provider
+				m.cleaning = true
+				m.message = fmt.Sprintf("Cleaning %s...", provider.Name())
+				return m, planClean(m.ctx, provider, m.adjust)
+			}
+			m.message = "Cleaning cancelled."
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.results)-1 {
+				m.cursor++
+			}
+		case "enter", " ", "right", "l":
+			m.expanded[m.cursor] = !m.expanded[m.cursor]
+		case "c":
+			if !m.loading && !m.cleaning && len(m.results) > 0 {
+				m.confirming = true
+				m.message = fmt.Sprintf("Clean safe caches for %s? [y/N]", m.results[m.cursor].Provider.Name())
+			}
+		case "r":
+			if !m.loading && !m.cleaning {
+				m.loading = true
+				m.message = ""
+				return m, m.scan()
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the TUI
+func (m model) View() string {
+	var view strings.Builder
+
+	view.WriteString(output.HeaderStyle.Render("Dependency Hell Analyzer") + "\n\n")
+
+	if m.loading {
+		view.WriteString("  Scanning development environment...\n")
+	} else if len(m.results) == 0 {
+		view.WriteString("  No languages detected in your environment.\n")
+	}
+
+	if !m.loading {
+		for i, result := range m.results {
+			view.WriteString(m.renderRow(i, result))
+		}
+	}
+
+	if m.message != "" {
+		view.WriteString("\n" + messageStyle.Render(m.message) + "\n")
+	}
+
+	view.WriteString("\n" + helpStyle.Render("↑/↓ navigate • enter expand • c clean • r rescan • q quit") + "\n")
+
+	return view.String()
+}
+
+// renderRow renders a language row and, if expanded, its cache breakdown
+func (m model) renderRow(index int, result output.ScanResult) string {
+	var row strings.Builder
+
+	installation := result.Installations[0]
+	status := core.DetermineStatus(installation.Source)
+
+	pointer := "  "
+	marker := "▸"
+	if m.expanded[index] {
+		marker = "▾"
+	}
+	if index == m.cursor {
+		pointer = cursorStyle.Render("❯ ")
+	}
+
+	var total int64
+	if result.DiskUsage != nil {
+		total = result.DiskUsage.Total
+	}
+
+	line := fmt.Sprintf("%s %s %-10s %-14s %-17s %s",
+		marker,
+		status.GetStatusIcon(),
+		result.Provider.Name(),
+		installation.Version,
+		installation.Source,
+		output.FormatBytes(total))
+	if index == m.cursor {
+		line = cursorStyle.Render(line)
+	}
+	row.WriteString(pointer + line + "\n")
+
+	if m.expanded[index] && result.DiskUsage != nil {
+		for _, item := range result.DiskUsage.Items {
+			desc := fmt.Sprintf("      ↳ %s: %s", item.Description, output.FormatBytes(item.Size))
+			row.WriteString(output.DiskUsageDescStyle.Render(desc) + "\n")
+		}
+	}
+
+	return row.String()
+}
+
+// renderCleanMessage summarizes a finished clean
+func renderCleanMessage(msg cleanDoneMsg) string {
+	if msg.err != nil {
+		return fmt.Sprintf("Error cleaning %s: %v", msg.language, msg.err)
+	}
+
+	text := fmt.Sprintf("Cleaned %d item(s) for %s, reclaimed %s",
+		msg.result.ItemsCleaned,
+		msg.language,
+		output.FormatBytes(msg.result.SpaceReclaimed))
+	if msg.skipped > 0 {
+		text += fmt.Sprintf(" (%d unsafe item(s) skipped; use dhell clean)", msg.skipped)
+	}
+	if len(msg.result.Errors) > 0 {
+		text += fmt.Sprintf(", %d error(s)", len(msg.result.Errors))
+	}
+	return text
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	"dependency-hell-cli/internal/core"
+	"dependency-hell-cli/internal/output"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeProvider is a language provider with nothing to clean
+type fakeProvider struct{}
+
+func (fakeProvider) Name() string { return "Test" }
+
+func (fakeProvider) DetectInstalled(context.Context) ([]core.Installation, error) { return nil, nil }
+
+func (fakeProvider) GetGlobalCacheUsage(context.Context) (*core.DiskUsage, error) {
+	return &core.DiskUsage{}, nil
+}
+
+func (fakeProvider) GetEnvVars(context.Context) map[string]string { return nil }
+
+func (fakeProvider) GetCleanableItems(context.Context) ([]core.CleanableItem, error) {
+	return nil, nil
+}
+
+func (fakeProvider) Clean(context.Context, []core.CleanableItem, bool) (*core.CleanResult, error) {
+	return &core.CleanResult{}, nil
+}
+
+// press sends a key to the model and returns the updated model
+func press(t *testing.T, m model, key string) (model, tea.Cmd) {
+	t.Helper()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	return updated.(model), cmd
+}
+
+func TestCleanKeyDisabledWhileCleaning(t *testing.T) {
+	m := model{
+		ctx:      context.Background(),
+		expanded: make(map[int]bool),
+		results:  []output.ScanResult{{Provider: fakeProvider{}, Installations: []core.Installation{{Version: "1.0.0"}}}},
+	}
+
+	m, _ = press(t, m, "c")
+	m, cmd := press(t, m, "y")
+	if !m.cleaning || cmd == nil {
+		t.Fatalf("confirming a clean: cleaning = %v, cmd = %v; want a clean in progress", m.cleaning, cmd)
+	}
+
+	// Neither a second clean nor a rescan may start before the first one reports back
+	for _, key := range []string{"c", "r"} {
+		if m, cmd = press(t, m, key); m.confirming || m.loading || cmd != nil {
+			t.Errorf("%q during a clean: confirming = %v, loading = %v, cmd = %v", key, m.confirming, m.loading, cmd)
+		}
+	}
+
+	// A plan waiting for the overridden items keeps the clean in progress
+	updated, _ := m.Update(cleanPlanMsg{provider: fakeProvider{}, overridden: []core.CleanableItem{{Description: "Cache"}}})
+	m = updated.(model)
+	if !m.cleaning || m.plan == nil {
+		t.Fatalf("after the plan: cleaning = %v, plan = %v; want the plan awaiting confirmation", m.cleaning, m.plan)
+	}
+	if m, _ = press(t, m, "n"); !m.cleaning {
+		t.Error("declining the overridden items ended the clean before it ran")
+	}
+
+	updated, _ = m.Update(cleanDoneMsg{language: "Test", result: &core.CleanResult{}})
+	m = updated.(model)
+	if m.cleaning {
+		t.Fatal("still cleaning after the clean finished")
+	}
+
+	// Once the rescan it started is done, cleaning is allowed again
+	updated, _ = m.Update(scanDoneMsg{results: m.results})
+	if m, _ = press(t, updated.(model), "c"); !m.confirming {
+		t.Error("c after the clean finished didn't ask to confirm")
+	}
+}